@@ -2,4 +2,12 @@
 DELETE FROM posts;
 
 INSERT INTO posts (id, title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at) VALUES 
-(UUID_TO_BIN('01234567-89ab-cdef-0123-456789abcdef'), 'Test Post Title', 'This is a test post body content for integration testing. It has enough content to pass the 100 character minimum requirement for validation.', 'published', NULL, 'general', '["test", "integration"]', NULL, 'Test post for integration testing', 'test-post-title', FALSE, FALSE, FALSE, '2024-01-01 10:00:00', '2024-01-01 10:00:00');
\ No newline at end of file
+(UUID_TO_BIN('01234567-89ab-cdef-0123-456789abcdef'), 'Test Post Title', 'This is a test post body content for integration testing. It has enough content to pass the 100 character minimum requirement for validation.', 'published', NULL, 'general', '["test", "integration"]', NULL, 'Test post for integration testing', 'test-post-title', FALSE, FALSE, FALSE, '2024-01-01 10:00:00', '2024-01-01 10:00:00');
+
+-- Users for local login (password: password123)
+DELETE FROM users;
+
+INSERT INTO users (id, email, password_hash, role, created_at) VALUES
+(UUID_TO_BIN('0f000000-0000-4000-8000-000000000001'), 'admin@example.com', '$2a$10$q15I44We7z40YhYjFoe7OuRJeQQiqeEYRiRQw3OiDkBn64hSi5MWy', 'admin', '2024-01-01 09:00:00'),
+(UUID_TO_BIN('0f000000-0000-4000-8000-000000000002'), 'editor@example.com', '$2a$10$q15I44We7z40YhYjFoe7OuRJeQQiqeEYRiRQw3OiDkBn64hSi5MWy', 'editor', '2024-01-01 09:00:00'),
+(UUID_TO_BIN('0f000000-0000-4000-8000-000000000003'), 'general@example.com', '$2a$10$q15I44We7z40YhYjFoe7OuRJeQQiqeEYRiRQw3OiDkBn64hSi5MWy', 'general', '2024-01-01 09:00:00');
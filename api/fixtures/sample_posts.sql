@@ -71,4 +71,12 @@ INSERT INTO posts (id, title, body, status, scheduled_at, category, tags, featur
  'Critical security vulnerability in Go - immediate update required', 
  'critical-security-update-required', 
  TRUE, TRUE, TRUE, 
- '2024-02-01 08:00:00', '2024-02-01 08:00:00');
\ No newline at end of file
+ '2024-02-01 08:00:00', '2024-02-01 08:00:00');
+
+-- Users for local login (password: password123)
+DELETE FROM users;
+
+INSERT INTO users (id, email, password_hash, role, created_at) VALUES
+(UUID_TO_BIN('0f000000-0000-4000-8000-000000000001'), 'admin@example.com', '$2a$10$q15I44We7z40YhYjFoe7OuRJeQQiqeEYRiRQw3OiDkBn64hSi5MWy', 'admin', '2024-01-01 09:00:00'),
+(UUID_TO_BIN('0f000000-0000-4000-8000-000000000002'), 'editor@example.com', '$2a$10$q15I44We7z40YhYjFoe7OuRJeQQiqeEYRiRQw3OiDkBn64hSi5MWy', 'editor', '2024-01-01 09:00:00'),
+(UUID_TO_BIN('0f000000-0000-4000-8000-000000000003'), 'general@example.com', '$2a$10$q15I44We7z40YhYjFoe7OuRJeQQiqeEYRiRQw3OiDkBn64hSi5MWy', 'general', '2024-01-01 09:00:00');
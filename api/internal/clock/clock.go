@@ -0,0 +1,21 @@
+package clock
+
+import "time"
+
+// Clock abstracts time so background workers can be driven by tests without
+// waiting for real time to pass.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// System is the Clock backed by the time package.
+type System struct{}
+
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+func (System) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
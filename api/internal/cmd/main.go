@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"expvar"
 	"log/slog"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/di"
 	"github.com/ss49919201/myblog/api/internal/server"
+	"github.com/ss49919201/myblog/api/internal/server/middleware"
 )
 
 func init() {
@@ -15,12 +24,107 @@ func init() {
 
 func main() {
 	r := gin.Default()
+	r.Use(middleware.ErrorHandler())
 	s := server.NewServer()
-	
-	openapi.RegisterHandlers(r, s)
-	
-	if err := r.Run(":8080"); err != nil {
+
+	container := di.NewContainer()
+
+	tokens, err := container.TokenManager()
+	if err != nil {
+		slog.Error("Failed to initialize token manager", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	sched, err := container.Scheduler()
+	if err != nil {
+		slog.Error("Failed to initialize scheduler", "error", err)
+		os.Exit(1)
+	}
+	go sched.Run(ctx)
+
+	purger, err := container.Purger()
+	if err != nil {
+		slog.Error("Failed to initialize trash purger", "error", err)
+		os.Exit(1)
+	}
+	go purger.Run(ctx)
+
+	relay, err := container.OutboxRelay()
+	if err != nil {
+		slog.Error("Failed to initialize outbox relay", "error", err)
+		os.Exit(1)
+	}
+	relayDone := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(relayDone)
+	}()
+
+	webhooks, err := container.WebhookWorker()
+	if err != nil {
+		slog.Error("Failed to initialize webhook worker", "error", err)
+		os.Exit(1)
+	}
+	go webhooks.Run(ctx)
+
+	snsWorker, err := container.SNSWorker()
+	if err != nil {
+		slog.Error("Failed to initialize SNS worker", "error", err)
+		os.Exit(1)
+	}
+	go snsWorker.Run(ctx)
+
+	bus, err := container.EventBus()
+	if err != nil {
+		slog.Error("Failed to initialize event bus", "error", err)
+		os.Exit(1)
+	}
+	expvar.Publish("eventBus", expvar.Func(func() any { return bus.Metrics() }))
+
+	// expvar はメモリ統計なども公開するため、API とは別のポートで待ち受ける
+	adminAddr := os.Getenv("ADMIN_ADDR")
+	if adminAddr == "" {
+		adminAddr = "localhost:6060"
+	}
+	admin := &http.Server{Addr: adminAddr, Handler: expvar.Handler()}
+	go func() {
+		if err := admin.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("Failed to start admin server", "error", err)
+		}
+	}()
+
+	openapi.RegisterHandlersWithOptions(r, s, openapi.GinServerOptions{
+		Middlewares: []openapi.MiddlewareFunc{middleware.Authenticate(tokens)},
+	})
+
+	srv := &http.Server{Addr: ":8080", Handler: r}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
 		slog.Error("Failed to start server", "error", err)
 		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	// 受付を止めてからリレーの終了を待ち、最後にイベントバスを空にする
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to shut down server", "error", err)
+	}
+	if err := admin.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Failed to shut down admin server", "error", err)
+	}
+	<-relayDone
+	if err := bus.Close(shutdownCtx); err != nil {
+		slog.Error("Failed to drain event bus", "error", err)
 	}
+	slog.Info("Server stopped")
 }
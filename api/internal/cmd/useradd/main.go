@@ -0,0 +1,48 @@
+// Command useradd registers a user who can log in to the API.
+//
+//	go run ./api/internal/cmd/useradd -email editor@example.com -role editor
+//
+// The password is read from the USERADD_PASSWORD environment variable so that
+// it does not end up in the shell history.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ss49919201/myblog/api/internal/post/di"
+	"github.com/ss49919201/myblog/api/internal/user/entity/user"
+	userusecase "github.com/ss49919201/myblog/api/internal/user/usecase"
+)
+
+func main() {
+	email := flag.String("email", "", "email address used to log in")
+	role := flag.String("role", string(user.RoleGeneral), "general, editor or admin")
+	flag.Parse()
+
+	password := os.Getenv("USERADD_PASSWORD")
+	if *email == "" || password == "" {
+		fmt.Fprintln(os.Stderr, "usage: USERADD_PASSWORD=... useradd -email <email> [-role <role>]")
+		os.Exit(2)
+	}
+
+	uc, err := di.NewContainer().RegisterUserUsecase()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to get usecase: %v\n", err)
+		os.Exit(1)
+	}
+
+	output, err := uc.Execute(context.Background(), userusecase.RegisterUserInput{
+		Email:    *email,
+		Password: password,
+		Role:     user.Role(*role),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to register user: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("registered %s (%s) as %s\n", output.User.Email, output.User.ID, output.User.Role)
+}
@@ -0,0 +1,44 @@
+// Package markdown renders post bodies written in Markdown to HTML.
+//
+// The dialect is CommonMark with the GitHub Flavored Markdown extensions
+// (tables, strikethrough, autolinks and task lists) and footnotes. Fenced
+// code blocks are rendered as <pre><code class="language-xxx"> so that the
+// site can highlight them by their language. Line breaks between Japanese
+// characters do not add spaces.
+//
+// Raw HTML is passed through as written; callers must sanitize the result
+// before showing it to readers.
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+var converter = goldmark.New(
+	goldmark.WithExtensions(
+		// GFM。表の揃えは style 属性ではなく align 属性で出力する
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+		extension.TaskList,
+		extension.Footnote,
+		extension.CJK,
+	),
+	goldmark.WithRendererOptions(
+		html.WithUnsafe(),
+	),
+)
+
+// Render converts src to HTML.
+func Render(src string) (string, error) {
+	var buf bytes.Buffer
+	if err := converter.Convert([]byte(src), &buf); err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	return buf.String(), nil
+}
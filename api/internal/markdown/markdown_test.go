@@ -0,0 +1,37 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "heading", in: "# 見出し", want: []string{"<h1>見出し</h1>"}},
+		{name: "japanese line break", in: "本文の\n続き", want: []string{"<p>本文の続き</p>"}},
+		{name: "table", in: "| a | b |\n|:--|--:|\n| 1 | 2 |", want: []string{"<table>", `<th align="left">a</th>`, `<td align="right">2</td>`}},
+		{name: "task list", in: "- [x] done\n- [ ] todo", want: []string{`<input checked="" disabled="" type="checkbox"> done`, `<input disabled="" type="checkbox"> todo`}},
+		{name: "footnote", in: "本文[^1]\n\n[^1]: 注釈", want: []string{`<a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a>`, `<li id="fn:1">`}},
+		{name: "fenced code", in: "```go\nfmt.Println(\"<x>\")\n```", want: []string{`<pre><code class="language-go">fmt.Println(&quot;&lt;x&gt;&quot;)`}},
+		{name: "strikethrough", in: "~~old~~", want: []string{"<del>old</del>"}},
+		{name: "autolink", in: "see https://example.com", want: []string{`<a href="https://example.com">https://example.com</a>`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Render(%q) = %q, want it to contain %q", tt.in, got, want)
+				}
+			}
+		})
+	}
+}
@@ -12,18 +12,90 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for BodyFormat.
+const (
+	Html     BodyFormat = "html"
+	Markdown BodyFormat = "markdown"
+	Plain    BodyFormat = "plain"
+)
+
+// Defines values for DiffMode.
+const (
+	Line DiffMode = "line"
+	Word DiffMode = "word"
+)
+
+// Defines values for DiffOp.
+const (
+	Delete DiffOp = "delete"
+	Equal  DiffOp = "equal"
+	Insert DiffOp = "insert"
+)
+
+// Defines values for PostSort.
+const (
+	PostSortCreatedAt        PostSort = "createdAt"
+	PostSortMinusCreatedAt   PostSort = "-createdAt"
+	PostSortMinusPublishedAt PostSort = "-publishedAt"
+	PostSortPublishedAt      PostSort = "publishedAt"
+)
+
+// Defines values for PublicPostSort.
+const (
+	PublicPostSortMinusPublishedAt PublicPostSort = "-publishedAt"
+	PublicPostSortPublishedAt      PublicPostSort = "publishedAt"
+)
+
 // Defines values for PublicationStatus.
 const (
+	Archived  PublicationStatus = "archived"
 	Draft     PublicationStatus = "draft"
 	Published PublicationStatus = "published"
 	Scheduled PublicationStatus = "scheduled"
 )
 
-// Defines values for UserRole.
+// Defines values for ReviewAction.
+const (
+	Approve ReviewAction = "approve"
+	Reject  ReviewAction = "reject"
+	Submit  ReviewAction = "submit"
+)
+
+// Defines values for ReviewStatus.
+const (
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusNone     ReviewStatus = "none"
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+// Defines values for RuleField.
 const (
-	Admin   UserRole = "admin"
-	Editor  UserRole = "editor"
-	General UserRole = "general"
+	FeaturedImageUrl RuleField = "featuredImageUrl"
+	MetaDescription  RuleField = "metaDescription"
+	ScheduledAt      RuleField = "scheduledAt"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "dead"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Defines values for Weekday.
+const (
+	Fri Weekday = "fri"
+	Mon Weekday = "mon"
+	Sat Weekday = "sat"
+	Sun Weekday = "sun"
+	Thu Weekday = "thu"
+	Tue Weekday = "tue"
+	Wed Weekday = "wed"
 )
 
 // AnalyzeResult defines model for AnalyzeResult.
@@ -32,20 +104,81 @@ type AnalyzeResult struct {
 	Id       string `json:"id"`
 }
 
+// BodyFormat Markup the body of a post is written in
+type BodyFormat string
+
 // CreatePostRequest defines model for CreatePostRequest.
 type CreatePostRequest struct {
-	Body                 string            `json:"body"`
-	Category             string            `json:"category"`
-	EmergencyFlag        bool              `json:"emergencyFlag"`
-	ExternalNotification bool              `json:"externalNotification"`
-	FeaturedImageURL     *string           `json:"featuredImageURL"`
-	MetaDescription      *string           `json:"metaDescription"`
-	ScheduledAt          *time.Time        `json:"scheduledAt"`
-	Slug                 *string           `json:"slug"`
-	SnsAutoPost          bool              `json:"snsAutoPost"`
-	Status               PublicationStatus `json:"status"`
-	Tags                 []string          `json:"tags"`
-	Title                string            `json:"title"`
+	Body string `json:"body"`
+
+	// BodyFormat Markup of body. Defaults to markdown.
+	BodyFormat           *BodyFormat `json:"bodyFormat,omitempty"`
+	Category             string      `json:"category"`
+	EmergencyFlag        bool        `json:"emergencyFlag"`
+	ExternalNotification bool        `json:"externalNotification"`
+	FeaturedImageURL     *string     `json:"featuredImageURL"`
+	MetaDescription      *string     `json:"metaDescription"`
+	ScheduledAt          *time.Time  `json:"scheduledAt"`
+
+	// Slug URL slug of lowercase letters, digits and hyphens. Generated from the title when null or empty.
+	Slug        *string           `json:"slug"`
+	SnsAutoPost bool              `json:"snsAutoPost"`
+	Status      PublicationStatus `json:"status"`
+	Tags        []string          `json:"tags"`
+
+	// Title Lengths count Unicode characters after NFC normalization, not bytes
+	Title string `json:"title"`
+}
+
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	EventTypes []string `json:"eventTypes"`
+
+	// Secret Shared secret used to sign deliveries with HMAC-SHA256. At least 16 characters.
+	Secret string `json:"secret"`
+	Url    string `json:"url"`
+}
+
+// DiffEdit A run of text kept, inserted or deleted
+type DiffEdit struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// DiffMode defines model for DiffMode.
+type DiffMode string
+
+// DiffOp defines model for DiffOp.
+type DiffOp string
+
+// EditorialRuleSet The rules in effect. A category's rules are overlaid on the global ones.
+type EditorialRuleSet struct {
+	Categories map[string]EditorialRules `json:"categories"`
+
+	// Global Editorial rules of one scope. An absent rule is not set at this scope.
+	Global EditorialRules `json:"global"`
+}
+
+// EditorialRules Editorial rules of one scope. An absent rule is not set at this scope.
+type EditorialRules struct {
+	// DailyQuota Posts that can be scheduled for the same day in a category
+	DailyQuota *int32 `json:"dailyQuota,omitempty"`
+
+	// LeadTimeMinutes How far in the future a post must be scheduled
+	LeadTimeMinutes *int32 `json:"leadTimeMinutes,omitempty"`
+
+	// MaxExternalLinks Bodies with more external links are rejected
+	MaxExternalLinks *int32 `json:"maxExternalLinks,omitempty"`
+	MinTags          *int32 `json:"minTags,omitempty"`
+
+	// PublishWindow Not applied to emergency posts
+	PublishWindow *PublishWindow `json:"publishWindow,omitempty"`
+
+	// RequiredFields Fields that must be present for a post to be saved
+	RequiredFields *[]RuleField `json:"requiredFields,omitempty"`
+
+	// ReviewExternalLinks Posts with at least this many external links need approval before they are scheduled or published
+	ReviewExternalLinks *int32 `json:"reviewExternalLinks,omitempty"`
 }
 
 // Error defines model for Error.
@@ -54,33 +187,76 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// LoginRequest defines model for LoginRequest.
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginResponse defines model for LoginResponse.
+type LoginResponse struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	Token     string    `json:"token"`
+}
+
 // Post defines model for Post.
 type Post struct {
-	Body                 string            `json:"body"`
-	Category             string            `json:"category"`
-	CreatedAt            time.Time         `json:"createdAt"`
-	EmergencyFlag        bool              `json:"emergencyFlag"`
-	ExternalNotification bool              `json:"externalNotification"`
-	FeaturedImageURL     *string           `json:"featuredImageURL"`
-	Id                   string            `json:"id"`
-	MetaDescription      *string           `json:"metaDescription"`
-	PublishedAt          *time.Time        `json:"publishedAt"`
-	ScheduledAt          *time.Time        `json:"scheduledAt"`
-	Slug                 *string           `json:"slug"`
-	SnsAutoPost          bool              `json:"snsAutoPost"`
-	Status               PublicationStatus `json:"status"`
-	Tags                 []string          `json:"tags"`
-	Title                string            `json:"title"`
+	// AuthorId User who created the post
+	AuthorId *string `json:"authorId"`
+
+	// Body At least 100 characters when created or changed. Posts saved before may be shorter.
+	Body string `json:"body"`
+
+	// BodyFormat Markup the body of a post is written in
+	BodyFormat           BodyFormat `json:"bodyFormat"`
+	Category             string     `json:"category"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	EmergencyFlag        bool       `json:"emergencyFlag"`
+	ExternalNotification bool       `json:"externalNotification"`
+	FeaturedImageURL     *string    `json:"featuredImageURL"`
+	Id                   string     `json:"id"`
+
+	// LastEditorId User who last changed the post
+	LastEditorId    *string    `json:"lastEditorId"`
+	MetaDescription *string    `json:"metaDescription"`
+	PublishedAt     *time.Time `json:"publishedAt"`
+
+	// RenderedBody Sanitized HTML of body, rendered when the body or its format changes
+	RenderedBody *string `json:"renderedBody,omitempty"`
+
+	// ReviewStatus Approval workflow state. Pending and rejected posts cannot be scheduled or published.
+	ReviewStatus *ReviewStatus     `json:"reviewStatus,omitempty"`
+	ScheduledAt  *time.Time        `json:"scheduledAt"`
+	Slug         *string           `json:"slug"`
+	SnsAutoPost  bool              `json:"snsAutoPost"`
+	Status       PublicationStatus `json:"status"`
+	Tags         []string          `json:"tags"`
+
+	// Title Lengths count Unicode characters after NFC normalization, not bytes
+	Title string `json:"title"`
+
+	// Version Incremented by every change. Sent as the ETag of the post.
+	Version *int32 `json:"version,omitempty"`
 }
 
 // PostList defines model for PostList.
 type PostList struct {
 	Items []Post `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"nextCursor"`
+
+	// Total Number of posts matching the filters, present when includeTotal is true
+	Total *int32 `json:"total,omitempty"`
 }
 
 // PostMergePatchUpdate defines model for PostMergePatchUpdate.
 type PostMergePatchUpdate struct {
-	Body                 *string            `json:"body,omitempty"`
+	// Body At least 100 characters when created or changed. Posts saved before may be shorter.
+	Body *string `json:"body,omitempty"`
+
+	// BodyFormat Markup the body of a post is written in
+	BodyFormat           *BodyFormat        `json:"bodyFormat,omitempty"`
 	Category             *string            `json:"category,omitempty"`
 	CreatedAt            *time.Time         `json:"createdAt,omitempty"`
 	EmergencyFlag        *bool              `json:"emergencyFlag,omitempty"`
@@ -94,20 +270,255 @@ type PostMergePatchUpdate struct {
 	SnsAutoPost          *bool              `json:"snsAutoPost,omitempty"`
 	Status               *PublicationStatus `json:"status,omitempty"`
 	Tags                 *[]string          `json:"tags,omitempty"`
-	Title                *string            `json:"title,omitempty"`
+
+	// Title Lengths count Unicode characters after NFC normalization, not bytes
+	Title *string `json:"title,omitempty"`
+}
+
+// PostPreview A draft body rendered as it would be shown to readers
+type PostPreview struct {
+	// Html Sanitized HTML of the body
+	Html string `json:"html"`
+
+	// Issues Markup that was removed from html because it is not allowed. Saving the body fails with these errors.
+	Issues []ValidationError `json:"issues"`
+}
+
+// PostReview A step of the approval workflow of a Post
+type PostReview struct {
+	Action ReviewAction `json:"action"`
+
+	// ActorId Author who submitted the post or reviewer who decided on it
+	ActorId   *string   `json:"actorId"`
+	Comment   *string   `json:"comment"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Reasons Rules that required the review, e.g. external_links. Set on submission.
+	Reasons []string `json:"reasons"`
+}
+
+// PostReviewList defines model for PostReviewList.
+type PostReviewList struct {
+	// Items Newest first
+	Items []PostReview `json:"items"`
+}
+
+// PostRevision defines model for PostRevision.
+type PostRevision struct {
+	Body string `json:"body"`
+
+	// BodyFormat Markup the body of a post is written in
+	BodyFormat BodyFormat `json:"bodyFormat"`
+	Category   string     `json:"category"`
+	CreatedAt  time.Time  `json:"createdAt"`
+
+	// EditorId User who saved the revision
+	EditorId         *string `json:"editorId"`
+	FeaturedImageURL *string `json:"featuredImageURL"`
+	MetaDescription  *string `json:"metaDescription"`
+	Number           int32   `json:"number"`
+
+	// RestoredFrom Number of the revision this one restored, null for regular edits
+	RestoredFrom *int32   `json:"restoredFrom"`
+	Slug         *string  `json:"slug"`
+	Tags         []string `json:"tags"`
+	Title        string   `json:"title"`
+}
+
+// PostRevisionDiff defines model for PostRevisionDiff.
+type PostRevisionDiff struct {
+	Body []DiffEdit `json:"body"`
+
+	// Changes Category, tags, featured image, meta description and slug when they differ
+	Changes []RevisionFieldChange `json:"changes"`
+	From    int32                 `json:"from"`
+	Mode    DiffMode              `json:"mode"`
+	Title   []DiffEdit            `json:"title"`
+	To      int32                 `json:"to"`
+}
+
+// PostRevisionList defines model for PostRevisionList.
+type PostRevisionList struct {
+	// Items Newest first
+	Items []PostRevisionSummary `json:"items"`
+}
+
+// PostRevisionSummary defines model for PostRevisionSummary.
+type PostRevisionSummary struct {
+	CreatedAt    time.Time `json:"createdAt"`
+	EditorId     *string   `json:"editorId"`
+	Number       int32     `json:"number"`
+	RestoredFrom *int32    `json:"restoredFrom"`
+	Title        string    `json:"title"`
+}
+
+// PostSort defines model for PostSort.
+type PostSort string
+
+// PreviewPostRequest defines model for PreviewPostRequest.
+type PreviewPostRequest struct {
+	Body string `json:"body"`
+
+	// BodyFormat Markup of body. Defaults to markdown.
+	BodyFormat *BodyFormat `json:"bodyFormat,omitempty"`
+}
+
+// PublicPost A published post as readers see it, without editorial fields
+type PublicPost struct {
+	// Body Body rendered to HTML, with every element, attribute and URL scheme outside the allowlist removed
+	Body             string    `json:"body"`
+	Category         string    `json:"category"`
+	FeaturedImageURL *string   `json:"featuredImageURL"`
+	Id               string    `json:"id"`
+	MetaDescription  *string   `json:"metaDescription"`
+	PublishedAt      time.Time `json:"publishedAt"`
+	Slug             *string   `json:"slug"`
+	Tags             []string  `json:"tags"`
+	Title            string    `json:"title"`
 }
 
+// PublicPostList defines model for PublicPostList.
+type PublicPostList struct {
+	Items []PublicPost `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"nextCursor"`
+}
+
+// PublicPostSort defines model for PublicPostSort.
+type PublicPostSort string
+
 // PublicationStatus defines model for PublicationStatus.
 type PublicationStatus string
 
-// UserRole defines model for UserRole.
-type UserRole string
+// PublishWindow Hours of some days, in server local time, when posts may be published immediately
+type PublishWindow struct {
+	// EndHour Exclusive, 1-24
+	EndHour int32 `json:"endHour"`
 
-// ValidationError defines model for ValidationError.
-type ValidationError struct {
+	// StartHour Inclusive, 0-23
+	StartHour int32     `json:"startHour"`
+	Weekdays  []Weekday `json:"weekdays"`
+}
+
+// ReviewAction defines model for ReviewAction.
+type ReviewAction string
+
+// ReviewRequest defines model for ReviewRequest.
+type ReviewRequest struct {
+	// Comment Note for the reviewers or the author. Required when rejecting.
+	Comment *string `json:"comment"`
+}
+
+// ReviewRequiredError The Post must be approved before it is scheduled or published
+type ReviewRequiredError struct {
+	Code    int32  `json:"code"`
+	Message string `json:"message"`
+
+	// Reasons Rules that require the review. Empty when the post is pending or was rejected.
+	Reasons      []string     `json:"reasons"`
+	ReviewStatus ReviewStatus `json:"reviewStatus"`
+}
+
+// ReviewStatus defines model for ReviewStatus.
+type ReviewStatus string
+
+// RevisionFieldChange defines model for RevisionFieldChange.
+type RevisionFieldChange struct {
+	// After JSON value in the to revision
+	After interface{} `json:"after"`
+
+	// Before JSON value in the from revision
+	Before interface{} `json:"before"`
+	Field  string      `json:"field"`
+}
+
+// RuleField defines model for RuleField.
+type RuleField string
+
+// SchedulePostRequest defines model for SchedulePostRequest.
+type SchedulePostRequest struct {
+	ScheduledAt time.Time `json:"scheduledAt"`
+}
+
+// SlugConflictError The slug is used by another post
+type SlugConflictError struct {
 	Code    int32  `json:"code"`
-	Field   string `json:"field"`
 	Message string `json:"message"`
+	Slug    string `json:"slug"`
+
+	// Suggestions Free slugs derived from the requested one
+	Suggestions []string `json:"suggestions"`
+}
+
+// SlugRedirect The slug was used by the post before. Location is the post's current path.
+type SlugRedirect struct {
+	Id string `json:"id"`
+
+	// Slug Current slug of the post, null when it has none
+	Slug *string `json:"slug"`
+}
+
+// TrashedPost A deleted Post waiting in the trash to be restored or purged
+type TrashedPost struct {
+	// AuthorId User who created the post
+	AuthorId *string `json:"authorId"`
+
+	// Body At least 100 characters when created or changed. Posts saved before may be shorter.
+	Body string `json:"body"`
+
+	// BodyFormat Markup the body of a post is written in
+	BodyFormat BodyFormat `json:"bodyFormat"`
+	Category   string     `json:"category"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	DeletedAt  time.Time  `json:"deletedAt"`
+
+	// DeletedBy User who deleted the post
+	DeletedBy            *string `json:"deletedBy"`
+	EmergencyFlag        bool    `json:"emergencyFlag"`
+	ExternalNotification bool    `json:"externalNotification"`
+	FeaturedImageURL     *string `json:"featuredImageURL"`
+	Id                   string  `json:"id"`
+
+	// LastEditorId User who last changed the post
+	LastEditorId    *string    `json:"lastEditorId"`
+	MetaDescription *string    `json:"metaDescription"`
+	PublishedAt     *time.Time `json:"publishedAt"`
+
+	// RenderedBody Sanitized HTML of body, rendered when the body or its format changes
+	RenderedBody *string `json:"renderedBody,omitempty"`
+
+	// ReviewStatus Approval workflow state. Pending and rejected posts cannot be scheduled or published.
+	ReviewStatus *ReviewStatus     `json:"reviewStatus,omitempty"`
+	ScheduledAt  *time.Time        `json:"scheduledAt"`
+	Slug         *string           `json:"slug"`
+	SnsAutoPost  bool              `json:"snsAutoPost"`
+	Status       PublicationStatus `json:"status"`
+	Tags         []string          `json:"tags"`
+
+	// Title Lengths count Unicode characters after NFC normalization, not bytes
+	Title string `json:"title"`
+
+	// Version Incremented by every change. Sent as the ETag of the post.
+	Version *int32 `json:"version,omitempty"`
+}
+
+// TrashedPostList defines model for TrashedPostList.
+type TrashedPostList struct {
+	Items []TrashedPost `json:"items"`
+
+	// NextCursor Cursor of the next page, null on the last page
+	NextCursor *string `json:"nextCursor"`
+}
+
+// ValidationError defines model for ValidationError.
+type ValidationError struct {
+	Code int32 `json:"code"`
+
+	// ErrorCode Stable machine-readable name of the failed rule, e.g. length, required_by_category or lead_time
+	ErrorCode string `json:"errorCode"`
+	Field     string `json:"field"`
+	Message   string `json:"message"`
 }
 
 // ValidationErrors defines model for ValidationErrors.
@@ -117,37 +528,286 @@ type ValidationErrors struct {
 	Message string            `json:"message"`
 }
 
-// PostsCreateParams defines parameters for PostsCreate.
-type PostsCreateParams struct {
-	XUserRole UserRole `json:"X-User-Role"`
+// Webhook defines model for Webhook.
+type Webhook struct {
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// EventTypes Post event types delivered to the endpoint, e.g. post.published
+	EventTypes []string `json:"eventTypes"`
+	Id         string   `json:"id"`
+	Url        string   `json:"url"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int32      `json:"attempts"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	DeliveredAt *time.Time `json:"deliveredAt"`
+	EndpointId  string     `json:"endpointId"`
+	EventId     string     `json:"eventId"`
+	EventType   string     `json:"eventType"`
+	Id          string     `json:"id"`
+	LastError   *string    `json:"lastError"`
+
+	// LastStatusCode HTTP status of the last attempt, null if no response was received
+	LastStatusCode *int32                `json:"lastStatusCode"`
+	NextAttemptAt  time.Time             `json:"nextAttemptAt"`
+	PostId         string                `json:"postId"`
+	Status         WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryList defines model for WebhookDeliveryList.
+type WebhookDeliveryList struct {
+	Items []WebhookDelivery `json:"items"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// WebhookList defines model for WebhookList.
+type WebhookList struct {
+	Items []Webhook `json:"items"`
+}
+
+// Weekday defines model for Weekday.
+type Weekday string
+
+// PostsListParams defines parameters for PostsList.
+type PostsListParams struct {
+	// Cursor nextCursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Page size between 1 and 100. Defaults to 20.
+	Limit  *int32             `form:"limit,omitempty" json:"limit,omitempty"`
+	Status *PublicationStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// ReviewStatus Only posts in this review state, e.g. pending for the review queue
+	ReviewStatus *ReviewStatus `form:"reviewStatus,omitempty" json:"reviewStatus,omitempty"`
+	Category     *string       `form:"category,omitempty" json:"category,omitempty"`
+	Tag          *string       `form:"tag,omitempty" json:"tag,omitempty"`
+	AuthorId     *string       `form:"authorId,omitempty" json:"authorId,omitempty"`
+
+	// CreatedFrom Only posts created at or after this time
+	CreatedFrom *time.Time `form:"createdFrom,omitempty" json:"createdFrom,omitempty"`
+
+	// CreatedTo Only posts created before this time
+	CreatedTo *time.Time `form:"createdTo,omitempty" json:"createdTo,omitempty"`
+
+	// PublishedFrom Only posts published at or after this time
+	PublishedFrom *time.Time `form:"publishedFrom,omitempty" json:"publishedFrom,omitempty"`
+
+	// PublishedTo Only posts published before this time
+	PublishedTo *time.Time `form:"publishedTo,omitempty" json:"publishedTo,omitempty"`
+
+	// Sort Sort order. Defaults to -createdAt. Sorting by publishedAt omits posts without a publication time.
+	Sort *PostSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// IncludeTotal Count the posts matching the filters
+	IncludeTotal *bool `form:"includeTotal,omitempty" json:"includeTotal,omitempty"`
+}
+
+// PostsReadBySlugParams defines parameters for PostsReadBySlug.
+type PostsReadBySlugParams struct {
+	// IfNoneMatch ETags of a cached copy. A matching copy is answered with 304.
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PostsListTrashParams defines parameters for PostsListTrash.
+type PostsListTrashParams struct {
+	// Cursor nextCursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Page size between 1 and 100. Defaults to 20.
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostsDeleteParams defines parameters for PostsDelete.
+type PostsDeleteParams struct {
+	// IfMatch ETag of the version being deleted. Required; a stale ETag fails with 412.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PostsReadParams defines parameters for PostsRead.
+type PostsReadParams struct {
+	// IfNoneMatch ETags of a cached copy. A matching copy is answered with 304.
+	IfNoneMatch *string `json:"If-None-Match,omitempty"`
+}
+
+// PostsUpdateParams defines parameters for PostsUpdate.
+type PostsUpdateParams struct {
+	// IfMatch ETag of the version being changed. Required; a stale ETag fails with 412.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PostsDiffRevisionParams defines parameters for PostsDiffRevision.
+type PostsDiffRevisionParams struct {
+	// From Revision to compare with. Defaults to revision - 1.
+	From *int32 `form:"from,omitempty" json:"from,omitempty"`
+
+	// Mode Defaults to line
+	Mode *DiffMode `form:"mode,omitempty" json:"mode,omitempty"`
+}
+
+// PublicPostsListParams defines parameters for PublicPostsList.
+type PublicPostsListParams struct {
+	// Cursor nextCursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Page size between 1 and 100. Defaults to 20.
+	Limit    *int32  `form:"limit,omitempty" json:"limit,omitempty"`
+	Category *string `form:"category,omitempty" json:"category,omitempty"`
+	Tag      *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// Sort Sort order. Defaults to -publishedAt.
+	Sort *PublicPostSort `form:"sort,omitempty" json:"sort,omitempty"`
+}
+
+// WebhooksListDeliveriesParams defines parameters for WebhooksListDeliveries.
+type WebhooksListDeliveriesParams struct {
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// AuthLoginJSONRequestBody defines body for AuthLogin for application/json ContentType.
+type AuthLoginJSONRequestBody = LoginRequest
+
 // PostsCreateJSONRequestBody defines body for PostsCreate for application/json ContentType.
 type PostsCreateJSONRequestBody = CreatePostRequest
 
+// PostsPreviewJSONRequestBody defines body for PostsPreview for application/json ContentType.
+type PostsPreviewJSONRequestBody = PreviewPostRequest
+
 // PostsUpdateApplicationMergePatchPlusJSONRequestBody defines body for PostsUpdate for application/merge-patch+json ContentType.
 type PostsUpdateApplicationMergePatchPlusJSONRequestBody = PostMergePatchUpdate
 
+// PostsApproveReviewJSONRequestBody defines body for PostsApproveReview for application/json ContentType.
+type PostsApproveReviewJSONRequestBody = ReviewRequest
+
+// PostsRejectReviewJSONRequestBody defines body for PostsRejectReview for application/json ContentType.
+type PostsRejectReviewJSONRequestBody = ReviewRequest
+
+// PostsSubmitReviewJSONRequestBody defines body for PostsSubmitReview for application/json ContentType.
+type PostsSubmitReviewJSONRequestBody = ReviewRequest
+
+// PostsScheduleJSONRequestBody defines body for PostsSchedule for application/json ContentType.
+type PostsScheduleJSONRequestBody = SchedulePostRequest
+
+// EditorialRuleSetsUpdateCategoryJSONRequestBody defines body for EditorialRuleSetsUpdateCategory for application/json ContentType.
+type EditorialRuleSetsUpdateCategoryJSONRequestBody = EditorialRules
+
+// EditorialRuleSetsUpdateGlobalJSONRequestBody defines body for EditorialRuleSetsUpdateGlobal for application/json ContentType.
+type EditorialRuleSetsUpdateGlobalJSONRequestBody = EditorialRules
+
+// WebhooksCreateJSONRequestBody defines body for WebhooksCreate for application/json ContentType.
+type WebhooksCreateJSONRequestBody = CreateWebhookRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (POST /api/auth/login)
+	AuthLogin(c *gin.Context)
+
 	// (GET /api/posts)
-	PostsList(c *gin.Context)
+	PostsList(c *gin.Context, params PostsListParams)
 
 	// (POST /api/posts)
-	PostsCreate(c *gin.Context, params PostsCreateParams)
+	PostsCreate(c *gin.Context)
+
+	// (GET /api/posts/by-slug/{slug})
+	PostsReadBySlug(c *gin.Context, slug string, params PostsReadBySlugParams)
+
+	// (POST /api/posts/preview)
+	PostsPreview(c *gin.Context)
+
+	// (GET /api/posts/trash)
+	PostsListTrash(c *gin.Context, params PostsListTrashParams)
 
 	// (DELETE /api/posts/{id})
-	PostsDelete(c *gin.Context, id string)
+	PostsDelete(c *gin.Context, id string, params PostsDeleteParams)
 
 	// (GET /api/posts/{id})
-	PostsRead(c *gin.Context, id string)
+	PostsRead(c *gin.Context, id string, params PostsReadParams)
 
 	// (PATCH /api/posts/{id})
-	PostsUpdate(c *gin.Context, id string)
+	PostsUpdate(c *gin.Context, id string, params PostsUpdateParams)
 
 	// (POST /api/posts/{id}/analyze)
 	PostsAnalyze(c *gin.Context, id string)
+
+	// (POST /api/posts/{id}/archive)
+	PostsArchive(c *gin.Context, id string)
+
+	// (POST /api/posts/{id}/publish)
+	PostsPublish(c *gin.Context, id string)
+
+	// (POST /api/posts/{id}/restore)
+	PostsRestore(c *gin.Context, id string)
+
+	// (POST /api/posts/{id}/review/approve)
+	PostsApproveReview(c *gin.Context, id string)
+
+	// (POST /api/posts/{id}/review/reject)
+	PostsRejectReview(c *gin.Context, id string)
+
+	// (POST /api/posts/{id}/review/submit)
+	PostsSubmitReview(c *gin.Context, id string)
+
+	// (GET /api/posts/{id}/reviews)
+	PostsListReviews(c *gin.Context, id string)
+
+	// (GET /api/posts/{id}/revisions)
+	PostsListRevisions(c *gin.Context, id string)
+
+	// (GET /api/posts/{id}/revisions/{revision})
+	PostsReadRevision(c *gin.Context, id string, revision int32)
+
+	// (GET /api/posts/{id}/revisions/{revision}/diff)
+	PostsDiffRevision(c *gin.Context, id string, revision int32, params PostsDiffRevisionParams)
+
+	// (POST /api/posts/{id}/revisions/{revision}/restore)
+	PostsRestoreRevision(c *gin.Context, id string, revision int32)
+
+	// (POST /api/posts/{id}/schedule)
+	PostsSchedule(c *gin.Context, id string)
+
+	// (POST /api/posts/{id}/unarchive)
+	PostsUnarchive(c *gin.Context, id string)
+
+	// (POST /api/posts/{id}/unpublish)
+	PostsUnpublish(c *gin.Context, id string)
+
+	// (POST /api/posts/{id}/unschedule)
+	PostsUnschedule(c *gin.Context, id string)
+
+	// (GET /api/public/posts)
+	PublicPostsList(c *gin.Context, params PublicPostsListParams)
+
+	// (GET /api/public/posts/{slug})
+	PublicPostsRead(c *gin.Context, slug string)
+
+	// (GET /api/rules)
+	EditorialRuleSetsRead(c *gin.Context)
+
+	// (DELETE /api/rules/categories/{category})
+	EditorialRuleSetsDeleteCategory(c *gin.Context, category string)
+
+	// (PUT /api/rules/categories/{category})
+	EditorialRuleSetsUpdateCategory(c *gin.Context, category string)
+
+	// (PUT /api/rules/global)
+	EditorialRuleSetsUpdateGlobal(c *gin.Context)
+
+	// (GET /api/webhooks)
+	WebhooksList(c *gin.Context)
+
+	// (POST /api/webhooks)
+	WebhooksCreate(c *gin.Context)
+
+	// (DELETE /api/webhooks/{id})
+	WebhooksDelete(c *gin.Context, id string)
+
+	// (GET /api/webhooks/{id}/deliveries)
+	WebhooksListDeliveries(c *gin.Context, id string, params WebhooksListDeliveriesParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -159,8 +819,8 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
-// PostsList operation middleware
-func (siw *ServerInterfaceWrapper) PostsList(c *gin.Context) {
+// AuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) AuthLogin(c *gin.Context) {
 
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
@@ -169,97 +829,347 @@ func (siw *ServerInterfaceWrapper) PostsList(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostsList(c)
+	siw.Handler.AuthLogin(c)
 }
 
-// PostsCreate operation middleware
-func (siw *ServerInterfaceWrapper) PostsCreate(c *gin.Context) {
+// PostsList operation middleware
+func (siw *ServerInterfaceWrapper) PostsList(c *gin.Context) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PostsCreateParams
+	c.Set(BearerAuthScopes, []string{})
 
-	headers := c.Request.Header
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostsListParams
 
-	// ------------- Required header parameter "X-User-Role" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("X-User-Role")]; found {
-		var XUserRole UserRole
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandler(c, fmt.Errorf("Expected one value for X-User-Role, got %d", n), http.StatusBadRequest)
-			return
-		}
+	// ------------- Optional query parameter "cursor" -------------
 
-		err = runtime.BindStyledParameterWithOptions("simple", "X-User-Role", valueList[0], &XUserRole, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
-		if err != nil {
-			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter X-User-Role: %w", err), http.StatusBadRequest)
-			return
-		}
+	err = runtime.BindQueryParameter("form", false, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
 
-		params.XUserRole = XUserRole
+	// ------------- Optional query parameter "limit" -------------
 
-	} else {
-		siw.ErrorHandler(c, fmt.Errorf("Header parameter X-User-Role is required, but not found"), http.StatusBadRequest)
+	err = runtime.BindQueryParameter("form", false, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
+	// ------------- Optional query parameter "status" -------------
 
-	siw.Handler.PostsCreate(c, params)
-}
+	err = runtime.BindQueryParameter("form", false, false, "status", c.Request.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter status: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// PostsDelete operation middleware
-func (siw *ServerInterfaceWrapper) PostsDelete(c *gin.Context) {
+	// ------------- Optional query parameter "reviewStatus" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", false, false, "reviewStatus", c.Request.URL.Query(), &params.ReviewStatus)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter reviewStatus: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	// ------------- Optional query parameter "category" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", false, false, "category", c.Request.URL.Query(), &params.Category)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter category: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
+	// ------------- Optional query parameter "tag" -------------
 
-	siw.Handler.PostsDelete(c, id)
-}
+	err = runtime.BindQueryParameter("form", false, false, "tag", c.Request.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tag: %w", err), http.StatusBadRequest)
+		return
+	}
 
-// PostsRead operation middleware
-func (siw *ServerInterfaceWrapper) PostsRead(c *gin.Context) {
+	// ------------- Optional query parameter "authorId" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", false, false, "authorId", c.Request.URL.Query(), &params.AuthorId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter authorId: %w", err), http.StatusBadRequest)
+		return
+	}
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	// ------------- Optional query parameter "createdFrom" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", false, false, "createdFrom", c.Request.URL.Query(), &params.CreatedFrom)
 	if err != nil {
-		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdFrom: %w", err), http.StatusBadRequest)
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
+	// ------------- Optional query parameter "createdTo" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "createdTo", c.Request.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter createdTo: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "publishedFrom" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "publishedFrom", c.Request.URL.Query(), &params.PublishedFrom)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter publishedFrom: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "publishedTo" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "publishedTo", c.Request.URL.Query(), &params.PublishedTo)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter publishedTo: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "includeTotal" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "includeTotal", c.Request.URL.Query(), &params.IncludeTotal)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter includeTotal: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsList(c, params)
+}
+
+// PostsCreate operation middleware
+func (siw *ServerInterfaceWrapper) PostsCreate(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsCreate(c)
+}
+
+// PostsReadBySlug operation middleware
+func (siw *ServerInterfaceWrapper) PostsReadBySlug(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", c.Param("slug"), &slug, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter slug: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostsReadBySlugParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsReadBySlug(c, slug, params)
+}
+
+// PostsPreview operation middleware
+func (siw *ServerInterfaceWrapper) PostsPreview(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsPreview(c)
+}
+
+// PostsListTrash operation middleware
+func (siw *ServerInterfaceWrapper) PostsListTrash(c *gin.Context) {
+
+	var err error
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostsListTrashParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsListTrash(c, params)
+}
+
+// PostsDelete operation middleware
+func (siw *ServerInterfaceWrapper) PostsDelete(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostsDeleteParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsDelete(c, id, params)
+}
+
+// PostsRead operation middleware
+func (siw *ServerInterfaceWrapper) PostsRead(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostsReadParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-None-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-None-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
 		if c.IsAborted() {
 			return
 		}
 	}
 
-	siw.Handler.PostsRead(c, id)
+	siw.Handler.PostsRead(c, id, params)
 }
 
 // PostsUpdate operation middleware
@@ -276,6 +1186,32 @@ func (siw *ServerInterfaceWrapper) PostsUpdate(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostsUpdateParams
+
+	headers := c.Request.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandler(c, fmt.Errorf("Expected one value for If-Match, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter If-Match: %w", err), http.StatusBadRequest)
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -283,7 +1219,7 @@ func (siw *ServerInterfaceWrapper) PostsUpdate(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostsUpdate(c, id)
+	siw.Handler.PostsUpdate(c, id, params)
 }
 
 // PostsAnalyze operation middleware
@@ -300,6 +1236,8 @@ func (siw *ServerInterfaceWrapper) PostsAnalyze(c *gin.Context) {
 		return
 	}
 
+	c.Set(BearerAuthScopes, []string{})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -310,6 +1248,699 @@ func (siw *ServerInterfaceWrapper) PostsAnalyze(c *gin.Context) {
 	siw.Handler.PostsAnalyze(c, id)
 }
 
+// PostsArchive operation middleware
+func (siw *ServerInterfaceWrapper) PostsArchive(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsArchive(c, id)
+}
+
+// PostsPublish operation middleware
+func (siw *ServerInterfaceWrapper) PostsPublish(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsPublish(c, id)
+}
+
+// PostsRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostsRestore(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsRestore(c, id)
+}
+
+// PostsApproveReview operation middleware
+func (siw *ServerInterfaceWrapper) PostsApproveReview(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsApproveReview(c, id)
+}
+
+// PostsRejectReview operation middleware
+func (siw *ServerInterfaceWrapper) PostsRejectReview(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsRejectReview(c, id)
+}
+
+// PostsSubmitReview operation middleware
+func (siw *ServerInterfaceWrapper) PostsSubmitReview(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsSubmitReview(c, id)
+}
+
+// PostsListReviews operation middleware
+func (siw *ServerInterfaceWrapper) PostsListReviews(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsListReviews(c, id)
+}
+
+// PostsListRevisions operation middleware
+func (siw *ServerInterfaceWrapper) PostsListRevisions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsListRevisions(c, id)
+}
+
+// PostsReadRevision operation middleware
+func (siw *ServerInterfaceWrapper) PostsReadRevision(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", c.Param("revision"), &revision, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter revision: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsReadRevision(c, id, revision)
+}
+
+// PostsDiffRevision operation middleware
+func (siw *ServerInterfaceWrapper) PostsDiffRevision(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", c.Param("revision"), &revision, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter revision: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostsDiffRevisionParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "mode", c.Request.URL.Query(), &params.Mode)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter mode: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsDiffRevision(c, id, revision, params)
+}
+
+// PostsRestoreRevision operation middleware
+func (siw *ServerInterfaceWrapper) PostsRestoreRevision(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int32
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", c.Param("revision"), &revision, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter revision: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsRestoreRevision(c, id, revision)
+}
+
+// PostsSchedule operation middleware
+func (siw *ServerInterfaceWrapper) PostsSchedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsSchedule(c, id)
+}
+
+// PostsUnarchive operation middleware
+func (siw *ServerInterfaceWrapper) PostsUnarchive(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsUnarchive(c, id)
+}
+
+// PostsUnpublish operation middleware
+func (siw *ServerInterfaceWrapper) PostsUnpublish(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsUnpublish(c, id)
+}
+
+// PostsUnschedule operation middleware
+func (siw *ServerInterfaceWrapper) PostsUnschedule(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostsUnschedule(c, id)
+}
+
+// PublicPostsList operation middleware
+func (siw *ServerInterfaceWrapper) PublicPostsList(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PublicPostsListParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "category", c.Request.URL.Query(), &params.Category)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter category: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "tag", c.Request.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter tag: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PublicPostsList(c, params)
+}
+
+// PublicPostsRead operation middleware
+func (siw *ServerInterfaceWrapper) PublicPostsRead(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", c.Param("slug"), &slug, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter slug: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PublicPostsRead(c, slug)
+}
+
+// EditorialRuleSetsRead operation middleware
+func (siw *ServerInterfaceWrapper) EditorialRuleSetsRead(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EditorialRuleSetsRead(c)
+}
+
+// EditorialRuleSetsDeleteCategory operation middleware
+func (siw *ServerInterfaceWrapper) EditorialRuleSetsDeleteCategory(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "category" -------------
+	var category string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "category", c.Param("category"), &category, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter category: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EditorialRuleSetsDeleteCategory(c, category)
+}
+
+// EditorialRuleSetsUpdateCategory operation middleware
+func (siw *ServerInterfaceWrapper) EditorialRuleSetsUpdateCategory(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "category" -------------
+	var category string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "category", c.Param("category"), &category, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter category: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EditorialRuleSetsUpdateCategory(c, category)
+}
+
+// EditorialRuleSetsUpdateGlobal operation middleware
+func (siw *ServerInterfaceWrapper) EditorialRuleSetsUpdateGlobal(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.EditorialRuleSetsUpdateGlobal(c)
+}
+
+// WebhooksList operation middleware
+func (siw *ServerInterfaceWrapper) WebhooksList(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.WebhooksList(c)
+}
+
+// WebhooksCreate operation middleware
+func (siw *ServerInterfaceWrapper) WebhooksCreate(c *gin.Context) {
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.WebhooksCreate(c)
+}
+
+// WebhooksDelete operation middleware
+func (siw *ServerInterfaceWrapper) WebhooksDelete(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.WebhooksDelete(c, id)
+}
+
+// WebhooksListDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) WebhooksListDeliveries(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Param("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter id: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params WebhooksListDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.WebhooksListDeliveries(c, id, params)
+}
+
 // GinServerOptions provides options for the Gin server.
 type GinServerOptions struct {
 	BaseURL      string
@@ -337,10 +1968,39 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.POST(options.BaseURL+"/api/auth/login", wrapper.AuthLogin)
 	router.GET(options.BaseURL+"/api/posts", wrapper.PostsList)
 	router.POST(options.BaseURL+"/api/posts", wrapper.PostsCreate)
+	router.GET(options.BaseURL+"/api/posts/by-slug/:slug", wrapper.PostsReadBySlug)
+	router.POST(options.BaseURL+"/api/posts/preview", wrapper.PostsPreview)
+	router.GET(options.BaseURL+"/api/posts/trash", wrapper.PostsListTrash)
 	router.DELETE(options.BaseURL+"/api/posts/:id", wrapper.PostsDelete)
 	router.GET(options.BaseURL+"/api/posts/:id", wrapper.PostsRead)
 	router.PATCH(options.BaseURL+"/api/posts/:id", wrapper.PostsUpdate)
 	router.POST(options.BaseURL+"/api/posts/:id/analyze", wrapper.PostsAnalyze)
+	router.POST(options.BaseURL+"/api/posts/:id/archive", wrapper.PostsArchive)
+	router.POST(options.BaseURL+"/api/posts/:id/publish", wrapper.PostsPublish)
+	router.POST(options.BaseURL+"/api/posts/:id/restore", wrapper.PostsRestore)
+	router.POST(options.BaseURL+"/api/posts/:id/review/approve", wrapper.PostsApproveReview)
+	router.POST(options.BaseURL+"/api/posts/:id/review/reject", wrapper.PostsRejectReview)
+	router.POST(options.BaseURL+"/api/posts/:id/review/submit", wrapper.PostsSubmitReview)
+	router.GET(options.BaseURL+"/api/posts/:id/reviews", wrapper.PostsListReviews)
+	router.GET(options.BaseURL+"/api/posts/:id/revisions", wrapper.PostsListRevisions)
+	router.GET(options.BaseURL+"/api/posts/:id/revisions/:revision", wrapper.PostsReadRevision)
+	router.GET(options.BaseURL+"/api/posts/:id/revisions/:revision/diff", wrapper.PostsDiffRevision)
+	router.POST(options.BaseURL+"/api/posts/:id/revisions/:revision/restore", wrapper.PostsRestoreRevision)
+	router.POST(options.BaseURL+"/api/posts/:id/schedule", wrapper.PostsSchedule)
+	router.POST(options.BaseURL+"/api/posts/:id/unarchive", wrapper.PostsUnarchive)
+	router.POST(options.BaseURL+"/api/posts/:id/unpublish", wrapper.PostsUnpublish)
+	router.POST(options.BaseURL+"/api/posts/:id/unschedule", wrapper.PostsUnschedule)
+	router.GET(options.BaseURL+"/api/public/posts", wrapper.PublicPostsList)
+	router.GET(options.BaseURL+"/api/public/posts/:slug", wrapper.PublicPostsRead)
+	router.GET(options.BaseURL+"/api/rules", wrapper.EditorialRuleSetsRead)
+	router.DELETE(options.BaseURL+"/api/rules/categories/:category", wrapper.EditorialRuleSetsDeleteCategory)
+	router.PUT(options.BaseURL+"/api/rules/categories/:category", wrapper.EditorialRuleSetsUpdateCategory)
+	router.PUT(options.BaseURL+"/api/rules/global", wrapper.EditorialRuleSetsUpdateGlobal)
+	router.GET(options.BaseURL+"/api/webhooks", wrapper.WebhooksList)
+	router.POST(options.BaseURL+"/api/webhooks", wrapper.WebhooksCreate)
+	router.DELETE(options.BaseURL+"/api/webhooks/:id", wrapper.WebhooksDelete)
+	router.GET(options.BaseURL+"/api/webhooks/:id/deliveries", wrapper.WebhooksListDeliveries)
 }
@@ -1,15 +1,44 @@
 package di
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"strconv"
 	"sync"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/ss49919201/myblog/api/internal/clock"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
 	"github.com/ss49919201/myblog/api/internal/post/event"
+	"github.com/ss49919201/myblog/api/internal/post/outbox"
 	"github.com/ss49919201/myblog/api/internal/post/rdb"
 	"github.com/ss49919201/myblog/api/internal/post/repository"
+	"github.com/ss49919201/myblog/api/internal/post/scheduler"
+	"github.com/ss49919201/myblog/api/internal/post/subscriber"
 	"github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/rule/entity/rule"
+	rulerdb "github.com/ss49919201/myblog/api/internal/rule/rdb"
+	rulerepository "github.com/ss49919201/myblog/api/internal/rule/repository"
+	ruleusecase "github.com/ss49919201/myblog/api/internal/rule/usecase"
+	"github.com/ss49919201/myblog/api/internal/sns/autopost"
+	"github.com/ss49919201/myblog/api/internal/sns/connector"
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+	snsrdb "github.com/ss49919201/myblog/api/internal/sns/rdb"
+	snsrepository "github.com/ss49919201/myblog/api/internal/sns/repository"
+	snsusecase "github.com/ss49919201/myblog/api/internal/sns/usecase"
+	userrdb "github.com/ss49919201/myblog/api/internal/user/rdb"
+	userrepository "github.com/ss49919201/myblog/api/internal/user/repository"
+	"github.com/ss49919201/myblog/api/internal/user/token"
+	userusecase "github.com/ss49919201/myblog/api/internal/user/usecase"
+	"github.com/ss49919201/myblog/api/internal/webhook/delivery"
+	webhookrdb "github.com/ss49919201/myblog/api/internal/webhook/rdb"
+	webhookrepository "github.com/ss49919201/myblog/api/internal/webhook/repository"
+	webhookusecase "github.com/ss49919201/myblog/api/internal/webhook/usecase"
 )
 
 var containerOnceValue = sync.OnceValue(func() *Container {
@@ -17,13 +46,55 @@ var containerOnceValue = sync.OnceValue(func() *Container {
 })
 
 type Container struct {
-	dbOnce                 func() (*sql.DB, error)
-	postRepoOnce           func() (repository.PostRepository, error)
-	eventDispatcherOnce    func() (event.EventDispatcher, error)
-	createPostUsecaseOnce  func() (*usecase.CreatePostUsecase, error)
-	updatePostUsecaseOnce  func() (*usecase.UpdatePostUsecase, error)
-	deletePostUsecaseOnce  func() (*usecase.DeletePostUsecase, error)
-	analyzePostUsecaseOnce func() (*usecase.AnalyzePostUsecase, error)
+	dbOnce                     func() (*sql.DB, error)
+	postRepoOnce               func() (repository.PostRepository, error)
+	eventDispatcherOnce        func() (event.EventDispatcher, error)
+	policyOnce                 func() (usecase.Policy, error)
+	createPostUsecaseOnce      func() (*usecase.CreatePostUsecase, error)
+	updatePostUsecaseOnce      func() (*usecase.UpdatePostUsecase, error)
+	transitionPostUsecaseOnce  func() (*usecase.TransitionPostUsecase, error)
+	deletePostUsecaseOnce      func() (*usecase.DeletePostUsecase, error)
+	restorePostUsecaseOnce     func() (*usecase.RestorePostUsecase, error)
+	listTrashUsecaseOnce       func() (*usecase.ListTrashUsecase, error)
+	revisionRepoOnce           func() (repository.RevisionRepository, error)
+	restoreRevisionUsecaseOnce func() (*usecase.RestoreRevisionUsecase, error)
+	listRevisionsUsecaseOnce   func() (*usecase.ListRevisionsUsecase, error)
+	readRevisionUsecaseOnce    func() (*usecase.ReadRevisionUsecase, error)
+	diffRevisionsUsecaseOnce   func() (*usecase.DiffRevisionsUsecase, error)
+	reviewRepoOnce             func() (repository.ReviewRepository, error)
+	reviewPostUsecaseOnce      func() (*usecase.ReviewPostUsecase, error)
+	listReviewsUsecaseOnce     func() (*usecase.ListReviewsUsecase, error)
+	ruleEngineOnce             func() (*usecase.RuleEngine, error)
+	analyzePostUsecaseOnce     func() (*usecase.AnalyzePostUsecase, error)
+
+	transactorOnce                   func() (repository.Transactor, error)
+	publishScheduledPostsUsecaseOnce func() (*usecase.PublishScheduledPostsUsecase, error)
+	schedulerOnce                    func() (*scheduler.Scheduler, error)
+	purgeTrashUsecaseOnce            func() (*usecase.PurgeTrashUsecase, error)
+	purgerOnce                       func() (*scheduler.Purger, error)
+	outboxRelayOnce                  func() (*outbox.Relay, error)
+	eventBusOnce                     func() (*event.Bus, error)
+
+	userRepoOnce            func() (userrepository.UserRepository, error)
+	tokenManagerOnce        func() (*token.Manager, error)
+	loginUsecaseOnce        func() (*userusecase.LoginUsecase, error)
+	registerUserUsecaseOnce func() (*userusecase.RegisterUserUsecase, error)
+
+	webhookEndpointRepoOnce             func() (webhookrepository.EndpointRepository, error)
+	webhookDeliveryRepoOnce             func() (webhookrepository.DeliveryRepository, error)
+	registerWebhookUsecaseOnce          func() (*webhookusecase.RegisterEndpointUsecase, error)
+	deactivateWebhookUsecaseOnce        func() (*webhookusecase.DeactivateEndpointUsecase, error)
+	enqueueWebhookDeliveriesUsecaseOnce func() (*webhookusecase.EnqueueDeliveriesUsecase, error)
+	webhookWorkerOnce                   func() (*delivery.Worker, error)
+
+	socialPublishersOnce        func() ([]connector.SocialPublisher, error)
+	snsShareRepoOnce            func() (snsrepository.ShareRepository, error)
+	enqueueSNSSharesUsecaseOnce func() (*snsusecase.EnqueueSharesUsecase, error)
+	snsWorkerOnce               func() (*autopost.Worker, error)
+
+	ruleRepoOnce                   func() (rulerepository.RuleRepository, error)
+	updateRulesUsecaseOnce         func() (*ruleusecase.UpdateRulesUsecase, error)
+	deleteCategoryRulesUsecaseOnce func() (*ruleusecase.DeleteCategoryRulesUsecase, error)
 }
 
 func NewContainer() *Container {
@@ -52,11 +123,136 @@ func (c *Container) initOnceValues() {
 		if err != nil {
 			return nil, err
 		}
-		return rdb.NewPostRepository(db), nil
+		slugPolicy, err := rdb.ParseSlugDeletePolicy(os.Getenv("SLUG_DELETE_POLICY"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SLUG_DELETE_POLICY: %w", err)
+		}
+		return rdb.NewPostRepository(db, slugPolicy), nil
+	})
+
+	c.revisionRepoOnce = sync.OnceValues(func() (repository.RevisionRepository, error) {
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		return rdb.NewRevisionRepository(db), nil
+	})
+
+	c.reviewRepoOnce = sync.OnceValues(func() (repository.ReviewRepository, error) {
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		return rdb.NewReviewRepository(db), nil
+	})
+
+	c.ruleRepoOnce = sync.OnceValues(func() (rulerepository.RuleRepository, error) {
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		base := rule.Default()
+		if path := os.Getenv("POST_RULES_FILE"); path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read POST_RULES_FILE: %w", err)
+			}
+			if base, err = rule.Parse(data); err != nil {
+				return nil, fmt.Errorf("invalid POST_RULES_FILE: %w", err)
+			}
+		}
+		return rulerdb.NewRuleRepository(db, base), nil
+	})
+
+	c.ruleEngineOnce = sync.OnceValues(func() (*usecase.RuleEngine, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		rules, err := c.RuleRepository()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewRuleEngine(repo, rules), nil
+	})
+
+	c.updateRulesUsecaseOnce = sync.OnceValues(func() (*ruleusecase.UpdateRulesUsecase, error) {
+		repo, err := c.RuleRepository()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return ruleusecase.NewUpdateRulesUsecase(repo, policy), nil
+	})
+
+	c.deleteCategoryRulesUsecaseOnce = sync.OnceValues(func() (*ruleusecase.DeleteCategoryRulesUsecase, error) {
+		repo, err := c.RuleRepository()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return ruleusecase.NewDeleteCategoryRulesUsecase(repo, policy), nil
 	})
 
 	c.eventDispatcherOnce = sync.OnceValues(func() (event.EventDispatcher, error) {
-		return event.NewNoopEventDispatcher(), nil
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		return rdb.NewOutboxEventDispatcher(db), nil
+	})
+
+	c.outboxRelayOnce = sync.OnceValues(func() (*outbox.Relay, error) {
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		bus, err := c.EventBus()
+		if err != nil {
+			return nil, err
+		}
+		enqueueWebhooks, err := c.EnqueueWebhookDeliveriesUsecase()
+		if err != nil {
+			return nil, err
+		}
+		enqueueShares, err := c.EnqueueSNSSharesUsecase()
+		if err != nil {
+			return nil, err
+		}
+		relay := outbox.NewRelay(rdb.NewOutboxStore(db), tx, clock.System{}, outbox.DefaultRelayConfig())
+		// Webhook と SNS の配信予約はリレーのトランザクション内で書き込み、失敗時はリレーが再試行する
+		relay.Register(outbox.HandlerFunc(enqueueWebhooks.Execute))
+		relay.Register(outbox.HandlerFunc(enqueueShares.Execute))
+		// インメモリのバスは購読者のキューが詰まると待たされるため、コミット後に渡す
+		relay.RegisterAfterCommit(outbox.HandlerFunc(func(ctx context.Context, e post.PostEvent) error {
+			return bus.DispatchEvents(ctx, []post.PostEvent{e})
+		}))
+		return relay, nil
+	})
+
+	c.eventBusOnce = sync.OnceValues(func() (*event.Bus, error) {
+		const workers, queueSize = 2, 100
+
+		bus := event.NewBus()
+		bus.Subscribe(subscriber.NewSearchIndexer(), workers, queueSize)
+		bus.Subscribe(subscriber.NewCacheInvalidator(), workers, queueSize)
+		bus.Subscribe(subscriber.NewFeedRegenerator(), workers, queueSize)
+		bus.Subscribe(subscriber.NewNotifier(), workers, queueSize)
+		return bus, nil
+	})
+
+	c.policyOnce = sync.OnceValues(func() (usecase.Policy, error) {
+		return usecase.NewRolePolicy(), nil
 	})
 
 	c.createPostUsecaseOnce = sync.OnceValues(func() (*usecase.CreatePostUsecase, error) {
@@ -68,7 +264,27 @@ func (c *Container) initOnceValues() {
 		if err != nil {
 			return nil, err
 		}
-		return usecase.NewCreatePostUsecase(repo, dispatcher), nil
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		revisions, err := c.RevisionRepository()
+		if err != nil {
+			return nil, err
+		}
+		reviews, err := c.ReviewRepository()
+		if err != nil {
+			return nil, err
+		}
+		rules, err := c.RuleEngine()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewCreatePostUsecase(repo, revisions, reviews, tx, dispatcher, policy, rules), nil
 	})
 
 	c.updatePostUsecaseOnce = sync.OnceValues(func() (*usecase.UpdatePostUsecase, error) {
@@ -80,7 +296,47 @@ func (c *Container) initOnceValues() {
 		if err != nil {
 			return nil, err
 		}
-		return usecase.NewUpdatePostUsecase(repo, dispatcher), nil
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		revisions, err := c.RevisionRepository()
+		if err != nil {
+			return nil, err
+		}
+		rules, err := c.RuleEngine()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewUpdatePostUsecase(repo, revisions, tx, dispatcher, policy, rules), nil
+	})
+
+	c.transitionPostUsecaseOnce = sync.OnceValues(func() (*usecase.TransitionPostUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		dispatcher, err := c.EventDispatcher()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		rules, err := c.RuleEngine()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewTransitionPostUsecase(repo, tx, dispatcher, policy, rules), nil
 	})
 
 	c.deletePostUsecaseOnce = sync.OnceValues(func() (*usecase.DeletePostUsecase, error) {
@@ -88,11 +344,443 @@ func (c *Container) initOnceValues() {
 		if err != nil {
 			return nil, err
 		}
-		return usecase.NewDeletePostUsecase(repo), nil
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		dispatcher, err := c.EventDispatcher()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewDeletePostUsecase(repo, tx, dispatcher, policy), nil
+	})
+
+	c.restorePostUsecaseOnce = sync.OnceValues(func() (*usecase.RestorePostUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		dispatcher, err := c.EventDispatcher()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewRestorePostUsecase(repo, tx, dispatcher, policy), nil
+	})
+
+	c.listTrashUsecaseOnce = sync.OnceValues(func() (*usecase.ListTrashUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewListTrashUsecase(repo, policy), nil
+	})
+
+	c.restoreRevisionUsecaseOnce = sync.OnceValues(func() (*usecase.RestoreRevisionUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		revisions, err := c.RevisionRepository()
+		if err != nil {
+			return nil, err
+		}
+		dispatcher, err := c.EventDispatcher()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		rules, err := c.RuleEngine()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewRestoreRevisionUsecase(repo, revisions, tx, dispatcher, policy, rules), nil
+	})
+
+	c.listRevisionsUsecaseOnce = sync.OnceValues(func() (*usecase.ListRevisionsUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		revisions, err := c.RevisionRepository()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewListRevisionsUsecase(repo, revisions, policy), nil
+	})
+
+	c.readRevisionUsecaseOnce = sync.OnceValues(func() (*usecase.ReadRevisionUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		revisions, err := c.RevisionRepository()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewReadRevisionUsecase(repo, revisions, policy), nil
+	})
+
+	c.diffRevisionsUsecaseOnce = sync.OnceValues(func() (*usecase.DiffRevisionsUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		revisions, err := c.RevisionRepository()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewDiffRevisionsUsecase(repo, revisions, policy), nil
+	})
+
+	c.reviewPostUsecaseOnce = sync.OnceValues(func() (*usecase.ReviewPostUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		reviews, err := c.ReviewRepository()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		dispatcher, err := c.EventDispatcher()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		rules, err := c.RuleEngine()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewReviewPostUsecase(repo, reviews, tx, dispatcher, policy, rules), nil
+	})
+
+	c.listReviewsUsecaseOnce = sync.OnceValues(func() (*usecase.ListReviewsUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		reviews, err := c.ReviewRepository()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewListReviewsUsecase(repo, reviews, policy), nil
 	})
 
 	c.analyzePostUsecaseOnce = sync.OnceValues(func() (*usecase.AnalyzePostUsecase, error) {
-		return usecase.NewAnalyzePostUsecase(), nil
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewAnalyzePostUsecase(policy), nil
+	})
+
+	c.userRepoOnce = sync.OnceValues(func() (userrepository.UserRepository, error) {
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		return userrdb.NewUserRepository(db), nil
+	})
+
+	c.transactorOnce = sync.OnceValues(func() (repository.Transactor, error) {
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		return rdb.NewTransactor(db), nil
+	})
+
+	c.publishScheduledPostsUsecaseOnce = sync.OnceValues(func() (*usecase.PublishScheduledPostsUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		dispatcher, err := c.EventDispatcher()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewPublishScheduledPostsUsecase(repo, tx, dispatcher), nil
+	})
+
+	c.schedulerOnce = sync.OnceValues(func() (*scheduler.Scheduler, error) {
+		uc, err := c.PublishScheduledPostsUsecase()
+		if err != nil {
+			return nil, err
+		}
+		interval := scheduler.DefaultInterval
+		if v := os.Getenv("SCHEDULER_INTERVAL"); v != "" {
+			interval, err = time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SCHEDULER_INTERVAL: %w", err)
+			}
+		}
+		return scheduler.NewScheduler(uc, clock.System{}, interval, scheduler.DefaultBatchSize), nil
+	})
+
+	c.purgeTrashUsecaseOnce = sync.OnceValues(func() (*usecase.PurgeTrashUsecase, error) {
+		repo, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		dispatcher, err := c.EventDispatcher()
+		if err != nil {
+			return nil, err
+		}
+		return usecase.NewPurgeTrashUsecase(repo, tx, dispatcher), nil
+	})
+
+	c.purgerOnce = sync.OnceValues(func() (*scheduler.Purger, error) {
+		uc, err := c.PurgeTrashUsecase()
+		if err != nil {
+			return nil, err
+		}
+		interval := scheduler.DefaultPurgeInterval
+		if v := os.Getenv("TRASH_PURGE_INTERVAL"); v != "" {
+			interval, err = time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TRASH_PURGE_INTERVAL: %w", err)
+			}
+		}
+		retention := scheduler.DefaultTrashRetention
+		if v := os.Getenv("TRASH_RETENTION"); v != "" {
+			retention, err = time.ParseDuration(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TRASH_RETENTION: %w", err)
+			}
+		}
+		return scheduler.NewPurger(uc, clock.System{}, interval, retention, scheduler.DefaultBatchSize), nil
+	})
+
+	c.tokenManagerOnce = sync.OnceValues(func() (*token.Manager, error) {
+		secret := os.Getenv("AUTH_TOKEN_SECRET")
+		if secret == "" {
+			return nil, errors.New("AUTH_TOKEN_SECRET is not set")
+		}
+		return token.NewManager(secret, token.DefaultTTL)
+	})
+
+	c.loginUsecaseOnce = sync.OnceValues(func() (*userusecase.LoginUsecase, error) {
+		repo, err := c.UserRepository()
+		if err != nil {
+			return nil, err
+		}
+		tokens, err := c.TokenManager()
+		if err != nil {
+			return nil, err
+		}
+		return userusecase.NewLoginUsecase(repo, tokens), nil
+	})
+
+	c.registerUserUsecaseOnce = sync.OnceValues(func() (*userusecase.RegisterUserUsecase, error) {
+		repo, err := c.UserRepository()
+		if err != nil {
+			return nil, err
+		}
+		return userusecase.NewRegisterUserUsecase(repo), nil
+	})
+
+	c.webhookEndpointRepoOnce = sync.OnceValues(func() (webhookrepository.EndpointRepository, error) {
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		return webhookrdb.NewEndpointRepository(db), nil
+	})
+
+	c.webhookDeliveryRepoOnce = sync.OnceValues(func() (webhookrepository.DeliveryRepository, error) {
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		return webhookrdb.NewDeliveryRepository(db), nil
+	})
+
+	c.registerWebhookUsecaseOnce = sync.OnceValues(func() (*webhookusecase.RegisterEndpointUsecase, error) {
+		repo, err := c.WebhookEndpointRepository()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return webhookusecase.NewRegisterEndpointUsecase(repo, policy), nil
+	})
+
+	c.deactivateWebhookUsecaseOnce = sync.OnceValues(func() (*webhookusecase.DeactivateEndpointUsecase, error) {
+		repo, err := c.WebhookEndpointRepository()
+		if err != nil {
+			return nil, err
+		}
+		policy, err := c.Policy()
+		if err != nil {
+			return nil, err
+		}
+		return webhookusecase.NewDeactivateEndpointUsecase(repo, policy), nil
+	})
+
+	c.enqueueWebhookDeliveriesUsecaseOnce = sync.OnceValues(func() (*webhookusecase.EnqueueDeliveriesUsecase, error) {
+		posts, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		endpoints, err := c.WebhookEndpointRepository()
+		if err != nil {
+			return nil, err
+		}
+		deliveries, err := c.WebhookDeliveryRepository()
+		if err != nil {
+			return nil, err
+		}
+		return webhookusecase.NewEnqueueDeliveriesUsecase(posts, endpoints, deliveries), nil
+	})
+
+	c.webhookWorkerOnce = sync.OnceValues(func() (*delivery.Worker, error) {
+		repo, err := c.WebhookDeliveryRepository()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		client := &http.Client{Timeout: 10 * time.Second}
+		return delivery.NewWorker(repo, tx, client, clock.System{}, delivery.DefaultConfig()), nil
+	})
+
+	// SNS コネクタは環境変数が設定されたものだけを有効にする
+	c.socialPublishersOnce = sync.OnceValues(func() ([]connector.SocialPublisher, error) {
+		client := &http.Client{Timeout: 10 * time.Second}
+
+		var publishers []connector.SocialPublisher
+		if baseURL := os.Getenv("MASTODON_BASE_URL"); baseURL != "" {
+			token := os.Getenv("MASTODON_ACCESS_TOKEN")
+			if token == "" {
+				return nil, errors.New("MASTODON_ACCESS_TOKEN is not set")
+			}
+			publishers = append(publishers, connector.NewMastodon(baseURL, token, connector.MastodonLimits, client))
+		}
+		if url := os.Getenv("SNS_HTTP_URL"); url != "" {
+			network := os.Getenv("SNS_HTTP_NETWORK")
+			if network == "" {
+				network = "http"
+			}
+			limits := sns.Limits{MaxLength: 280}
+			if v := os.Getenv("SNS_HTTP_MAX_LENGTH"); v != "" {
+				maxLength, err := strconv.Atoi(v)
+				if err != nil || maxLength <= 0 {
+					return nil, fmt.Errorf("invalid SNS_HTTP_MAX_LENGTH: %q", v)
+				}
+				limits.MaxLength = maxLength
+			}
+			publishers = append(publishers, connector.NewHTTP(network, url, os.Getenv("SNS_HTTP_TOKEN"), limits, client))
+		}
+		return publishers, nil
+	})
+
+	c.snsShareRepoOnce = sync.OnceValues(func() (snsrepository.ShareRepository, error) {
+		db, err := c.DB()
+		if err != nil {
+			return nil, err
+		}
+		return snsrdb.NewShareRepository(db), nil
+	})
+
+	c.enqueueSNSSharesUsecaseOnce = sync.OnceValues(func() (*snsusecase.EnqueueSharesUsecase, error) {
+		posts, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		shares, err := c.SNSShareRepository()
+		if err != nil {
+			return nil, err
+		}
+		publishers, err := c.SocialPublishers()
+		if err != nil {
+			return nil, err
+		}
+		networks := make([]string, 0, len(publishers))
+		for _, p := range publishers {
+			networks = append(networks, p.Network())
+		}
+		return snsusecase.NewEnqueueSharesUsecase(posts, shares, networks), nil
+	})
+
+	c.snsWorkerOnce = sync.OnceValues(func() (*autopost.Worker, error) {
+		shares, err := c.SNSShareRepository()
+		if err != nil {
+			return nil, err
+		}
+		posts, err := c.PostRepository()
+		if err != nil {
+			return nil, err
+		}
+		tx, err := c.Transactor()
+		if err != nil {
+			return nil, err
+		}
+		publishers, err := c.SocialPublishers()
+		if err != nil {
+			return nil, err
+		}
+		baseURL := os.Getenv("SITE_BASE_URL")
+		if baseURL == "" {
+			baseURL = "http://localhost:3000"
+		}
+		return autopost.NewWorker(shares, posts, tx, publishers, clock.System{}, autopost.DefaultConfig(baseURL)), nil
 	})
 }
 
@@ -108,6 +796,10 @@ func (c *Container) EventDispatcher() (event.EventDispatcher, error) {
 	return c.eventDispatcherOnce()
 }
 
+func (c *Container) Policy() (usecase.Policy, error) {
+	return c.policyOnce()
+}
+
 func (c *Container) CreatePostUsecase() (*usecase.CreatePostUsecase, error) {
 	return c.createPostUsecaseOnce()
 }
@@ -116,10 +808,163 @@ func (c *Container) UpdatePostUsecase() (*usecase.UpdatePostUsecase, error) {
 	return c.updatePostUsecaseOnce()
 }
 
+func (c *Container) TransitionPostUsecase() (*usecase.TransitionPostUsecase, error) {
+	return c.transitionPostUsecaseOnce()
+}
+
 func (c *Container) DeletePostUsecase() (*usecase.DeletePostUsecase, error) {
 	return c.deletePostUsecaseOnce()
 }
 
+func (c *Container) RestorePostUsecase() (*usecase.RestorePostUsecase, error) {
+	return c.restorePostUsecaseOnce()
+}
+
+func (c *Container) ListTrashUsecase() (*usecase.ListTrashUsecase, error) {
+	return c.listTrashUsecaseOnce()
+}
+
+func (c *Container) RevisionRepository() (repository.RevisionRepository, error) {
+	return c.revisionRepoOnce()
+}
+
+func (c *Container) RestoreRevisionUsecase() (*usecase.RestoreRevisionUsecase, error) {
+	return c.restoreRevisionUsecaseOnce()
+}
+
+func (c *Container) ListRevisionsUsecase() (*usecase.ListRevisionsUsecase, error) {
+	return c.listRevisionsUsecaseOnce()
+}
+
+func (c *Container) ReadRevisionUsecase() (*usecase.ReadRevisionUsecase, error) {
+	return c.readRevisionUsecaseOnce()
+}
+
+func (c *Container) DiffRevisionsUsecase() (*usecase.DiffRevisionsUsecase, error) {
+	return c.diffRevisionsUsecaseOnce()
+}
+
+func (c *Container) ReviewRepository() (repository.ReviewRepository, error) {
+	return c.reviewRepoOnce()
+}
+
+func (c *Container) ReviewPostUsecase() (*usecase.ReviewPostUsecase, error) {
+	return c.reviewPostUsecaseOnce()
+}
+
+func (c *Container) ListReviewsUsecase() (*usecase.ListReviewsUsecase, error) {
+	return c.listReviewsUsecaseOnce()
+}
+
+// RuleRepository holds the editorial rules: those of POST_RULES_FILE, or the
+// built-in defaults, overridden by the scopes edited through the admin API.
+func (c *Container) RuleRepository() (rulerepository.RuleRepository, error) {
+	return c.ruleRepoOnce()
+}
+
+func (c *Container) RuleEngine() (*usecase.RuleEngine, error) {
+	return c.ruleEngineOnce()
+}
+
+func (c *Container) UpdateRulesUsecase() (*ruleusecase.UpdateRulesUsecase, error) {
+	return c.updateRulesUsecaseOnce()
+}
+
+func (c *Container) DeleteCategoryRulesUsecase() (*ruleusecase.DeleteCategoryRulesUsecase, error) {
+	return c.deleteCategoryRulesUsecaseOnce()
+}
+
 func (c *Container) AnalyzePostUsecase() (*usecase.AnalyzePostUsecase, error) {
 	return c.analyzePostUsecaseOnce()
 }
+
+func (c *Container) Transactor() (repository.Transactor, error) {
+	return c.transactorOnce()
+}
+
+func (c *Container) PublishScheduledPostsUsecase() (*usecase.PublishScheduledPostsUsecase, error) {
+	return c.publishScheduledPostsUsecaseOnce()
+}
+
+func (c *Container) Scheduler() (*scheduler.Scheduler, error) {
+	return c.schedulerOnce()
+}
+
+func (c *Container) PurgeTrashUsecase() (*usecase.PurgeTrashUsecase, error) {
+	return c.purgeTrashUsecaseOnce()
+}
+
+// Purger removes posts from the trash once TRASH_RETENTION has passed.
+func (c *Container) Purger() (*scheduler.Purger, error) {
+	return c.purgerOnce()
+}
+
+// OutboxRelay delivers events committed to the outbox table.
+// Handlers must be registered before the relay is run.
+func (c *Container) OutboxRelay() (*outbox.Relay, error) {
+	return c.outboxRelayOnce()
+}
+
+// EventBus fans events relayed from the outbox out to the subscribers.
+func (c *Container) EventBus() (*event.Bus, error) {
+	return c.eventBusOnce()
+}
+
+func (c *Container) UserRepository() (userrepository.UserRepository, error) {
+	return c.userRepoOnce()
+}
+
+func (c *Container) TokenManager() (*token.Manager, error) {
+	return c.tokenManagerOnce()
+}
+
+func (c *Container) LoginUsecase() (*userusecase.LoginUsecase, error) {
+	return c.loginUsecaseOnce()
+}
+
+func (c *Container) RegisterUserUsecase() (*userusecase.RegisterUserUsecase, error) {
+	return c.registerUserUsecaseOnce()
+}
+
+func (c *Container) WebhookEndpointRepository() (webhookrepository.EndpointRepository, error) {
+	return c.webhookEndpointRepoOnce()
+}
+
+func (c *Container) WebhookDeliveryRepository() (webhookrepository.DeliveryRepository, error) {
+	return c.webhookDeliveryRepoOnce()
+}
+
+func (c *Container) RegisterWebhookUsecase() (*webhookusecase.RegisterEndpointUsecase, error) {
+	return c.registerWebhookUsecaseOnce()
+}
+
+func (c *Container) DeactivateWebhookUsecase() (*webhookusecase.DeactivateEndpointUsecase, error) {
+	return c.deactivateWebhookUsecaseOnce()
+}
+
+func (c *Container) EnqueueWebhookDeliveriesUsecase() (*webhookusecase.EnqueueDeliveriesUsecase, error) {
+	return c.enqueueWebhookDeliveriesUsecaseOnce()
+}
+
+// WebhookWorker sends pending webhook deliveries to their endpoints.
+func (c *Container) WebhookWorker() (*delivery.Worker, error) {
+	return c.webhookWorkerOnce()
+}
+
+// SocialPublishers are the SNS connectors enabled by the environment.
+func (c *Container) SocialPublishers() ([]connector.SocialPublisher, error) {
+	return c.socialPublishersOnce()
+}
+
+func (c *Container) SNSShareRepository() (snsrepository.ShareRepository, error) {
+	return c.snsShareRepoOnce()
+}
+
+func (c *Container) EnqueueSNSSharesUsecase() (*snsusecase.EnqueueSharesUsecase, error) {
+	return c.enqueueSNSSharesUsecaseOnce()
+}
+
+// SNSWorker announces published posts on the enabled networks.
+func (c *Container) SNSWorker() (*autopost.Worker, error) {
+	return c.snsWorkerOnce()
+}
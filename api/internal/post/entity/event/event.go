@@ -4,6 +4,19 @@ import "github.com/ss49919201/myblog/api/internal/post/id"
 
 type ID id.UUID
 
+func (i ID) String() string {
+	return id.UUID(i).String()
+}
+
 func GenerateID() ID {
 	return ID(id.GenerateUUID())
 }
+
+func ParseID(s string) (ID, error) {
+	parsed, err := id.ParseUUID(s)
+	if err != nil {
+		return ID{}, err
+	}
+
+	return ID(parsed), nil
+}
@@ -0,0 +1,105 @@
+package post
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/ss49919201/myblog/api/internal/markdown"
+	"github.com/ss49919201/myblog/api/internal/sanitize"
+)
+
+// BodyFormat is the markup the body of a post is written in.
+type BodyFormat string
+
+const (
+	BodyFormatMarkdown BodyFormat = "markdown"
+	BodyFormatHTML     BodyFormat = "html"
+	BodyFormatPlain    BodyFormat = "plain"
+)
+
+func (f BodyFormat) Valid() bool {
+	switch f {
+	case BodyFormatMarkdown, BodyFormatHTML, BodyFormatPlain:
+		return true
+	}
+	return false
+}
+
+func ValidateBodyFormat(format BodyFormat) error {
+	if !format.Valid() {
+		return NewValidationError("bodyFormat", ValidationCodeInvalidValue, "body format must be markdown, html or plain")
+	}
+	return nil
+}
+
+// RenderBody converts body written in format to the HTML shown to readers.
+// The result is always sanitized.
+func RenderBody(format BodyFormat, body string) (string, error) {
+	switch format {
+	case BodyFormatMarkdown:
+		rendered, err := markdown.Render(body)
+		if err != nil {
+			return "", err
+		}
+		return sanitize.Sanitize(rendered), nil
+	case BodyFormatHTML:
+		return sanitize.Sanitize(body), nil
+	case BodyFormatPlain:
+		return renderPlain(body), nil
+	}
+	return "", fmt.Errorf("unknown body format %q", format)
+}
+
+// renderPlain escapes body and turns blank-line separated blocks into
+// paragraphs and the remaining line breaks into <br>.
+func renderPlain(body string) string {
+	var b strings.Builder
+	for _, paragraph := range strings.Split(body, "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if paragraph == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// ValidateBodyMarkup reports the markup in body that would be removed when it
+// is rendered, as ValidationErrors. HTML bodies are checked as written, so
+// the errors carry line numbers of the body. Markdown bodies are checked
+// after rendering, since code blocks may show markup that is not meant as
+// HTML. Plain bodies hold no markup.
+func ValidateBodyMarkup(format BodyFormat, body string) error {
+	var errs ValidationErrors
+
+	switch format {
+	case BodyFormatHTML:
+		for _, issue := range sanitize.Check(body) {
+			_ = errs.Add(NewValidationError("body", ValidationCodeInvalidHTML, issue.String()))
+		}
+	case BodyFormatMarkdown:
+		rendered, err := markdown.Render(body)
+		if err != nil {
+			return err
+		}
+		for _, issue := range sanitize.Check(rendered) {
+			_ = errs.Add(NewValidationError("body", ValidationCodeInvalidHTML, issue.Message()))
+		}
+	}
+
+	return errs.Err()
+}
+
+// render refreshes RenderedBody, the sanitized HTML of the body that is
+// stored with the post so that readers are served without rendering again.
+func (p *Post) render() error {
+	rendered, err := RenderBody(p.BodyFormat, p.Body)
+	if err != nil {
+		return err
+	}
+	p.RenderedBody = rendered
+	return nil
+}
@@ -0,0 +1,78 @@
+package post
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		format BodyFormat
+		in     string
+		want   string
+	}{
+		{name: "markdown", format: BodyFormatMarkdown, in: "# 見出し\n\n**強調**", want: "<h1>見出し</h1>\n<p><strong>強調</strong></p>\n"},
+		{name: "markdown raw html is sanitized", format: BodyFormatMarkdown, in: "本文<script>alert(1)</script>", want: "<p>本文</p>\n"},
+		{name: "html", format: BodyFormatHTML, in: `<p onclick="x()">本文</p>`, want: "<p>本文</p>"},
+		{name: "plain", format: BodyFormatPlain, in: "1行目 <b>\n2行目\n\n次の段落", want: "<p>1行目 &lt;b&gt;<br>\n2行目</p>\n<p>次の段落</p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderBody(tt.format, tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderBody() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := RenderBody("rtf", "body"); err == nil {
+		t.Error("RenderBody() error = nil, want error for unknown format")
+	}
+}
+
+func TestValidateBodyMarkup(t *testing.T) {
+	err := ValidateBodyMarkup(BodyFormatHTML, "<p>ok</p>\n<img src=\"javascript:x\">")
+	all := ValidationErrorsOf(err)
+	if len(all) != 1 || all[0].Code != ValidationCodeInvalidHTML || all[0].Message != "line 2: URL in src of <img> uses a scheme that is not allowed" {
+		t.Errorf("ValidateBodyMarkup(html) = %v", err)
+	}
+
+	// コードブロック内のタグはマークアップではない
+	if err := ValidateBodyMarkup(BodyFormatMarkdown, "```html\n<script>alert(1)</script>\n```"); err != nil {
+		t.Errorf("ValidateBodyMarkup(markdown code block) = %v, want nil", err)
+	}
+	if err := ValidateBodyMarkup(BodyFormatMarkdown, "本文 <span onclick=\"x()\">a</span>"); err == nil {
+		t.Error("ValidateBodyMarkup(markdown raw html) = nil, want error")
+	}
+	if err := ValidateBodyMarkup(BodyFormatPlain, "<script>"); err != nil {
+		t.Errorf("ValidateBodyMarkup(plain) = %v, want nil", err)
+	}
+}
+
+func TestPost_RenderedBodyFollowsChanges(t *testing.T) {
+	p := newPatchTestPost(t)
+	if !strings.HasPrefix(p.RenderedBody, "<p>") {
+		t.Fatalf("RenderedBody = %q, want rendered markdown", p.RenderedBody)
+	}
+
+	merged, err := p.Patched(Patch{
+		Body:       SetField(strings.Repeat("plain text ", 10) + "\n\n*not emphasis*"),
+		BodyFormat: SetField(BodyFormatPlain),
+	}, testAuthorID, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(merged.RenderedBody, "<p>*not emphasis*</p>") {
+		t.Errorf("RenderedBody = %q, want plain paragraphs", merged.RenderedBody)
+	}
+
+	if _, err := p.Patched(Patch{BodyFormat: SetField(BodyFormat("rtf"))}, testAuthorID, time.Now()); !IsErrValidation(err) {
+		t.Errorf("Patched() error = %v, want validation error", err)
+	}
+}
@@ -1,6 +1,9 @@
 package post
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+)
 
 type ErrPostNotFound struct {
 }
@@ -21,3 +24,184 @@ func AsErrPostNotFound(err error) (*ErrPostNotFound, bool) {
 
 	return nil, false
 }
+
+// ErrForbidden is returned when the caller is not allowed to perform an action.
+type ErrForbidden struct {
+	Action string
+	Reason string
+}
+
+func NewForbiddenError(action, reason string) *ErrForbidden {
+	return &ErrForbidden{
+		Action: action,
+		Reason: reason,
+	}
+}
+
+func (e *ErrForbidden) Error() string {
+	return e.Reason
+}
+
+func AsErrForbidden(err error) (*ErrForbidden, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrForbidden
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
+
+// ErrInvalidTransition is returned when a post cannot move between two
+// publication states.
+type ErrInvalidTransition struct {
+	From PublicationStatus
+	To   PublicationStatus
+}
+
+func (e *ErrInvalidTransition) Error() string {
+	return "cannot change status from " + e.From.String() + " to " + e.To.String()
+}
+
+func AsErrInvalidTransition(err error) (*ErrInvalidTransition, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrInvalidTransition
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
+
+// ErrSlugConflict is returned when another post already uses the slug.
+// Suggestions lists free slugs derived from it.
+type ErrSlugConflict struct {
+	Slug        string
+	Suggestions []string
+}
+
+func (e *ErrSlugConflict) Error() string {
+	return "slug " + e.Slug + " is already taken"
+}
+
+func AsErrSlugConflict(err error) (*ErrSlugConflict, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrSlugConflict
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
+
+// ErrRevisionNotFound is returned when a post has no revision with the number.
+type ErrRevisionNotFound struct {
+	PostID PostID
+	Number int
+}
+
+func (e *ErrRevisionNotFound) Error() string {
+	return "revision " + strconv.Itoa(e.Number) + " of post " + e.PostID.String() + " not found"
+}
+
+func AsErrRevisionNotFound(err error) (*ErrRevisionNotFound, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrRevisionNotFound
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
+
+// ErrVersionConflict is returned when a post was changed after the caller
+// read it, so the caller's version no longer matches Current.
+type ErrVersionConflict struct {
+	PostID  PostID
+	Current int
+}
+
+func (e *ErrVersionConflict) Error() string {
+	return "post " + e.PostID.String() + " has been modified (current version " + strconv.Itoa(e.Current) + ")"
+}
+
+func AsErrVersionConflict(err error) (*ErrVersionConflict, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrVersionConflict
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
+
+// ErrInvalidReviewTransition is returned when a post cannot move between two
+// review states, e.g. when approving a post that was not submitted.
+type ErrInvalidReviewTransition struct {
+	From ReviewStatus
+	To   ReviewStatus
+}
+
+func (e *ErrInvalidReviewTransition) Error() string {
+	return "cannot change review status from " + e.From.String() + " to " + e.To.String()
+}
+
+func AsErrInvalidReviewTransition(err error) (*ErrInvalidReviewTransition, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrInvalidReviewTransition
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
+
+// ErrReviewRequired is returned when a post must be approved before it is
+// scheduled or published. Reasons lists the rules that require the review;
+// it is empty when the post is already pending or was rejected.
+type ErrReviewRequired struct {
+	PostID       PostID
+	ReviewStatus ReviewStatus
+	Reasons      []ReviewReason
+}
+
+func (e *ErrReviewRequired) Error() string {
+	switch e.ReviewStatus {
+	case ReviewStatusPending:
+		return "post is waiting for review"
+	case ReviewStatusRejected:
+		return "post was rejected in review and must be submitted again"
+	}
+	return "post requires approval before it is scheduled or published"
+}
+
+func AsErrReviewRequired(err error) (*ErrReviewRequired, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrReviewRequired
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
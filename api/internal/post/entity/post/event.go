@@ -0,0 +1,359 @@
+package post
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/event"
+)
+
+// PostEventSchemaVersion is the version of the JSON envelope and payloads
+// produced by PostEvent.MarshalJSON. Bump it on any incompatible change.
+const PostEventSchemaVersion = 1
+
+type PostEvent struct {
+	ID            event.ID
+	AggregateID   PostID
+	Type          PostEventType
+	SchemaVersion int
+	OccurredAt    time.Time
+	// ActorID is the user who caused the event, or nil for system actions
+	// such as the scheduler publishing a post.
+	ActorID *UserID
+	Payload PostEventPayload
+}
+
+// PostEventPayload is implemented by the typed payload of each event type:
+//
+//	post.created                      *PostCreatedPayload
+//	post.updated                      *PostUpdatedPayload
+//	post.deleted                      *PostDeletedPayload
+//	post.published, post.scheduled,
+//	post.unscheduled, post.unpublished,
+//	post.archived, post.unarchived    *PostStatusChangedPayload
+//	post.revision_restored            *PostRevisionRestoredPayload
+//	post.restored                     *PostRestoredPayload
+//	post.purged                       *PostPurgedPayload
+//	post.review_submitted,
+//	post.review_approved,
+//	post.review_rejected,
+//	post.review_invalidated           *PostReviewChangedPayload
+type PostEventPayload interface {
+	postEventPayload()
+}
+
+// PostSnapshot is the state of a post as exposed to event consumers.
+type PostSnapshot struct {
+	Title                string            `json:"title"`
+	Body                 string            `json:"body"`
+	BodyFormat           BodyFormat        `json:"bodyFormat"`
+	Status               PublicationStatus `json:"status"`
+	ScheduledAt          *time.Time        `json:"scheduledAt"`
+	Category             string            `json:"category"`
+	Tags                 []string          `json:"tags"`
+	FeaturedImageURL     *string           `json:"featuredImageURL"`
+	MetaDescription      *string           `json:"metaDescription"`
+	Slug                 *string           `json:"slug"`
+	SNSAutoPost          bool              `json:"snsAutoPost"`
+	ExternalNotification bool              `json:"externalNotification"`
+	EmergencyFlag        bool              `json:"emergencyFlag"`
+	CreatedAt            time.Time         `json:"createdAt"`
+	PublishedAt          *time.Time        `json:"publishedAt"`
+	AuthorID             *UserID           `json:"authorId"`
+}
+
+// snapshotFields lists the PostSnapshot JSON members in the order diffs report them.
+var snapshotFields = []string{
+	"title", "body", "bodyFormat", "status", "scheduledAt", "category", "tags", "featuredImageURL",
+	"metaDescription", "slug", "snsAutoPost", "externalNotification", "emergencyFlag",
+	"createdAt", "publishedAt", "authorId",
+}
+
+func (p *Post) Snapshot() PostSnapshot {
+	return PostSnapshot{
+		Title:                p.Title,
+		Body:                 p.Body,
+		BodyFormat:           p.BodyFormat,
+		Status:               p.Status,
+		ScheduledAt:          p.ScheduledAt,
+		Category:             p.Category,
+		Tags:                 slices.Clone(p.Tags),
+		FeaturedImageURL:     p.FeaturedImageURL,
+		MetaDescription:      p.MetaDescription,
+		Slug:                 p.Slug,
+		SNSAutoPost:          p.SNSAutoPost,
+		ExternalNotification: p.ExternalNotification,
+		EmergencyFlag:        p.EmergencyFlag,
+		CreatedAt:            p.CreatedAt,
+		PublishedAt:          p.PublishedAt,
+		AuthorID:             p.AuthorID,
+	}
+}
+
+type PostCreatedPayload struct {
+	Post PostSnapshot `json:"post"`
+}
+
+// PostUpdatedPayload lists the fields that differ between the post before and
+// after the update. Before and After hold the JSON encoding of each value.
+type PostUpdatedPayload struct {
+	Changes []FieldChange `json:"changes"`
+}
+
+type FieldChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+type PostDeletedPayload struct {
+	Post PostSnapshot `json:"post"`
+}
+
+// PostRestoredPayload is the state of a post taken out of the trash.
+type PostRestoredPayload struct {
+	Post PostSnapshot `json:"post"`
+}
+
+// PostPurgedPayload is the last state of a post removed from the trash for
+// good, and when it was moved to the trash.
+type PostPurgedPayload struct {
+	Post      PostSnapshot `json:"post"`
+	DeletedAt *time.Time   `json:"deletedAt"`
+}
+
+type PostStatusChangedPayload struct {
+	From        PublicationStatus `json:"from"`
+	To          PublicationStatus `json:"to"`
+	ScheduledAt *time.Time        `json:"scheduledAt"`
+	PublishedAt *time.Time        `json:"publishedAt"`
+}
+
+// PostReviewChangedPayload records a step of the approval workflow. Reasons
+// are the rules that required the review when the post was submitted.
+type PostReviewChangedPayload struct {
+	From    ReviewStatus   `json:"from"`
+	To      ReviewStatus   `json:"to"`
+	Comment *string        `json:"comment"`
+	Reasons []ReviewReason `json:"reasons"`
+}
+
+// PostRevisionRestoredPayload names the revision whose content was restored.
+// The changed fields are reported by the post.updated event emitted with it.
+type PostRevisionRestoredPayload struct {
+	Revision int `json:"revision"`
+}
+
+func (*PostCreatedPayload) postEventPayload()          {}
+func (*PostUpdatedPayload) postEventPayload()          {}
+func (*PostDeletedPayload) postEventPayload()          {}
+func (*PostStatusChangedPayload) postEventPayload()    {}
+func (*PostRevisionRestoredPayload) postEventPayload() {}
+func (*PostRestoredPayload) postEventPayload()         {}
+func (*PostPurgedPayload) postEventPayload()           {}
+func (*PostReviewChangedPayload) postEventPayload()    {}
+
+// postEventTypeNames are the stable names used when events leave the process,
+// e.g. in the outbox table. Never rename an existing entry.
+var postEventTypeNames = map[PostEventType]string{
+	PostEventTypeCreatePost:       "post.created",
+	PostEventTypeUpdatePost:       "post.updated",
+	PostEventTypePublishPost:      "post.published",
+	PostEventTypeSchedulePost:     "post.scheduled",
+	PostEventTypeUnschedulePost:   "post.unscheduled",
+	PostEventTypeUnpublishPost:    "post.unpublished",
+	PostEventTypeArchivePost:      "post.archived",
+	PostEventTypeUnarchivePost:    "post.unarchived",
+	PostEventTypeDeletePost:       "post.deleted",
+	PostEventTypeRestoreRevision:  "post.revision_restored",
+	PostEventTypeRestorePost:      "post.restored",
+	PostEventTypePurgePost:        "post.purged",
+	PostEventTypeSubmitReview:     "post.review_submitted",
+	PostEventTypeApproveReview:    "post.review_approved",
+	PostEventTypeRejectReview:     "post.review_rejected",
+	PostEventTypeInvalidateReview: "post.review_invalidated",
+}
+
+func (t PostEventType) String() string {
+	if name, ok := postEventTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("PostEventType(%d)", int(t))
+}
+
+func ParsePostEventType(name string) (PostEventType, error) {
+	for t, n := range postEventTypeNames {
+		if n == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown post event type %q", name)
+}
+
+// newPayload returns an empty payload of the type carried by t.
+func (t PostEventType) newPayload() PostEventPayload {
+	switch t {
+	case PostEventTypeCreatePost:
+		return &PostCreatedPayload{}
+	case PostEventTypeUpdatePost:
+		return &PostUpdatedPayload{}
+	case PostEventTypeDeletePost:
+		return &PostDeletedPayload{}
+	case PostEventTypeRestoreRevision:
+		return &PostRevisionRestoredPayload{}
+	case PostEventTypeRestorePost:
+		return &PostRestoredPayload{}
+	case PostEventTypePurgePost:
+		return &PostPurgedPayload{}
+	case PostEventTypeSubmitReview, PostEventTypeApproveReview, PostEventTypeRejectReview, PostEventTypeInvalidateReview:
+		return &PostReviewChangedPayload{}
+	default:
+		return &PostStatusChangedPayload{}
+	}
+}
+
+// postEventEnvelope is the wire format of a PostEvent:
+//
+//	{
+//	  "id": "…", "type": "post.updated", "schemaVersion": 1,
+//	  "aggregateType": "post", "aggregateId": "…",
+//	  "occurredAt": "2025-01-01T12:00:00Z", "actorId": "…" | null,
+//	  "payload": { … }
+//	}
+type postEventEnvelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schemaVersion"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	ActorID       *UserID         `json:"actorId"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+const postAggregateType = "post"
+
+func (e PostEvent) MarshalJSON() ([]byte, error) {
+	payload, err := json.Marshal(e.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(postEventEnvelope{
+		ID:            e.ID.String(),
+		Type:          e.Type.String(),
+		SchemaVersion: e.SchemaVersion,
+		AggregateType: postAggregateType,
+		AggregateID:   e.AggregateID.String(),
+		OccurredAt:    e.OccurredAt.UTC(),
+		ActorID:       e.ActorID,
+		Payload:       payload,
+	})
+}
+
+func (e *PostEvent) UnmarshalJSON(data []byte) error {
+	var envelope postEventEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return err
+	}
+
+	if envelope.AggregateType != postAggregateType {
+		return fmt.Errorf("unexpected aggregate type %q", envelope.AggregateType)
+	}
+	if envelope.SchemaVersion < 1 || envelope.SchemaVersion > PostEventSchemaVersion {
+		return fmt.Errorf("unsupported post event schema version %d", envelope.SchemaVersion)
+	}
+
+	eventID, err := event.ParseID(envelope.ID)
+	if err != nil {
+		return err
+	}
+	aggregateID, err := ParsePostID(envelope.AggregateID)
+	if err != nil {
+		return err
+	}
+	eventType, err := ParsePostEventType(envelope.Type)
+	if err != nil {
+		return err
+	}
+
+	payload := eventType.newPayload()
+	if err := json.Unmarshal(envelope.Payload, payload); err != nil {
+		return err
+	}
+
+	*e = PostEvent{
+		ID:            eventID,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		SchemaVersion: envelope.SchemaVersion,
+		OccurredAt:    envelope.OccurredAt,
+		ActorID:       envelope.ActorID,
+		Payload:       payload,
+	}
+	return nil
+}
+
+// diffSnapshots reports the fields whose JSON encoding differs.
+func diffSnapshots(before, after PostSnapshot) ([]FieldChange, error) {
+	beforeFields, err := snapshotMembers(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := snapshotMembers(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []FieldChange{}
+	for _, field := range snapshotFields {
+		if !bytes.Equal(beforeFields[field], afterFields[field]) {
+			changes = append(changes, FieldChange{Field: field, Before: beforeFields[field], After: afterFields[field]})
+		}
+	}
+	return changes, nil
+}
+
+func snapshotMembers(s PostSnapshot) (map[string]json.RawMessage, error) {
+	// nil と空のタグを同一視する
+	if s.Tags == nil {
+		s.Tags = []string{}
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (p *Post) appendEvent(eventType PostEventType, actorID *UserID, occurredAt time.Time, payload PostEventPayload) {
+	p.Events = append(p.Events, PostEvent{
+		ID:            event.GenerateID(),
+		AggregateID:   p.ID,
+		Type:          eventType,
+		SchemaVersion: PostEventSchemaVersion,
+		OccurredAt:    occurredAt,
+		ActorID:       actorID,
+		Payload:       payload,
+	})
+}
+
+// appendUpdateEvent records the difference between before and p.
+func (p *Post) appendUpdateEvent(before PostSnapshot, actorID *UserID, occurredAt time.Time) error {
+	changes, err := diffSnapshots(before, p.Snapshot())
+	if err != nil {
+		return err
+	}
+
+	p.appendEvent(PostEventTypeUpdatePost, actorID, occurredAt, &PostUpdatedPayload{Changes: changes})
+	return nil
+}
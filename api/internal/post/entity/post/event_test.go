@@ -0,0 +1,205 @@
+package post
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/id"
+)
+
+func TestPostEvent_JSONEnvelope(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := newTransitionTestPost(t, StatusDraft, now)
+	if err := p.Publish(&testActorID, now); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	e := p.Events[0]
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var envelope map[string]any
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	want := map[string]any{
+		"id":            e.ID.String(),
+		"type":          "post.published",
+		"schemaVersion": float64(PostEventSchemaVersion),
+		"aggregateType": "post",
+		"aggregateId":   p.ID.String(),
+		"occurredAt":    "2025-01-01T12:00:00Z",
+		"actorId":       testActorID.String(),
+	}
+	for key, value := range want {
+		if envelope[key] != value {
+			t.Errorf("envelope[%q] = %v, want %v", key, envelope[key], value)
+		}
+	}
+	payload, _ := envelope["payload"].(map[string]any)
+	if payload["from"] != "draft" || payload["to"] != "published" {
+		t.Errorf("payload = %v, want draft -> published", payload)
+	}
+
+	var decoded PostEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal(PostEvent) error = %v", err)
+	}
+	if decoded.ID != e.ID || decoded.AggregateID != e.AggregateID || decoded.Type != e.Type ||
+		decoded.ActorID == nil || *decoded.ActorID != testActorID || !decoded.OccurredAt.Equal(now) {
+		t.Errorf("decoded = %+v, want %+v", decoded, e)
+	}
+	if got, ok := decoded.Payload.(*PostStatusChangedPayload); !ok || got.To != StatusPublished {
+		t.Errorf("decoded payload = %#v", decoded.Payload)
+	}
+}
+
+func TestPostEvent_UnmarshalJSON_RejectsUnknownEnvelopes(t *testing.T) {
+	valid := `{"id":"457ff1e4-8705-4a75-8002-c185a3661430","type":"post.deleted","schemaVersion":1,"aggregateType":"post","aggregateId":"457ff1e4-8705-4a75-8002-c185a3661431","occurredAt":"2025-01-01T12:00:00Z","actorId":null,"payload":{"post":{}}}`
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "valid", data: valid},
+		{name: "future schema version", data: strings.Replace(valid, `"schemaVersion":1`, `"schemaVersion":2`, 1), wantErr: "schema version"},
+		{name: "unknown type", data: strings.Replace(valid, `"post.deleted"`, `"post.liked"`, 1), wantErr: "unknown post event type"},
+		{name: "other aggregate", data: strings.Replace(valid, `"aggregateType":"post"`, `"aggregateType":"user"`, 1), wantErr: "aggregate type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e PostEvent
+			err := json.Unmarshal([]byte(tt.data), &e)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error = %v", err)
+				}
+				if _, ok := e.Payload.(*PostDeletedPayload); !ok || e.ActorID != nil {
+					t.Errorf("decoded = %+v, want a system delete event", e)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPost_Patched_RecordsChangedFields(t *testing.T) {
+	p := newPatchTestPost(t)
+	editorID := UserID(id.GenerateUUID())
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	merged, err := p.Patched(Patch{
+		Title:           SetField("New Title"),
+		Category:        SetField("技術"), // unchanged value is not a change
+		MetaDescription: SetField[*string](nil),
+		Tags:            SetField([]string{"go"}),
+	}, editorID, now)
+	if err != nil {
+		t.Fatalf("Patched() error = %v", err)
+	}
+
+	e := merged.Events[len(merged.Events)-1]
+	payload, ok := e.Payload.(*PostUpdatedPayload)
+	if !ok {
+		t.Fatalf("Payload = %#v, want *PostUpdatedPayload", e.Payload)
+	}
+	if e.ActorID == nil || *e.ActorID != editorID || !e.OccurredAt.Equal(now) {
+		t.Errorf("event = %+v, want actor %v at %v", e, editorID, now)
+	}
+
+	want := []FieldChange{
+		{Field: "title", Before: json.RawMessage(`"Original Title"`), After: json.RawMessage(`"New Title"`)},
+		{Field: "tags", Before: json.RawMessage(`["go","test"]`), After: json.RawMessage(`["go"]`)},
+	}
+	if len(payload.Changes) != len(want) {
+		t.Fatalf("Changes = %s, want %d changes", mustJSON(t, payload.Changes), len(want))
+	}
+	for i, change := range payload.Changes {
+		if change.Field != want[i].Field || string(change.Before) != string(want[i].Before) || string(change.After) != string(want[i].After) {
+			t.Errorf("Changes[%d] = %s, want %s", i, mustJSON(t, change), mustJSON(t, want[i]))
+		}
+	}
+}
+
+func TestPost_Delete_RecordsLastState(t *testing.T) {
+	p := newPatchTestPost(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	p.Delete(testActorID, now)
+
+	e := p.Events[len(p.Events)-1]
+	payload, ok := e.Payload.(*PostDeletedPayload)
+	if e.Type != PostEventTypeDeletePost || !ok {
+		t.Fatalf("event = %+v, want post.deleted", e)
+	}
+	if payload.Post.Title != p.Title {
+		t.Errorf("snapshot title = %q, want %q", payload.Post.Title, p.Title)
+	}
+	if !p.IsTrashed() || !p.DeletedAt.Equal(now) || *p.DeletedBy != testActorID {
+		t.Errorf("DeletedAt, DeletedBy = %v, %v, want %v, %v", p.DeletedAt, p.DeletedBy, now, testActorID)
+	}
+}
+
+func TestPost_TrashEvents_RoundTrip(t *testing.T) {
+	p := newPatchTestPost(t)
+	deletedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	p.Delete(testActorID, deletedAt)
+
+	p.Purge(deletedAt.Add(time.Hour))
+	purged := p.Events[len(p.Events)-1]
+
+	p.Restore(testActorID, deletedAt.Add(2*time.Hour))
+	restored := p.Events[len(p.Events)-1]
+	if p.IsTrashed() || p.DeletedBy != nil {
+		t.Errorf("DeletedAt, DeletedBy = %v, %v, want nil", p.DeletedAt, p.DeletedBy)
+	}
+
+	tests := []struct {
+		event    PostEvent
+		wantType string
+	}{
+		{purged, "post.purged"},
+		{restored, "post.restored"},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.event)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		var decoded PostEvent
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", data, err)
+		}
+		if decoded.Type.String() != tt.wantType {
+			t.Errorf("Type = %v, want %v", decoded.Type, tt.wantType)
+		}
+		if !strings.Contains(string(data), `"title":"`+p.Title+`"`) {
+			t.Errorf("%s payload = %s, want the post snapshot", tt.wantType, data)
+		}
+	}
+
+	if payload := purged.Payload.(*PostPurgedPayload); payload.DeletedAt == nil || !payload.DeletedAt.Equal(deletedAt) {
+		t.Errorf("purged DeletedAt = %v, want %v", payload.DeletedAt, deletedAt)
+	}
+	if purged.ActorID != nil {
+		t.Errorf("purged ActorID = %v, want nil", purged.ActorID)
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
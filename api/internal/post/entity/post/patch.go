@@ -0,0 +1,163 @@
+package post
+
+import (
+	"slices"
+	"time"
+)
+
+// Field is one member of a Patch. Set is false when the member was absent
+// from the request, in which case the current value is kept.
+type Field[T any] struct {
+	Set   bool
+	Value T
+}
+
+func SetField[T any](v T) Field[T] {
+	return Field[T]{Set: true, Value: v}
+}
+
+func (f Field[T]) apply(dst *T) {
+	if f.Set {
+		*dst = f.Value
+	}
+}
+
+// Patch is a partial update of a Post following RFC 7396 (JSON Merge Patch).
+// Optional members are pointers: a set Field holding nil clears the value.
+type Patch struct {
+	Title                Field[string]
+	Body                 Field[string]
+	BodyFormat           Field[BodyFormat]
+	Status               Field[PublicationStatus]
+	ScheduledAt          Field[*time.Time]
+	Category             Field[string]
+	Tags                 Field[[]string]
+	FeaturedImageURL     Field[*string]
+	MetaDescription      Field[*string]
+	Slug                 Field[*string]
+	SNSAutoPost          Field[bool]
+	ExternalNotification Field[bool]
+	EmergencyFlag        Field[bool]
+}
+
+// Patched returns a copy of p with patch merged in. p itself is not modified,
+// so callers can validate the merged result before persisting it.
+// A status change is applied through the lifecycle methods in transition.go.
+func (p *Post) Patched(patch Patch, editorID UserID, now time.Time) (*Post, error) {
+	merged := *p
+	merged.Tags = slices.Clone(p.Tags)
+	merged.Events = slices.Clone(p.Events)
+
+	patch.Title.apply(&merged.Title)
+	patch.Body.apply(&merged.Body)
+	patch.BodyFormat.apply(&merged.BodyFormat)
+	patch.ScheduledAt.apply(&merged.ScheduledAt)
+	patch.Category.apply(&merged.Category)
+	patch.Tags.apply(&merged.Tags)
+	patch.FeaturedImageURL.apply(&merged.FeaturedImageURL)
+	patch.MetaDescription.apply(&merged.MetaDescription)
+	patch.Slug.apply(&merged.Slug)
+	patch.SNSAutoPost.apply(&merged.SNSAutoPost)
+	patch.ExternalNotification.apply(&merged.ExternalNotification)
+	patch.EmergencyFlag.apply(&merged.EmergencyFlag)
+
+	if merged.Tags == nil {
+		merged.Tags = []string{}
+	}
+
+	// 変更したフィールドだけ正規化する
+	if patch.Title.Set {
+		merged.Title = NormalizeLine(merged.Title)
+	}
+	if patch.Body.Set {
+		merged.Body = NormalizeText(merged.Body)
+	}
+	if patch.Category.Set {
+		merged.Category = NormalizeLine(merged.Category)
+	}
+	if patch.MetaDescription.Set {
+		merged.MetaDescription = normalizeOptionalText(merged.MetaDescription)
+	}
+
+	var errs ValidationErrors
+	_ = errs.Add(ValidateForUpdate(p, merged.Title, merged.Body, merged.Category, merged.MetaDescription))
+	if patch.BodyFormat.Set {
+		_ = errs.Add(ValidateBodyFormat(merged.BodyFormat))
+	}
+
+	if patch.Slug.Set && merged.Slug != nil {
+		normalized := NormalizeSlug(*merged.Slug)
+		if normalized == "" {
+			merged.Slug = nil
+		} else if err := ValidateSlug(normalized); err != nil {
+			_ = errs.Add(err)
+		} else {
+			merged.Slug = &normalized
+		}
+	}
+
+	if patch.Status.Set && !patch.Status.Value.Valid() {
+		_ = errs.Add(NewValidationError("status", ValidationCodeInvalidValue, "status is invalid"))
+	}
+
+	// 予約日時は予約投稿にしか持たせない
+	status := p.Status
+	if patch.Status.Set {
+		status = patch.Status.Value
+	}
+	if patch.ScheduledAt.Set && patch.ScheduledAt.Value != nil && status != StatusScheduled {
+		_ = errs.Add(NewValidationError("scheduledAt", ValidationCodeInvalidState, "scheduled time can only be set on scheduled posts"))
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	if patch.Body.Set || patch.BodyFormat.Set {
+		if err := merged.render(); err != nil {
+			return nil, err
+		}
+	}
+
+	// 承認後に内容が変わった場合は承認を取り消す
+	merged.invalidateApproval(p, editorID, now)
+
+	// ステータスは直接書き換えず、状態遷移メソッドを経由させる
+	if patch.Status.Set && patch.Status.Value != p.Status {
+		// 予約へ移すときは、以前に残っていた日時を使い回さずパッチの日時だけを使う
+		var scheduledAt *time.Time
+		if patch.ScheduledAt.Set {
+			scheduledAt = patch.ScheduledAt.Value
+		}
+		if err := merged.transitionTo(patch.Status.Value, scheduledAt, &editorID, now); err != nil {
+			return nil, err
+		}
+	} else if merged.Status == StatusScheduled && !EqualPtr(merged.ScheduledAt, p.ScheduledAt) {
+		if merged.ScheduledAt == nil {
+			return nil, NewValidationError("scheduledAt", ValidationCodeRequired, "scheduled posts require scheduled time")
+		}
+		if err := merged.Schedule(*merged.ScheduledAt, &editorID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	merged.LastEditorID = &editorID
+	if err := merged.appendUpdateEvent(p.Snapshot(), &editorID, now); err != nil {
+		return nil, err
+	}
+
+	return &merged, nil
+}
+
+// EqualPtr reports whether a and b are both nil or point to equal values.
+// Times are compared with time.Time.Equal, so the same instant read back in
+// another location is equal.
+func EqualPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if t, ok := any(*a).(time.Time); ok {
+		return t.Equal(any(*b).(time.Time))
+	}
+	return *a == *b
+}
@@ -0,0 +1,205 @@
+package post
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/id"
+)
+
+func newPatchTestPost(t *testing.T) *Post {
+	t.Helper()
+
+	featuredURL := "https://example.com/image.jpg"
+	p, err := Construct(
+		"Original Title",
+		"This is a test post body with enough content to pass the 100 character minimum requirement for validation.",
+		BodyFormatMarkdown,
+		StatusDraft,
+		nil,
+		"技術",
+		[]string{"go", "test"},
+		&featuredURL,
+		nil,
+		nil,
+		false,
+		false,
+		false,
+		testAuthorID,
+	)
+	if err != nil {
+		t.Fatalf("Construct() error = %v", err)
+	}
+	return p
+}
+
+func TestPost_Patched_LeavesAbsentFieldsUntouched(t *testing.T) {
+	p := newPatchTestPost(t)
+	editorID := UserID(id.GenerateUUID())
+
+	merged, err := p.Patched(Patch{Title: SetField("New Title")}, editorID, time.Now())
+	if err != nil {
+		t.Fatalf("Patched() error = %v", err)
+	}
+
+	if merged.Title != "New Title" {
+		t.Errorf("Title = %q, want %q", merged.Title, "New Title")
+	}
+	if merged.Category != "技術" || len(merged.Tags) != 2 || merged.FeaturedImageURL == nil {
+		t.Errorf("Patched() changed fields absent from the patch: %+v", merged)
+	}
+	if p.Title != "Original Title" {
+		t.Errorf("Patched() modified the receiver: Title = %q", p.Title)
+	}
+	if merged.LastEditorID == nil || *merged.LastEditorID != editorID {
+		t.Errorf("LastEditorID = %v, want %v", merged.LastEditorID, editorID)
+	}
+	if merged.Events[len(merged.Events)-1].Type != PostEventTypeUpdatePost {
+		t.Errorf("Expected UpdatePost event, got %v", merged.Events[len(merged.Events)-1].Type)
+	}
+}
+
+func TestPost_Patched_NullClearsOptionalFields(t *testing.T) {
+	p := newPatchTestPost(t)
+
+	merged, err := p.Patched(Patch{
+		FeaturedImageURL: SetField[*string](nil),
+		Tags:             SetField[[]string](nil),
+	}, testAuthorID, time.Now())
+	if err != nil {
+		t.Fatalf("Patched() error = %v", err)
+	}
+
+	if merged.FeaturedImageURL != nil {
+		t.Errorf("FeaturedImageURL = %v, want nil", *merged.FeaturedImageURL)
+	}
+	if merged.Tags == nil || len(merged.Tags) != 0 {
+		t.Errorf("Tags = %v, want empty slice", merged.Tags)
+	}
+}
+
+func TestPost_Patched_StatusMaintainsPublishedAt(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	scheduledAt := now.Add(2 * time.Hour)
+
+	tests := []struct {
+		name            string
+		patch           Patch
+		wantPublishedAt *time.Time
+		wantErr         bool
+	}{
+		{
+			name:            "publish sets published at to now",
+			patch:           Patch{Status: SetField(StatusPublished)},
+			wantPublishedAt: &now,
+		},
+		{
+			name:            "schedule sets published at to scheduled time",
+			patch:           Patch{Status: SetField(StatusScheduled), ScheduledAt: SetField(&scheduledAt)},
+			wantPublishedAt: &scheduledAt,
+		},
+		{
+			name:    "schedule without time is rejected",
+			patch:   Patch{Status: SetField(StatusScheduled)},
+			wantErr: true,
+		},
+		{
+			name:    "scheduled time on a draft is rejected",
+			patch:   Patch{ScheduledAt: SetField(&scheduledAt)},
+			wantErr: true,
+		},
+		{
+			name:    "scheduled time with a non-scheduled status is rejected",
+			patch:   Patch{Status: SetField(StatusPublished), ScheduledAt: SetField(&scheduledAt)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown status is rejected",
+			patch:   Patch{Status: SetField(PublicationStatus("hidden"))},
+			wantErr: true,
+		},
+		{
+			name:    "too short title is rejected",
+			patch:   Patch{Title: SetField("")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPatchTestPost(t)
+
+			merged, err := p.Patched(tt.patch, testAuthorID, now)
+			if tt.wantErr {
+				if !IsErrValidation(err) {
+					t.Errorf("Patched() error = %v, want validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Patched() error = %v", err)
+			}
+			if !EqualPtr(merged.PublishedAt, tt.wantPublishedAt) {
+				t.Errorf("PublishedAt = %v, want %v", merged.PublishedAt, tt.wantPublishedAt)
+			}
+		})
+	}
+}
+
+func TestEqualPtr(t *testing.T) {
+	utc := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	jst := utc.In(time.FixedZone("JST", 9*60*60))
+	a, b := "a", "b"
+
+	if !EqualPtr(&utc, &jst) {
+		t.Error("EqualPtr() = false for the same instant in another location")
+	}
+	if EqualPtr(&utc, nil) || !EqualPtr[time.Time](nil, nil) {
+		t.Error("EqualPtr() compares nil pointers wrongly")
+	}
+	if EqualPtr(&a, &b) {
+		t.Error("EqualPtr() = true for different strings")
+	}
+}
+
+func TestPost_Patched_ScheduleDoesNotReuseStaleTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	stale := now.Add(2 * time.Hour)
+
+	// 予約日時が残ったまま保存された下書き
+	p := newPatchTestPost(t)
+	p.ScheduledAt = &stale
+
+	if _, err := p.Patched(Patch{Status: SetField(StatusScheduled)}, testAuthorID, now); !IsErrValidation(err) {
+		t.Errorf("Patched() error = %v, want validation error", err)
+	}
+
+	// 日時を消すだけのパッチは受け付ける
+	merged, err := p.Patched(Patch{ScheduledAt: SetField[*time.Time](nil)}, testAuthorID, now)
+	if err != nil {
+		t.Fatalf("Patched() error = %v", err)
+	}
+	if merged.ScheduledAt != nil {
+		t.Errorf("ScheduledAt = %v, want nil", merged.ScheduledAt)
+	}
+}
+
+func TestPost_Patched_KeepsUnchangedShortBody(t *testing.T) {
+	p := newPatchTestPost(t)
+	// 100 文字の下限ができる前に保存された投稿
+	p.Body = "Short legacy body."
+	rev := p.NewRevision(&testAuthorID, p.CreatedAt)
+	now := time.Now()
+
+	if _, err := p.Patched(Patch{EmergencyFlag: SetField(true)}, testActorID, now); err != nil {
+		t.Errorf("Patched() of a flag error = %v, want nil", err)
+	}
+	if _, err := p.RestoreRevision(rev, testActorID, now); err != nil {
+		t.Errorf("RestoreRevision() with the same body error = %v, want nil", err)
+	}
+
+	_, err := p.Patched(Patch{Body: SetField("Another short body.")}, testActorID, now)
+	if validationErr, ok := AsErrValidation(err); !ok || validationErr.Field != "body" {
+		t.Errorf("Patched() of a short body error = %v, want a body validation error", err)
+	}
+}
@@ -2,10 +2,9 @@ package post
 
 import (
 	"encoding/json"
-	"errors"
+	"strings"
 	"time"
 
-	"github.com/ss49919201/myblog/api/internal/post/entity/event"
 	"github.com/ss49919201/myblog/api/internal/post/id"
 )
 
@@ -41,17 +40,28 @@ type PostEventType int
 const (
 	PostEventTypeCreatePost PostEventType = iota + 1
 	PostEventTypeUpdatePost
+	PostEventTypePublishPost
+	PostEventTypeSchedulePost
+	PostEventTypeUnschedulePost
+	PostEventTypeUnpublishPost
+	PostEventTypeArchivePost
+	PostEventTypeUnarchivePost
+	PostEventTypeDeletePost
+	PostEventTypeRestoreRevision
+	PostEventTypeRestorePost
+	PostEventTypePurgePost
+	PostEventTypeSubmitReview
+	PostEventTypeApproveReview
+	PostEventTypeRejectReview
+	PostEventTypeInvalidateReview
 )
 
-type PostEvent struct {
-	ID   event.ID
-	Type PostEventType
-}
-
 type Post struct {
 	ID                   PostID            `json:"id"`
 	Title                string            `json:"title"`
 	Body                 string            `json:"body"`
+	BodyFormat           BodyFormat        `json:"bodyFormat"`
+	RenderedBody         string            `json:"renderedBody"`
 	Status               PublicationStatus `json:"status"`
 	ScheduledAt          *time.Time        `json:"scheduledAt"`
 	Category             string            `json:"category"`
@@ -64,67 +74,71 @@ type Post struct {
 	EmergencyFlag        bool              `json:"emergencyFlag"`
 	CreatedAt            time.Time         `json:"createdAt"`
 	PublishedAt          *time.Time        `json:"publishedAt"`
+	AuthorID             *UserID           `json:"authorId"`
+	LastEditorID         *UserID           `json:"lastEditorId"`
+	Version              int               `json:"version"`
+	DeletedAt            *time.Time        `json:"deletedAt"`
+	DeletedBy            *UserID           `json:"deletedBy"`
+	ReviewStatus         ReviewStatus      `json:"reviewStatus"`
 
 	Events []PostEvent
 }
 
-func (p *Post) Update(title string, body string) error {
-	if err := ValidateTitle(title); err != nil {
-		return err
-	}
+// IsAuthoredBy reports whether userID wrote the post.
+// Posts created before authorship was tracked have no author.
+func (p *Post) IsAuthoredBy(userID UserID) bool {
+	return p.AuthorID != nil && *p.AuthorID == userID
+}
 
-	if err := ValidateBody(body); err != nil {
+func (p *Post) Update(title string, body string, editorID UserID) error {
+	title = NormalizeLine(title)
+	body = NormalizeText(body)
+
+	if err := ValidateForUpdate(p, title, body, p.Category, p.MetaDescription); err != nil {
 		return err
 	}
 
+	before := p.Snapshot()
 	p.Title = title
 	p.Body = body
-
-	p.Events = append(p.Events, PostEvent{
-		ID:   event.GenerateID(),
-		Type: PostEventTypeUpdatePost,
-	})
-
-	return nil
-}
-
-func ValidateTitle(title string) error {
-	validTitle := len(title) > 1 && len(title) <= 100
-	if !validTitle {
-		return errors.New("title must be between 1 and 100 characters")
+	p.LastEditorID = &editorID
+	if err := p.render(); err != nil {
+		return err
 	}
 
-	return nil
+	return p.appendUpdateEvent(before, &editorID, time.Now())
 }
 
-func ValidateBody(body string) error {
-	validBody := len(body) > 1 && len(body) <= 5000
-	if !validBody {
-		return errors.New("body must be between 1 and 5000 characters")
-	}
-
-	return nil
+// Delete moves the post to the trash. The row is kept until the trash is
+// purged; the event keeps the last state for consumers.
+func (p *Post) Delete(actorID UserID, now time.Time) {
+	p.DeletedAt = &now
+	p.DeletedBy = &actorID
+	p.appendEvent(PostEventTypeDeletePost, &actorID, now, &PostDeletedPayload{Post: p.Snapshot()})
 }
 
-func ValidateForConstruct(
-	title,
-	body string,
-) error {
-	if err := ValidateTitle(title); err != nil {
-		return err
-	}
+// IsTrashed reports whether the post was deleted and is waiting in the trash.
+func (p *Post) IsTrashed() bool {
+	return p.DeletedAt != nil
+}
 
-	if err := ValidateBody(body); err != nil {
-		return err
-	}
+// Restore takes the post out of the trash as it was when it was deleted.
+func (p *Post) Restore(actorID UserID, now time.Time) {
+	p.DeletedAt = nil
+	p.DeletedBy = nil
+	p.appendEvent(PostEventTypeRestorePost, &actorID, now, &PostRestoredPayload{Post: p.Snapshot()})
+}
 
-	return nil
+// Purge records that the system removed the trashed post for good.
+func (p *Post) Purge(now time.Time) {
+	p.appendEvent(PostEventTypePurgePost, nil, now, &PostPurgedPayload{Post: p.Snapshot(), DeletedAt: p.DeletedAt})
 }
 
 // Construct creates a new Post with all parameters explicitly specified
 func Construct(
 	title,
 	body string,
+	bodyFormat BodyFormat,
 	status PublicationStatus,
 	scheduledAt *time.Time,
 	category string,
@@ -135,16 +149,43 @@ func Construct(
 	snsAutoPost,
 	externalNotification,
 	emergencyFlag bool,
+	authorID UserID,
 ) (*Post, error) {
-	if err := ValidateForConstruct(title, body); err != nil {
+	title = NormalizeLine(title)
+	body = NormalizeText(body)
+	category = NormalizeLine(category)
+	metaDescription = normalizeOptionalText(metaDescription)
+
+	var errs ValidationErrors
+	_ = errs.Add(ValidateForConstruct(title, body, category, metaDescription))
+	_ = errs.Add(ValidateBodyFormat(bodyFormat))
+
+	if status != StatusDraft && status != StatusScheduled && status != StatusPublished {
+		_ = errs.Add(NewValidationError("status", ValidationCodeInvalidValue, "status must be draft, scheduled or published"))
+	}
+
+	postID := NewPostID()
+
+	// slug を省略した場合はタイトルから生成する
+	if slug == nil || strings.TrimSpace(*slug) == "" {
+		generated := GenerateSlug(title, postID)
+		slug = &generated
+	} else {
+		normalized := NormalizeSlug(*slug)
+		_ = errs.Add(ValidateSlug(normalized))
+		slug = &normalized
+	}
+
+	if err := errs.Err(); err != nil {
 		return nil, err
 	}
 
 	now := time.Now()
 	post := &Post{
-		ID:                   NewPostID(),
+		ID:                   postID,
 		Title:                title,
 		Body:                 body,
+		BodyFormat:           bodyFormat,
 		Status:               status,
 		ScheduledAt:          scheduledAt,
 		Category:             category,
@@ -156,8 +197,15 @@ func Construct(
 		ExternalNotification: externalNotification,
 		EmergencyFlag:        emergencyFlag,
 		CreatedAt:            now,
+		AuthorID:             &authorID,
+		LastEditorID:         &authorID,
+		Version:              1,
+		ReviewStatus:         ReviewStatusNone,
 		Events:               []PostEvent{},
 	}
+	if err := post.render(); err != nil {
+		return nil, err
+	}
 
 	// Set PublishedAt based on status
 	if status == StatusPublished {
@@ -166,18 +214,19 @@ func Construct(
 		post.PublishedAt = scheduledAt
 	}
 
-	post.Events = append(post.Events, PostEvent{
-		ID:   event.GenerateID(),
-		Type: PostEventTypeCreatePost,
-	})
+	post.appendEvent(PostEventTypeCreatePost, &authorID, now, &PostCreatedPayload{Post: post.Snapshot()})
 
 	return post, nil
 }
 
+// Reconstruct restores a stored post. renderedBody is the cached HTML of
+// body; posts stored before it was cached are rendered again.
 func Reconstruct(
 	id PostID,
 	title string,
 	body string,
+	bodyFormat BodyFormat,
+	renderedBody string,
 	status PublicationStatus,
 	scheduledAt *time.Time,
 	category string,
@@ -190,15 +239,21 @@ func Reconstruct(
 	emergencyFlag bool,
 	createdAt time.Time,
 	publishedAt *time.Time,
+	authorID *UserID,
+	lastEditorID *UserID,
+	version int,
+	reviewStatus ReviewStatus,
 ) (*Post, error) {
-	if err := ValidateForConstruct(title, body); err != nil {
+	if err := validateStored(title, body, category, metaDescription); err != nil {
 		return nil, err
 	}
 
-	return &Post{
+	p := &Post{
 		ID:                   id,
 		Title:                title,
 		Body:                 body,
+		BodyFormat:           bodyFormat,
+		RenderedBody:         renderedBody,
 		Status:               status,
 		ScheduledAt:          scheduledAt,
 		Category:             category,
@@ -211,7 +266,18 @@ func Reconstruct(
 		EmergencyFlag:        emergencyFlag,
 		CreatedAt:            createdAt,
 		PublishedAt:          publishedAt,
-	}, nil
+		AuthorID:             authorID,
+		LastEditorID:         lastEditorID,
+		Version:              version,
+		ReviewStatus:         reviewStatus,
+	}
+	if p.RenderedBody == "" {
+		if err := p.render(); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
 }
 
 func (p *Post) ToJSON() string {
@@ -3,8 +3,12 @@ package post
 import (
 	"testing"
 	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/id"
 )
 
+var testAuthorID = UserID(id.GenerateUUID())
+
 // TestConstruct_WithBasicFields_ReturnsPostWithDefaults tests basic Construct functionality
 func TestConstruct_WithBasicFields_ReturnsPostWithDefaults(t *testing.T) {
 	title := "Test Post Title"
@@ -13,6 +17,7 @@ func TestConstruct_WithBasicFields_ReturnsPostWithDefaults(t *testing.T) {
 	post, err := Construct(
 		title,
 		body,
+		BodyFormatMarkdown,
 		"draft",     // status
 		nil,         // scheduledAt
 		"",          // category
@@ -23,6 +28,7 @@ func TestConstruct_WithBasicFields_ReturnsPostWithDefaults(t *testing.T) {
 		false,       // snsAutoPost
 		false,       // externalNotification
 		false,       // emergencyFlag
+		testAuthorID,
 	)
 
 	if err != nil {
@@ -86,6 +92,7 @@ func TestConstruct_WithAllFields_ReturnsPostWithSpecifiedValues(t *testing.T) {
 	post, err := Construct(
 		title,
 		body,
+		BodyFormatMarkdown,
 		"scheduled",
 		&scheduledTime,
 		"技術",
@@ -96,6 +103,7 @@ func TestConstruct_WithAllFields_ReturnsPostWithSpecifiedValues(t *testing.T) {
 		true,  // SNSAutoPost
 		true,  // ExternalNotification
 		true,  // EmergencyFlag
+		testAuthorID,
 	)
 
 	if err != nil {
@@ -151,6 +159,7 @@ func TestConstruct_WithDifferentDefaults_SetsCorrectly(t *testing.T) {
 	post, err := Construct(
 		title,
 		body,
+		BodyFormatMarkdown,
 		"published", // status - different from draft
 		nil,         // scheduledAt
 		"general",   // category - not empty
@@ -161,6 +170,7 @@ func TestConstruct_WithDifferentDefaults_SetsCorrectly(t *testing.T) {
 		true,        // snsAutoPost - true
 		false,       // externalNotification
 		false,       // emergencyFlag
+		testAuthorID,
 	)
 
 	if err != nil {
@@ -193,6 +203,7 @@ func TestConstruct_PublishedStatus_SetsPublishedAtToNow(t *testing.T) {
 	post, err := Construct(
 		title,
 		body,
+		BodyFormatMarkdown,
 		"published", // status
 		nil,         // scheduledAt
 		"",          // category
@@ -203,6 +214,7 @@ func TestConstruct_PublishedStatus_SetsPublishedAtToNow(t *testing.T) {
 		false,       // snsAutoPost
 		false,       // externalNotification
 		false,       // emergencyFlag
+		testAuthorID,
 	)
 	after := time.Now()
 
@@ -232,6 +244,7 @@ func TestConstruct_InvalidTitle_ReturnsValidationError(t *testing.T) {
 	_, err := Construct(
 		invalidTitle,
 		body,
+		BodyFormatMarkdown,
 		"draft",     // status
 		nil,         // scheduledAt
 		"",          // category
@@ -242,6 +255,7 @@ func TestConstruct_InvalidTitle_ReturnsValidationError(t *testing.T) {
 		false,       // snsAutoPost
 		false,       // externalNotification
 		false,       // emergencyFlag
+		testAuthorID,
 	)
 
 	if err == nil {
@@ -262,6 +276,7 @@ func TestConstruct_InvalidBody_ReturnsValidationError(t *testing.T) {
 	_, err := Construct(
 		title,
 		invalidBody,
+		BodyFormatMarkdown,
 		"draft",     // status
 		nil,         // scheduledAt
 		"",          // category
@@ -272,13 +287,14 @@ func TestConstruct_InvalidBody_ReturnsValidationError(t *testing.T) {
 		false,       // snsAutoPost
 		false,       // externalNotification
 		false,       // emergencyFlag
+		testAuthorID,
 	)
 
 	if err == nil {
 		t.Fatal("Expected validation error, got nil")
 	}
 
-	expected := "body must be between 1 and 5000 characters"
+	expected := "body must be between 100 and 5000 characters"
 	if err.Error() != expected {
 		t.Errorf("Expected error %q, got %q", expected, err.Error())
 	}
@@ -292,6 +308,7 @@ func TestConstruct_WithPartialParameters_WorksAsExpected(t *testing.T) {
 	post, err := Construct(
 		title,
 		body,
+		BodyFormatMarkdown,
 		"draft",         // status
 		nil,             // scheduledAt
 		"general",       // category
@@ -302,6 +319,7 @@ func TestConstruct_WithPartialParameters_WorksAsExpected(t *testing.T) {
 		false,           // snsAutoPost
 		false,           // externalNotification
 		false,           // emergencyFlag
+		testAuthorID,
 	)
 
 	if err != nil {
@@ -319,4 +337,39 @@ func TestConstruct_WithPartialParameters_WorksAsExpected(t *testing.T) {
 	if post.Category != "general" {
 		t.Errorf("Expected category 'general', got %q", post.Category)
 	}
-}
\ No newline at end of file
+}
+
+// TestConstruct_SetsAuthorAndLastEditor tests authorship tracking
+func TestConstruct_SetsAuthorAndLastEditor(t *testing.T) {
+	body := "This is a test post body with enough content to pass the 100 character minimum requirement for validation."
+
+	post, err := Construct("Authored Post", body, BodyFormatMarkdown, "draft", nil, "", []string{}, nil, nil, nil, false, false, false, testAuthorID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !post.IsAuthoredBy(testAuthorID) {
+		t.Errorf("Expected post to be authored by %v, got %v", testAuthorID, post.AuthorID)
+	}
+
+	if post.LastEditorID == nil || *post.LastEditorID != testAuthorID {
+		t.Errorf("Expected last editor %v, got %v", testAuthorID, post.LastEditorID)
+	}
+
+	editorID := UserID(id.GenerateUUID())
+	if err := post.Update("Edited Title", body, editorID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !post.IsAuthoredBy(testAuthorID) {
+		t.Error("Expected Update to keep the original author")
+	}
+
+	if post.LastEditorID == nil || *post.LastEditorID != editorID {
+		t.Errorf("Expected last editor %v, got %v", editorID, post.LastEditorID)
+	}
+
+	if post.IsAuthoredBy(editorID) {
+		t.Error("Expected IsAuthoredBy to be false for a different user")
+	}
+}
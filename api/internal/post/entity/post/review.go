@@ -0,0 +1,167 @@
+package post
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// ReviewStatus is where a post stands in the approval workflow.
+//
+//	none ─────Submit──▶ pending ──Approve──▶ approved
+//	rejected ─Submit──▶    └──────Reject───▶ rejected
+//	approved ──title or body changed──▶ none
+//
+// A pending or rejected post cannot be scheduled or published.
+type ReviewStatus string
+
+const (
+	ReviewStatusNone     ReviewStatus = "none"
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+func (s ReviewStatus) String() string {
+	return string(s)
+}
+
+func (s ReviewStatus) Valid() bool {
+	switch s {
+	case ReviewStatusNone, ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+		return true
+	}
+	return false
+}
+
+// ReviewReason names an editorial rule that requires a post to be approved
+// before it is scheduled or published.
+type ReviewReason string
+
+const (
+	// ReviewReasonExternalLinks is reported for bodies with many external links.
+	ReviewReasonExternalLinks ReviewReason = "external_links"
+)
+
+// ReviewAction is a step of the approval workflow.
+type ReviewAction string
+
+const (
+	ReviewActionSubmit  ReviewAction = "submit"
+	ReviewActionApprove ReviewAction = "approve"
+	ReviewActionReject  ReviewAction = "reject"
+)
+
+func (a ReviewAction) Valid() bool {
+	switch a {
+	case ReviewActionSubmit, ReviewActionApprove, ReviewActionReject:
+		return true
+	}
+	return false
+}
+
+// Review is an immutable record of a step of the approval workflow, stored
+// each time a post is submitted, approved or rejected.
+type Review struct {
+	PostID PostID
+	Action ReviewAction
+	// ActorID is the author who submitted the post or the reviewer who
+	// decided on it.
+	ActorID   *UserID
+	Comment   *string
+	Reasons   []ReviewReason
+	CreatedAt time.Time
+}
+
+// SubmitForReview asks reviewers to approve the draft. reasons are the rules
+// that require the approval, if any; a post may also be submitted voluntarily.
+func (p *Post) SubmitForReview(actorID UserID, reasons []ReviewReason, comment *string, now time.Time) (*Review, error) {
+	if p.Status != StatusDraft {
+		return nil, NewValidationError("status", ValidationCodeInvalidState, "only drafts can be submitted for review")
+	}
+	if p.ReviewStatus == ReviewStatusPending || p.ReviewStatus == ReviewStatusApproved {
+		return nil, &ErrInvalidReviewTransition{From: p.ReviewStatus, To: ReviewStatusPending}
+	}
+
+	return p.recordReview(ReviewActionSubmit, ReviewStatusPending, actorID, reasons, comment, now), nil
+}
+
+// Approve lets the post proceed to be scheduled or published.
+func (p *Post) Approve(reviewerID UserID, comment *string, now time.Time) (*Review, error) {
+	if p.ReviewStatus != ReviewStatusPending {
+		return nil, &ErrInvalidReviewTransition{From: p.ReviewStatus, To: ReviewStatusApproved}
+	}
+
+	return p.recordReview(ReviewActionApprove, ReviewStatusApproved, reviewerID, nil, comment, now), nil
+}
+
+// Reject sends the post back to its author. The comment explaining why is
+// required.
+func (p *Post) Reject(reviewerID UserID, comment *string, now time.Time) (*Review, error) {
+	if p.ReviewStatus != ReviewStatusPending {
+		return nil, &ErrInvalidReviewTransition{From: p.ReviewStatus, To: ReviewStatusRejected}
+	}
+	if comment == nil || strings.TrimSpace(*comment) == "" {
+		return nil, NewValidationError("comment", ValidationCodeRequired, "a rejection requires a comment")
+	}
+
+	return p.recordReview(ReviewActionReject, ReviewStatusRejected, reviewerID, nil, comment, now), nil
+}
+
+// checkReviewed reports *ErrReviewRequired while the post waits for a review
+// decision or has been rejected.
+func (p *Post) checkReviewed() error {
+	if p.ReviewStatus == ReviewStatusPending || p.ReviewStatus == ReviewStatusRejected {
+		return &ErrReviewRequired{PostID: p.ID, ReviewStatus: p.ReviewStatus}
+	}
+	return nil
+}
+
+// invalidateApproval drops an approval given to content that has since
+// changed. It is called by Patched with the post before the patch.
+func (p *Post) invalidateApproval(before *Post, actorID UserID, now time.Time) {
+	if p.ReviewStatus != ReviewStatusApproved {
+		return
+	}
+	if p.Title == before.Title && p.Body == before.Body && p.BodyFormat == before.BodyFormat {
+		return
+	}
+
+	p.ReviewStatus = ReviewStatusNone
+	p.appendEvent(PostEventTypeInvalidateReview, &actorID, now, &PostReviewChangedPayload{
+		From: ReviewStatusApproved,
+		To:   ReviewStatusNone,
+	})
+}
+
+func (p *Post) recordReview(action ReviewAction, to ReviewStatus, actorID UserID, reasons []ReviewReason, comment *string, now time.Time) *Review {
+	from := p.ReviewStatus
+	p.ReviewStatus = to
+
+	reasons = slices.Clone(reasons)
+	if reasons == nil {
+		reasons = []ReviewReason{}
+	}
+
+	p.appendEvent(reviewEventTypes[action], &actorID, now, &PostReviewChangedPayload{
+		From:    from,
+		To:      to,
+		Comment: comment,
+		Reasons: reasons,
+	})
+
+	return &Review{
+		PostID:    p.ID,
+		Action:    action,
+		ActorID:   &actorID,
+		Comment:   comment,
+		Reasons:   reasons,
+		CreatedAt: now,
+	}
+}
+
+var reviewEventTypes = map[ReviewAction]PostEventType{
+	ReviewActionSubmit:  PostEventTypeSubmitReview,
+	ReviewActionApprove: PostEventTypeApproveReview,
+	ReviewActionReject:  PostEventTypeRejectReview,
+}
@@ -0,0 +1,110 @@
+package post
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/id"
+)
+
+func TestPost_ReviewWorkflow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	reviewerID := UserID(id.GenerateUUID())
+	comment := "リンク先を確認してください"
+
+	p := newPatchTestPost(t)
+	p.Events = nil
+	review, err := p.SubmitForReview(testActorID, []ReviewReason{ReviewReasonExternalLinks}, nil, now)
+	if err != nil {
+		t.Fatalf("SubmitForReview() error = %v", err)
+	}
+	if p.ReviewStatus != ReviewStatusPending {
+		t.Errorf("ReviewStatus = %q, want %q", p.ReviewStatus, ReviewStatusPending)
+	}
+	if review.Action != ReviewActionSubmit || len(review.Reasons) != 1 {
+		t.Errorf("review = %+v, want a submit with one reason", review)
+	}
+
+	if err := p.Publish(&testActorID, now); err == nil {
+		t.Fatal("Publish() error = nil, want ErrReviewRequired")
+	} else if _, ok := AsErrReviewRequired(err); !ok {
+		t.Fatalf("Publish() error = %v, want ErrReviewRequired", err)
+	}
+
+	if _, err := p.Reject(reviewerID, nil, now); err == nil {
+		t.Error("Reject() without comment error = nil, want validation error")
+	}
+	if _, err := p.Reject(reviewerID, &comment, now); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if err := p.Schedule(now.Add(time.Hour), &testActorID, now); err == nil {
+		t.Error("Schedule() of rejected post error = nil, want ErrReviewRequired")
+	}
+
+	if _, err := p.SubmitForReview(testActorID, nil, nil, now); err != nil {
+		t.Fatalf("SubmitForReview() after rejection error = %v", err)
+	}
+	if _, err := p.Approve(reviewerID, nil, now); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if _, err := p.Approve(reviewerID, nil, now); err == nil {
+		t.Error("Approve() twice error = nil, want ErrInvalidReviewTransition")
+	} else if _, ok := AsErrInvalidReviewTransition(err); !ok {
+		t.Errorf("Approve() twice error = %v, want ErrInvalidReviewTransition", err)
+	}
+
+	if err := p.Publish(&testActorID, now); err != nil {
+		t.Fatalf("Publish() of approved post error = %v", err)
+	}
+
+	wantEvents := []PostEventType{
+		PostEventTypeSubmitReview,
+		PostEventTypeRejectReview,
+		PostEventTypeSubmitReview,
+		PostEventTypeApproveReview,
+		PostEventTypePublishPost,
+	}
+	if len(p.Events) != len(wantEvents) {
+		t.Fatalf("len(Events) = %d, want %d", len(p.Events), len(wantEvents))
+	}
+	for i, want := range wantEvents {
+		if p.Events[i].Type != want {
+			t.Errorf("Events[%d].Type = %v, want %v", i, p.Events[i].Type, want)
+		}
+	}
+}
+
+func TestPost_Patched_InvalidatesApproval(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	reviewerID := UserID(id.GenerateUUID())
+
+	tests := []struct {
+		name       string
+		patch      Patch
+		wantStatus ReviewStatus
+	}{
+		{name: "title changed", patch: Patch{Title: SetField("New Title")}, wantStatus: ReviewStatusNone},
+		{name: "tags changed", patch: Patch{Tags: SetField([]string{"go"})}, wantStatus: ReviewStatusApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPatchTestPost(t)
+			if _, err := p.SubmitForReview(testActorID, nil, nil, now); err != nil {
+				t.Fatalf("SubmitForReview() error = %v", err)
+			}
+			if _, err := p.Approve(reviewerID, nil, now); err != nil {
+				t.Fatalf("Approve() error = %v", err)
+			}
+			p.Events = nil
+
+			merged, err := p.Patched(tt.patch, testActorID, now)
+			if err != nil {
+				t.Fatalf("Patched() error = %v", err)
+			}
+			if merged.ReviewStatus != tt.wantStatus {
+				t.Errorf("ReviewStatus = %q, want %q", merged.ReviewStatus, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package post
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/textdiff"
+)
+
+// Revision is an immutable copy of the content of a post, stored each time
+// the post is created, updated or restored. Revisions of a post are numbered
+// from 1 in the order they were stored.
+type Revision struct {
+	PostID           PostID
+	Number           int
+	Title            string
+	Body             string
+	BodyFormat       BodyFormat
+	Category         string
+	Tags             []string
+	FeaturedImageURL *string
+	MetaDescription  *string
+	Slug             *string
+	EditorID         *UserID
+	CreatedAt        time.Time
+	// RestoredFrom is the number of the revision this one restored, or nil
+	// for a regular edit.
+	RestoredFrom *int
+}
+
+// NewRevision copies the current content of p. Number is assigned by the
+// repository when the revision is stored.
+func (p *Post) NewRevision(editorID *UserID, createdAt time.Time) *Revision {
+	return &Revision{
+		PostID:           p.ID,
+		Title:            p.Title,
+		Body:             p.Body,
+		BodyFormat:       p.BodyFormat,
+		Category:         p.Category,
+		Tags:             slices.Clone(p.Tags),
+		FeaturedImageURL: p.FeaturedImageURL,
+		MetaDescription:  p.MetaDescription,
+		Slug:             p.Slug,
+		EditorID:         editorID,
+		CreatedAt:        createdAt,
+	}
+}
+
+// RestoreRevision returns a copy of p whose content is replaced by rev. The
+// restore is an ordinary update, so the copy carries a post.updated event for
+// the changed fields followed by a post.revision_restored event. The status
+// and flags of p are kept.
+func (p *Post) RestoreRevision(rev *Revision, editorID UserID, now time.Time) (*Post, error) {
+	if rev.PostID != p.ID {
+		return nil, &ErrRevisionNotFound{PostID: p.ID, Number: rev.Number}
+	}
+
+	restored, err := p.Patched(Patch{
+		Title:            SetField(rev.Title),
+		Body:             SetField(rev.Body),
+		BodyFormat:       SetField(rev.BodyFormat),
+		Category:         SetField(rev.Category),
+		Tags:             SetField(slices.Clone(rev.Tags)),
+		FeaturedImageURL: SetField(rev.FeaturedImageURL),
+		MetaDescription:  SetField(rev.MetaDescription),
+		Slug:             SetField(rev.Slug),
+	}, editorID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	restored.appendEvent(PostEventTypeRestoreRevision, &editorID, now, &PostRevisionRestoredPayload{Revision: rev.Number})
+	return restored, nil
+}
+
+// RevisionDiff is the difference between two revisions of a post. Title and
+// Body are text diffs; the other content fields are listed in Changes when
+// they differ.
+type RevisionDiff struct {
+	From    int
+	To      int
+	Mode    textdiff.Mode
+	Title   []textdiff.Edit
+	Body    []textdiff.Edit
+	Changes []FieldChange
+}
+
+// DiffRevisions compares from with to, which may be older or newer.
+func DiffRevisions(from, to *Revision, mode textdiff.Mode) (*RevisionDiff, error) {
+	if !mode.Valid() {
+		return nil, NewValidationError("mode", ValidationCodeInvalidValue, "mode must be line or word")
+	}
+
+	changes := []FieldChange{}
+	fields := []struct {
+		name     string
+		from, to any
+	}{
+		{"bodyFormat", from.BodyFormat, to.BodyFormat},
+		{"category", from.Category, to.Category},
+		{"tags", nonNilTags(from.Tags), nonNilTags(to.Tags)},
+		{"featuredImageURL", from.FeaturedImageURL, to.FeaturedImageURL},
+		{"metaDescription", from.MetaDescription, to.MetaDescription},
+		{"slug", from.Slug, to.Slug},
+	}
+	for _, f := range fields {
+		before, err := json.Marshal(f.from)
+		if err != nil {
+			return nil, err
+		}
+		after, err := json.Marshal(f.to)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(before, after) {
+			changes = append(changes, FieldChange{Field: f.name, Before: before, After: after})
+		}
+	}
+
+	return &RevisionDiff{
+		From:    from.Number,
+		To:      to.Number,
+		Mode:    mode,
+		Title:   textdiff.Diff(from.Title, to.Title, mode),
+		Body:    textdiff.Diff(from.Body, to.Body, mode),
+		Changes: changes,
+	}, nil
+}
+
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -0,0 +1,123 @@
+package post
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/textdiff"
+)
+
+func TestPost_NewRevision_CopiesContent(t *testing.T) {
+	p := newPatchTestPost(t)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	rev := p.NewRevision(&testAuthorID, now)
+	p.Tags[0] = "changed"
+
+	if rev.PostID != p.ID || rev.Title != p.Title || rev.Body != p.Body || rev.Category != p.Category {
+		t.Errorf("revision = %+v, want the content of the post", rev)
+	}
+	if rev.Tags[0] != "go" {
+		t.Errorf("Tags = %v, want a copy taken before the change", rev.Tags)
+	}
+	if rev.EditorID == nil || *rev.EditorID != testAuthorID || !rev.CreatedAt.Equal(now) {
+		t.Errorf("editor = %v at %v, want %v at %v", rev.EditorID, rev.CreatedAt, testAuthorID, now)
+	}
+}
+
+func TestPost_RestoreRevision(t *testing.T) {
+	p := newPatchTestPost(t)
+	original := p.NewRevision(&testAuthorID, p.CreatedAt)
+	original.Number = 1
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	edited, err := p.Patched(Patch{Title: SetField("Edited Title"), Tags: SetField([]string{"go"})}, testActorID, now)
+	if err != nil {
+		t.Fatalf("Patched() error = %v", err)
+	}
+	edited.Events = nil
+
+	restored, err := edited.RestoreRevision(original, testActorID, now)
+	if err != nil {
+		t.Fatalf("RestoreRevision() error = %v", err)
+	}
+
+	if restored.Title != "Original Title" || len(restored.Tags) != 2 || restored.Status != edited.Status {
+		t.Errorf("restored = %+v, want the content of revision 1", restored)
+	}
+	if edited.Title != "Edited Title" {
+		t.Errorf("RestoreRevision() modified the receiver")
+	}
+
+	if len(restored.Events) != 2 {
+		t.Fatalf("Events = %d, want post.updated and post.revision_restored", len(restored.Events))
+	}
+	if restored.Events[0].Type != PostEventTypeUpdatePost {
+		t.Errorf("Events[0] = %v, want post.updated", restored.Events[0].Type)
+	}
+	e := restored.Events[1]
+	payload, ok := e.Payload.(*PostRevisionRestoredPayload)
+	if e.Type != PostEventTypeRestoreRevision || !ok || payload.Revision != 1 {
+		t.Errorf("Events[1] = %+v, want post.revision_restored of revision 1", e)
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded PostEvent
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalJSON() error = %v", err)
+	}
+	if decoded.Payload.(*PostRevisionRestoredPayload).Revision != 1 {
+		t.Errorf("decoded payload = %+v", decoded.Payload)
+	}
+}
+
+func TestPost_RestoreRevision_RejectsOtherPost(t *testing.T) {
+	p := newPatchTestPost(t)
+	other := newPatchTestPost(t).NewRevision(nil, time.Now())
+
+	if _, err := p.RestoreRevision(other, testActorID, time.Now()); err == nil {
+		t.Fatal("RestoreRevision() error = nil, want not found")
+	} else if _, ok := AsErrRevisionNotFound(err); !ok {
+		t.Errorf("error = %v, want *ErrRevisionNotFound", err)
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	slug := "hello"
+	from := &Revision{Number: 1, Title: "記事のタイトル", Body: "一行目\n二行目\n", Category: "技術", Tags: nil, Slug: &slug}
+	to := &Revision{Number: 3, Title: "記事の新しいタイトル", Body: "一行目\n2行目\n", Category: "技術", Tags: []string{"go"}}
+
+	diff, err := DiffRevisions(from, to, textdiff.ModeWord)
+	if err != nil {
+		t.Fatalf("DiffRevisions() error = %v", err)
+	}
+
+	if diff.From != 1 || diff.To != 3 {
+		t.Errorf("From, To = %d, %d, want 1, 3", diff.From, diff.To)
+	}
+	wantTitle := []textdiff.Edit{{Op: textdiff.OpEqual, Text: "記事の"}, {Op: textdiff.OpInsert, Text: "新しい"}, {Op: textdiff.OpEqual, Text: "タイトル"}}
+	if len(diff.Title) != len(wantTitle) {
+		t.Fatalf("Title = %v, want %v", diff.Title, wantTitle)
+	}
+	for i := range wantTitle {
+		if diff.Title[i] != wantTitle[i] {
+			t.Errorf("Title[%d] = %v, want %v", i, diff.Title[i], wantTitle[i])
+		}
+	}
+
+	var fields []string
+	for _, change := range diff.Changes {
+		fields = append(fields, change.Field)
+	}
+	if len(fields) != 2 || fields[0] != "tags" || fields[1] != "slug" {
+		t.Errorf("changed fields = %v, want [tags slug]", fields)
+	}
+
+	if _, err := DiffRevisions(from, to, "char"); !IsErrValidation(err) {
+		t.Errorf("DiffRevisions(char) error = %v, want validation error", err)
+	}
+}
@@ -0,0 +1,208 @@
+package post
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MaxSlugLength matches the slug column of the posts table.
+	MaxSlugLength = 200
+
+	// generatedSlugLength keeps slugs generated from long titles readable.
+	generatedSlugLength = 80
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// NormalizeSlug folds s into the canonical slug form: full-width characters
+// are narrowed, letters are lower-cased, and spaces and underscores become
+// single hyphens. It does not transliterate, so the result may still be
+// rejected by ValidateSlug.
+func NormalizeSlug(s string) string {
+	s = strings.ToLower(norm.NFKC.String(strings.TrimSpace(s)))
+
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsSpace(r) || r == '_' || r == '-' {
+			r = '-'
+		}
+		if r == '-' && (b.Len() == 0 || strings.HasSuffix(b.String(), "-")) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// ValidateSlug checks a normalized slug. Slugs are lowercase ASCII words
+// joined by hyphens. A slug shaped like a UUID is rejected because the
+// public API looks posts without a slug up by their id.
+func ValidateSlug(slug string) error {
+	if slug == "" {
+		return NewValidationError("slug", ValidationCodeRequired, "slug must not be empty")
+	}
+	if len(slug) > MaxSlugLength {
+		return NewValidationError("slug", ValidationCodeLength, "slug must be at most "+strconv.Itoa(MaxSlugLength)+" characters")
+	}
+	if !slugPattern.MatchString(slug) {
+		return NewValidationError("slug", ValidationCodeInvalidFormat, "slug may only contain lowercase letters, digits and single hyphens")
+	}
+	if _, err := ParsePostID(slug); err == nil {
+		return NewValidationError("slug", ValidationCodeReserved, "slug must not be a UUID")
+	}
+
+	return nil
+}
+
+// GenerateSlug derives a slug from title. Kana are romanized with Hepburn
+// spelling; other scripts such as kanji cannot be read without a dictionary
+// and only separate words. When nothing usable remains, the slug falls back
+// to "post-" followed by the start of id.
+func GenerateSlug(title string, id PostID) string {
+	slug := truncateSlug(NormalizeSlug(romanize(title)), generatedSlugLength)
+	if ValidateSlug(slug) != nil {
+		return "post-" + strings.ReplaceAll(id.String(), "-", "")[:8]
+	}
+
+	return slug
+}
+
+// ReplaceGeneratedSlug swaps the slug generated by Construct for slug when
+// the generated one turned out to be taken. It must be called before the
+// post is saved, and keeps the pending creation event in step.
+func (p *Post) ReplaceGeneratedSlug(slug string) {
+	p.Slug = &slug
+	for _, e := range p.Events {
+		if payload, ok := e.Payload.(*PostCreatedPayload); ok {
+			payload.Post.Slug = p.Slug
+		}
+	}
+}
+
+// SlugWithSuffix returns slug followed by "-n", shortened so that the result
+// still fits MaxSlugLength. It is used to suggest free alternatives.
+func SlugWithSuffix(slug string, n int) string {
+	suffix := "-" + strconv.Itoa(n)
+	return truncateSlug(slug, MaxSlugLength-len(suffix)) + suffix
+}
+
+// truncateSlug cuts slug to at most max bytes, preferring a hyphen boundary.
+func truncateSlug(slug string, max int) string {
+	if len(slug) <= max {
+		return slug
+	}
+
+	cut := slug[:max]
+	if i := strings.LastIndexByte(cut, '-'); i > 0 {
+		cut = cut[:i]
+	}
+
+	return strings.TrimSuffix(cut, "-")
+}
+
+// romanize replaces kana in s with their Hepburn romanization and every
+// other character that cannot appear in a slug with a space.
+func romanize(s string) string {
+	s = strings.ToLower(norm.NFKC.String(s))
+
+	var b strings.Builder
+	doubleNext, inKana := false, false
+	for _, r := range s {
+		// カタカナはひらがなに寄せて同じ表で変換する
+		if r >= 'ァ' && r <= 'ヶ' {
+			r -= 'ァ' - 'ぁ'
+		}
+
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			// 英数字とかなの境目は単語の区切りとして扱う
+			if inKana {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			doubleNext, inKana = false, false
+			continue
+		case r == 'っ':
+			doubleNext = true
+			continue
+		case r == 'ー':
+			continue
+		}
+
+		if small, ok := smallKana[r]; ok {
+			out := b.String()
+			switch {
+			case strings.HasSuffix(out, "shi"), strings.HasSuffix(out, "chi"), strings.HasSuffix(out, "ji"):
+				// しゃ → sha, ちゅ → chu, じょ → jo
+				b.Reset()
+				b.WriteString(out[:len(out)-1] + small[len(small)-1:])
+			case len(small) == 2 && strings.HasSuffix(out, "i") && len(out) > 1 && !isVowel(out[len(out)-2]):
+				// きゃ → kya
+				b.Reset()
+				b.WriteString(out[:len(out)-1] + small)
+			case len(small) == 1 && len(out) > 1 && isVowel(out[len(out)-1]) && !isVowel(out[len(out)-2]):
+				// ファ → fa, ティ → ti
+				b.Reset()
+				b.WriteString(out[:len(out)-1] + small)
+			default:
+				b.WriteString(small[len(small)-1:])
+			}
+			continue
+		}
+
+		roman, ok := kana[r]
+		if !ok {
+			b.WriteByte(' ')
+			doubleNext, inKana = false, false
+			continue
+		}
+		if !inKana {
+			b.WriteByte(' ')
+		}
+		if doubleNext && !isVowel(roman[0]) && roman != "n" {
+			if strings.HasPrefix(roman, "ch") {
+				b.WriteByte('t')
+			} else {
+				b.WriteByte(roman[0])
+			}
+		}
+		doubleNext, inKana = false, true
+		b.WriteString(roman)
+	}
+
+	return b.String()
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aiueo", c) >= 0
+}
+
+var smallKana = map[rune]string{
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo",
+}
+
+var kana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n",
+	'ゔ': "vu", 'ゎ': "wa", 'ゕ': "ka", 'ゖ': "ke",
+}
@@ -0,0 +1,163 @@
+package post
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeSlug(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "hello-world", want: "hello-world"},
+		{in: "  Hello World  ", want: "hello-world"},
+		{in: "go_1_24__release", want: "go-1-24-release"},
+		{in: "--a--b--", want: "a-b"},
+		{in: "ＧＯ　ｌａｎｇ", want: "go-lang"},
+		{in: "日本語", want: "日本語"},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeSlug(tt.in); got != tt.want {
+			t.Errorf("NormalizeSlug(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestValidateSlug(t *testing.T) {
+	valid := []string{"a", "hello-world", "go-1-24", strings.Repeat("a", MaxSlugLength)}
+	for _, slug := range valid {
+		if err := ValidateSlug(slug); err != nil {
+			t.Errorf("ValidateSlug(%q) = %v, want nil", slug, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"Hello",
+		"hello--world",
+		"-hello",
+		"hello_world",
+		"日本語",
+		strings.Repeat("a", MaxSlugLength+1),
+		"0f000000-0000-4000-8000-000000000001",
+	}
+	for _, slug := range invalid {
+		err := ValidateSlug(slug)
+		if validationErr, ok := AsErrValidation(err); !ok || validationErr.Field != "slug" {
+			t.Errorf("ValidateSlug(%q) = %v, want slug validation error", slug, err)
+		}
+	}
+}
+
+func TestGenerateSlug(t *testing.T) {
+	id, _ := ParsePostID("0f1e2d3c-0000-4000-8000-000000000001")
+
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Hello, World!", want: "hello-world"},
+		{title: "Ｇｏ　１．２４ リリース", want: "go-1-24-ririsu"},
+		{title: "Goのテスト入門", want: "go-notesuto"},
+		{title: "しんぶん", want: "shinbun"},
+		{title: "まっちゃ", want: "matcha"},
+		{title: "キャッシュ戦略", want: "kyasshu"},
+		{title: "ちょっと", want: "chotto"},
+		{title: "ファイル", want: "fairu"},
+		{title: "パーティー", want: "pati"},
+		{title: "じょうほう", want: "jouhou"},
+		{title: "東京", want: "post-0f1e2d3c"},
+		{title: "!!!", want: "post-0f1e2d3c"},
+	}
+
+	for _, tt := range tests {
+		if got := GenerateSlug(tt.title, id); got != tt.want {
+			t.Errorf("GenerateSlug(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateSlug_TruncatesAtWordBoundary(t *testing.T) {
+	title := strings.Repeat("word ", 30)
+
+	got := GenerateSlug(title, NewPostID())
+	if len(got) > generatedSlugLength || strings.HasSuffix(got, "-") || strings.HasSuffix(got, "wor") {
+		t.Errorf("GenerateSlug() = %q, want whole words within %d bytes", got, generatedSlugLength)
+	}
+	if err := ValidateSlug(got); err != nil {
+		t.Errorf("ValidateSlug(%q) = %v", got, err)
+	}
+}
+
+func TestSlugWithSuffix(t *testing.T) {
+	if got := SlugWithSuffix("hello", 2); got != "hello-2" {
+		t.Errorf("SlugWithSuffix() = %q, want hello-2", got)
+	}
+
+	long := strings.Repeat("a", MaxSlugLength)
+	got := SlugWithSuffix(long, 10)
+	if len(got) != MaxSlugLength || !strings.HasSuffix(got, "-10") {
+		t.Errorf("SlugWithSuffix() = %q, want %d bytes ending in -10", got, MaxSlugLength)
+	}
+}
+
+func TestConstruct_Slug(t *testing.T) {
+	authorID, _ := ParseUserID("0f000000-0000-4000-8000-000000000002")
+	construct := func(slug *string) (*Post, error) {
+		return Construct("Hello World", strings.Repeat("body ", 25), BodyFormatMarkdown, StatusDraft, nil, "", nil, nil, nil, slug, false, false, false, authorID)
+	}
+	str := func(s string) *string { return &s }
+
+	p, err := construct(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Slug == nil || *p.Slug != "hello-world" {
+		t.Errorf("generated slug = %v, want hello-world", p.Slug)
+	}
+
+	p, err = construct(str(" My_Slug "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *p.Slug != "my-slug" {
+		t.Errorf("slug = %q, want my-slug", *p.Slug)
+	}
+
+	if _, err := construct(str("スラッグ")); !IsErrValidation(err) {
+		t.Errorf("err = %v, want validation error", err)
+	}
+}
+
+func TestReplaceGeneratedSlug(t *testing.T) {
+	authorID, _ := ParseUserID("0f000000-0000-4000-8000-000000000002")
+	p, err := Construct("Hello World", strings.Repeat("body ", 25), BodyFormatMarkdown, StatusDraft, nil, "", nil, nil, nil, nil, false, false, false, authorID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.ReplaceGeneratedSlug("hello-world-2")
+
+	payload, ok := p.Events[0].Payload.(*PostCreatedPayload)
+	if !ok {
+		t.Fatalf("payload = %T, want *PostCreatedPayload", p.Events[0].Payload)
+	}
+	if *p.Slug != "hello-world-2" || payload.Post.Slug == nil || *payload.Post.Slug != "hello-world-2" {
+		t.Errorf("slug = %v, event slug = %v, want hello-world-2", p.Slug, payload.Post.Slug)
+	}
+}
+
+func TestAsErrSlugConflict(t *testing.T) {
+	err := error(&ErrSlugConflict{Slug: "hello", Suggestions: []string{"hello-2"}})
+
+	conflict, ok := AsErrSlugConflict(errors.Join(errors.New("save"), err))
+	if !ok || conflict.Slug != "hello" {
+		t.Errorf("AsErrSlugConflict() = %v, %v", conflict, ok)
+	}
+	if _, ok := AsErrSlugConflict(errors.New("other")); ok {
+		t.Error("AsErrSlugConflict(other) = true")
+	}
+}
@@ -6,8 +6,17 @@ const (
 	StatusDraft     PublicationStatus = "draft"
 	StatusScheduled PublicationStatus = "scheduled"
 	StatusPublished PublicationStatus = "published"
+	StatusArchived  PublicationStatus = "archived"
 )
 
 func (s PublicationStatus) String() string {
 	return string(s)
-}
\ No newline at end of file
+}
+
+func (s PublicationStatus) Valid() bool {
+	switch s {
+	case StatusDraft, StatusScheduled, StatusPublished, StatusArchived:
+		return true
+	}
+	return false
+}
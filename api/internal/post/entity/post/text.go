@@ -0,0 +1,161 @@
+package post
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// 文字数の上限・下限。文字数は NFC 正規化後の Unicode 文字（rune）で数える。
+// DB のカラム長（utf8mb4 の VARCHAR は文字数）と OpenAPI の minLength/maxLength
+// も同じ値にそろえる。
+const (
+	MinTitleLength           = 1
+	MaxTitleLength           = 100
+	MinBodyLength            = 100
+	MaxBodyLength            = 5000
+	MaxCategoryLength        = 50
+	MaxMetaDescriptionLength = 300
+)
+
+// NormalizeLine prepares single-line input such as a title: it applies NFC,
+// turns line breaks and tabs into spaces, drops other control characters and
+// trims surrounding white space.
+func NormalizeLine(s string) string {
+	s = norm.NFC.String(s)
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// NormalizeText prepares multi-line input such as a body: it applies NFC,
+// converts CRLF to LF, drops control characters other than LF and tab and
+// trims surrounding white space.
+func NormalizeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = norm.NFC.String(s)
+	s = strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// TextLength counts the characters of s as users see them once s is
+// normalized, i.e. Unicode code points after NFC rather than bytes.
+func TextLength(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+func validateLength(field, s string, min, max int) error {
+	n := TextLength(s)
+	if n >= min && n <= max {
+		return nil
+	}
+
+	if min == 0 {
+		return NewValidationError(field, ValidationCodeLength, fmt.Sprintf("%s must be at most %d characters", field, max))
+	}
+	return NewValidationError(field, ValidationCodeLength, fmt.Sprintf("%s must be between %d and %d characters", field, min, max))
+}
+
+func ValidateTitle(title string) error {
+	return validateLength("title", title, MinTitleLength, MaxTitleLength)
+}
+
+func ValidateBody(body string) error {
+	return validateLength("body", body, MinBodyLength, MaxBodyLength)
+}
+
+func ValidateCategory(category string) error {
+	return validateLength("category", category, 0, MaxCategoryLength)
+}
+
+func ValidateMetaDescription(metaDescription *string) error {
+	if metaDescription == nil {
+		return nil
+	}
+	return validateLength("metaDescription", *metaDescription, 0, MaxMetaDescriptionLength)
+}
+
+// ValidateForConstruct checks the text fields of a post against the limits
+// above and reports every invalid field as ValidationErrors.
+func ValidateForConstruct(
+	title,
+	body,
+	category string,
+	metaDescription *string,
+) error {
+	var errs ValidationErrors
+	_ = errs.Add(ValidateTitle(title))
+	_ = errs.Add(ValidateBody(body))
+	_ = errs.Add(ValidateCategory(category))
+	_ = errs.Add(ValidateMetaDescription(metaDescription))
+
+	return errs.Err()
+}
+
+// ValidateForUpdate checks the text fields that differ from previous the
+// same way as ValidateForConstruct. Unchanged fields are not checked again,
+// so that posts saved under older limits can still be edited.
+func ValidateForUpdate(
+	previous *Post,
+	title,
+	body,
+	category string,
+	metaDescription *string,
+) error {
+	var errs ValidationErrors
+	if title != previous.Title {
+		_ = errs.Add(ValidateTitle(title))
+	}
+	if body != previous.Body {
+		_ = errs.Add(ValidateBody(body))
+	}
+	if category != previous.Category {
+		_ = errs.Add(ValidateCategory(category))
+	}
+	if !EqualPtr(metaDescription, previous.MetaDescription) {
+		_ = errs.Add(ValidateMetaDescription(metaDescription))
+	}
+
+	return errs.Err()
+}
+
+// validateStored checks a post read from storage. Only the upper limits,
+// which match the column sizes, are enforced: posts saved while the lower
+// limits were counted in bytes must stay readable.
+func validateStored(
+	title,
+	body,
+	category string,
+	metaDescription *string,
+) error {
+	var errs ValidationErrors
+	_ = errs.Add(validateLength("title", title, 0, MaxTitleLength))
+	_ = errs.Add(validateLength("body", body, 0, MaxBodyLength))
+	_ = errs.Add(ValidateCategory(category))
+	_ = errs.Add(ValidateMetaDescription(metaDescription))
+
+	return errs.Err()
+}
+
+func normalizeOptionalText(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	normalized := NormalizeText(*s)
+	return &normalized
+}
@@ -0,0 +1,111 @@
+package post
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeLine(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"trim", "  タイトル  ", "タイトル"},
+		{"nfc", "\u30ab\u3099", "\u30ac"},
+		{"line breaks", "一行目\r\n二行目\t三", "一行目  二行目 三"},
+		{"control characters", "abc\x00\x7f\u0085def", "abcdef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeLine(tt.in); got != tt.want {
+				t.Errorf("NormalizeLine(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"trim", "\n 本文 \n", "本文"},
+		{"nfc", "\u30cf\u309a", "\u30d1"},
+		{"crlf", "一行目\r\n二行目", "一行目\n二行目"},
+		{"keep tabs", "a\tb", "a\tb"},
+		{"control characters", "a\x00b\x1bc", "abc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeText(tt.in); got != tt.want {
+				t.Errorf("NormalizeText(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTitle_CountsCharacters(t *testing.T) {
+	// 40文字の日本語タイトルは120バイトだが、文字数では上限内
+	title := strings.Repeat("日本語タイトル", 5) + strings.Repeat("あ", 5)
+	if err := ValidateTitle(title); err != nil {
+		t.Errorf("ValidateTitle() = %v, want nil for %d characters", err, TextLength(title))
+	}
+
+	if err := ValidateTitle(strings.Repeat("あ", MaxTitleLength+1)); err == nil {
+		t.Error("ValidateTitle() = nil, want error for a title over the limit")
+	}
+	if err := ValidateTitle("a"); err != nil {
+		t.Errorf("ValidateTitle() = %v, want nil for a single character", err)
+	}
+}
+
+func TestValidateBody_CountsCharacters(t *testing.T) {
+	if err := ValidateBody(strings.Repeat("あ", MinBodyLength)); err != nil {
+		t.Errorf("ValidateBody() = %v, want nil at the lower limit", err)
+	}
+	if err := ValidateBody(strings.Repeat("あ", MaxBodyLength)); err != nil {
+		t.Errorf("ValidateBody() = %v, want nil at the upper limit", err)
+	}
+
+	err := ValidateBody(strings.Repeat("あ", MinBodyLength-1))
+	validationErr, ok := AsErrValidation(err)
+	if !ok || validationErr.Code != ValidationCodeLength {
+		t.Errorf("ValidateBody() = %v, want length error", err)
+	}
+}
+
+func TestConstruct_NormalizesText(t *testing.T) {
+	authorID, _ := ParseUserID("0f000000-0000-4000-8000-000000000002")
+	meta := " 説明\x00 "
+	p, err := Construct(" \u30ab\u3099イド\n", strings.Repeat("本文", 50)+"\r\n", BodyFormatMarkdown, StatusDraft, nil, " 技術 ", nil, nil, &meta, nil, false, false, false, authorID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Title != "ガイド" {
+		t.Errorf("Title = %q, want ガイド", p.Title)
+	}
+	if strings.HasSuffix(p.Body, "\r\n") {
+		t.Errorf("Body = %q, want trailing line break trimmed", p.Body)
+	}
+	if p.Category != "技術" {
+		t.Errorf("Category = %q, want 技術", p.Category)
+	}
+	if p.MetaDescription == nil || *p.MetaDescription != "説明" {
+		t.Errorf("MetaDescription = %v, want 説明", p.MetaDescription)
+	}
+}
+
+func TestReconstruct_KeepsPostsUnderFormerLimits(t *testing.T) {
+	// バイト数で下限を数えていた頃に保存された短い本文も読み出せる
+	if _, err := Reconstruct(NewPostID(), "タイトル", "短い本文", BodyFormatMarkdown, "", StatusDraft, nil, "", nil, nil, nil, nil, false, false, false, time.Now(), nil, nil, nil, 1, ReviewStatusNone); err != nil {
+		t.Errorf("Reconstruct() = %v, want nil", err)
+	}
+
+	if _, err := Reconstruct(NewPostID(), strings.Repeat("あ", MaxTitleLength+1), "本文", BodyFormatMarkdown, "", StatusDraft, nil, "", nil, nil, nil, nil, false, false, false, time.Now(), nil, nil, nil, 1, ReviewStatusNone); err == nil {
+		t.Error("Reconstruct() = nil, want error for a title over the limit")
+	}
+}
@@ -0,0 +1,173 @@
+package post
+
+import "time"
+
+// The publication lifecycle of a post:
+//
+//	draft ──Schedule──▶ scheduled ──Publish──▶ published
+//	  │  ◀─Unschedule──     │                     │
+//	  │ ◀──────────────────────────Unpublish──────┘
+//	  └──────Publish──────────────────────────▶ published
+//
+// Any of draft, scheduled and published can be archived; Unarchive returns an
+// archived post to draft. A post waiting for review or rejected in review
+// cannot be scheduled or published (see review.go).
+//
+// actorID is the user performing the transition and becomes LastEditorID.
+// It is nil when the system acts on its own, e.g. the scheduler.
+
+// Publish makes the post public at now.
+func (p *Post) Publish(actorID *UserID, now time.Time) error {
+	if p.Status != StatusDraft && p.Status != StatusScheduled {
+		return &ErrInvalidTransition{From: p.Status, To: StatusPublished}
+	}
+	if err := p.checkReviewed(); err != nil {
+		return err
+	}
+
+	from := p.Status
+	p.Status = StatusPublished
+	p.ScheduledAt = nil
+	p.PublishedAt = &now
+	p.recordTransition(PostEventTypePublishPost, from, actorID, now)
+
+	return nil
+}
+
+// PublishScheduled publishes a scheduled post whose scheduled time has come.
+// PublishedAt keeps the scheduled time rather than the moment the scheduler
+// happened to pick the post up.
+func (p *Post) PublishScheduled(now time.Time) error {
+	if p.Status != StatusScheduled || p.ScheduledAt == nil || p.ScheduledAt.After(now) {
+		return &ErrInvalidTransition{From: p.Status, To: StatusPublished}
+	}
+
+	publishedAt := *p.ScheduledAt
+	p.Status = StatusPublished
+	p.ScheduledAt = nil
+	p.PublishedAt = &publishedAt
+	p.recordTransition(PostEventTypePublishPost, StatusScheduled, nil, now)
+
+	return nil
+}
+
+// Schedule plans the publication of the post at scheduledAt.
+// A scheduled post can be rescheduled.
+func (p *Post) Schedule(scheduledAt time.Time, actorID *UserID, now time.Time) error {
+	if p.Status != StatusDraft && p.Status != StatusScheduled {
+		return &ErrInvalidTransition{From: p.Status, To: StatusScheduled}
+	}
+	if err := p.checkReviewed(); err != nil {
+		return err
+	}
+
+	if !scheduledAt.After(now) {
+		return NewValidationError("scheduledAt", ValidationCodeNotInFuture, "scheduled time must be in the future")
+	}
+
+	from := p.Status
+	p.Status = StatusScheduled
+	p.ScheduledAt = &scheduledAt
+	// 予約投稿では公開予定日時を PublishedAt に保持する
+	p.PublishedAt = &scheduledAt
+	p.recordTransition(PostEventTypeSchedulePost, from, actorID, now)
+
+	return nil
+}
+
+// Unschedule cancels a planned publication and returns the post to draft.
+func (p *Post) Unschedule(actorID *UserID, now time.Time) error {
+	if p.Status != StatusScheduled {
+		return &ErrInvalidTransition{From: p.Status, To: StatusDraft}
+	}
+
+	p.Status = StatusDraft
+	p.ScheduledAt = nil
+	p.PublishedAt = nil
+	p.recordTransition(PostEventTypeUnschedulePost, StatusScheduled, actorID, now)
+
+	return nil
+}
+
+// Unpublish withdraws a published post back to draft.
+func (p *Post) Unpublish(actorID *UserID, now time.Time) error {
+	if p.Status != StatusPublished {
+		return &ErrInvalidTransition{From: p.Status, To: StatusDraft}
+	}
+
+	p.Status = StatusDraft
+	p.PublishedAt = nil
+	p.recordTransition(PostEventTypeUnpublishPost, StatusPublished, actorID, now)
+
+	return nil
+}
+
+// Archive retires the post. The original publication time is kept.
+func (p *Post) Archive(actorID *UserID, now time.Time) error {
+	if p.Status == StatusArchived {
+		return &ErrInvalidTransition{From: p.Status, To: StatusArchived}
+	}
+
+	from := p.Status
+	if p.Status == StatusScheduled {
+		p.PublishedAt = nil
+	}
+	p.Status = StatusArchived
+	p.ScheduledAt = nil
+	p.recordTransition(PostEventTypeArchivePost, from, actorID, now)
+
+	return nil
+}
+
+// Unarchive returns an archived post to draft.
+func (p *Post) Unarchive(actorID *UserID, now time.Time) error {
+	if p.Status != StatusArchived {
+		return &ErrInvalidTransition{From: p.Status, To: StatusDraft}
+	}
+
+	p.Status = StatusDraft
+	p.PublishedAt = nil
+	p.recordTransition(PostEventTypeUnarchivePost, StatusArchived, actorID, now)
+
+	return nil
+}
+
+// transitionTo moves the post to status using the lifecycle methods above.
+// It is used when the target status comes from a merge patch.
+func (p *Post) transitionTo(status PublicationStatus, scheduledAt *time.Time, actorID *UserID, now time.Time) error {
+	switch status {
+	case StatusPublished:
+		return p.Publish(actorID, now)
+	case StatusScheduled:
+		if scheduledAt == nil {
+			return NewValidationError("scheduledAt", ValidationCodeRequired, "scheduled posts require scheduled time")
+		}
+		return p.Schedule(*scheduledAt, actorID, now)
+	case StatusArchived:
+		return p.Archive(actorID, now)
+	case StatusDraft:
+		switch p.Status {
+		case StatusScheduled:
+			return p.Unschedule(actorID, now)
+		case StatusPublished:
+			return p.Unpublish(actorID, now)
+		case StatusArchived:
+			return p.Unarchive(actorID, now)
+		}
+	}
+
+	return &ErrInvalidTransition{From: p.Status, To: status}
+}
+
+func (p *Post) recordTransition(eventType PostEventType, from PublicationStatus, actorID *UserID, now time.Time) {
+	if actorID != nil {
+		p.LastEditorID = actorID
+	}
+
+	p.appendEvent(eventType, actorID, now, &PostStatusChangedPayload{
+		From:        from,
+		To:          p.Status,
+		ScheduledAt: p.ScheduledAt,
+		PublishedAt: p.PublishedAt,
+	})
+}
@@ -0,0 +1,197 @@
+package post
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/id"
+)
+
+var testActorID = UserID(id.GenerateUUID())
+
+func newTransitionTestPost(t *testing.T, status PublicationStatus, now time.Time) *Post {
+	t.Helper()
+
+	p := newPatchTestPost(t)
+	switch status {
+	case StatusScheduled:
+		if err := p.Schedule(now.Add(time.Hour), nil, now); err != nil {
+			t.Fatalf("Schedule() error = %v", err)
+		}
+	case StatusPublished:
+		if err := p.Publish(nil, now.Add(-time.Hour)); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	case StatusArchived:
+		if err := p.Archive(nil, now); err != nil {
+			t.Fatalf("Archive() error = %v", err)
+		}
+	}
+	p.Events = nil
+	return p
+}
+
+func TestPost_Transitions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	future := now.Add(2 * time.Hour)
+
+	publish := func(p *Post) error { return p.Publish(&testActorID, now) }
+	schedule := func(p *Post) error { return p.Schedule(future, &testActorID, now) }
+	unschedule := func(p *Post) error { return p.Unschedule(&testActorID, now) }
+	unpublish := func(p *Post) error { return p.Unpublish(&testActorID, now) }
+	archive := func(p *Post) error { return p.Archive(&testActorID, now) }
+	unarchive := func(p *Post) error { return p.Unarchive(&testActorID, now) }
+
+	tests := []struct {
+		name            string
+		from            PublicationStatus
+		transition      func(p *Post) error
+		wantStatus      PublicationStatus
+		wantEvent       PostEventType
+		wantScheduledAt *time.Time
+		wantPublishedAt *time.Time
+		wantErr         bool
+	}{
+		{name: "publish draft", from: StatusDraft, transition: publish, wantStatus: StatusPublished, wantEvent: PostEventTypePublishPost, wantPublishedAt: &now},
+		{name: "publish scheduled", from: StatusScheduled, transition: publish, wantStatus: StatusPublished, wantEvent: PostEventTypePublishPost, wantPublishedAt: &now},
+		{name: "publish published", from: StatusPublished, transition: publish, wantErr: true},
+		{name: "publish archived", from: StatusArchived, transition: publish, wantErr: true},
+		{name: "schedule draft", from: StatusDraft, transition: schedule, wantStatus: StatusScheduled, wantEvent: PostEventTypeSchedulePost, wantScheduledAt: &future, wantPublishedAt: &future},
+		{name: "reschedule scheduled", from: StatusScheduled, transition: schedule, wantStatus: StatusScheduled, wantEvent: PostEventTypeSchedulePost, wantScheduledAt: &future, wantPublishedAt: &future},
+		{name: "schedule published", from: StatusPublished, transition: schedule, wantErr: true},
+		{name: "unschedule scheduled", from: StatusScheduled, transition: unschedule, wantStatus: StatusDraft, wantEvent: PostEventTypeUnschedulePost},
+		{name: "unschedule draft", from: StatusDraft, transition: unschedule, wantErr: true},
+		{name: "unpublish published", from: StatusPublished, transition: unpublish, wantStatus: StatusDraft, wantEvent: PostEventTypeUnpublishPost},
+		{name: "unpublish draft", from: StatusDraft, transition: unpublish, wantErr: true},
+		{name: "archive draft", from: StatusDraft, transition: archive, wantStatus: StatusArchived, wantEvent: PostEventTypeArchivePost},
+		{name: "archive scheduled drops schedule", from: StatusScheduled, transition: archive, wantStatus: StatusArchived, wantEvent: PostEventTypeArchivePost},
+		{name: "archive archived", from: StatusArchived, transition: archive, wantErr: true},
+		{name: "unarchive archived", from: StatusArchived, transition: unarchive, wantStatus: StatusDraft, wantEvent: PostEventTypeUnarchivePost},
+		{name: "unarchive published", from: StatusPublished, transition: unarchive, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTransitionTestPost(t, tt.from, now)
+
+			err := tt.transition(p)
+			if tt.wantErr {
+				if _, ok := AsErrInvalidTransition(err); !ok {
+					t.Fatalf("error = %v, want ErrInvalidTransition", err)
+				}
+				if p.Status != tt.from {
+					t.Errorf("Status = %v, want unchanged %v", p.Status, tt.from)
+				}
+				if len(p.Events) != 0 {
+					t.Errorf("Events = %v, want none", p.Events)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+
+			if p.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", p.Status, tt.wantStatus)
+			}
+			if !EqualPtr(p.ScheduledAt, tt.wantScheduledAt) {
+				t.Errorf("ScheduledAt = %v, want %v", p.ScheduledAt, tt.wantScheduledAt)
+			}
+			if !EqualPtr(p.PublishedAt, tt.wantPublishedAt) {
+				t.Errorf("PublishedAt = %v, want %v", p.PublishedAt, tt.wantPublishedAt)
+			}
+			if len(p.Events) != 1 || p.Events[0].Type != tt.wantEvent {
+				t.Fatalf("Events = %v, want one %v", p.Events, tt.wantEvent)
+			}
+
+			e := p.Events[0]
+			if e.AggregateID != p.ID || e.ActorID == nil || *e.ActorID != testActorID || !e.OccurredAt.Equal(now) {
+				t.Errorf("event metadata = %+v, want aggregate %v, actor %v at %v", e, p.ID, testActorID, now)
+			}
+			payload, ok := e.Payload.(*PostStatusChangedPayload)
+			if !ok || payload.From != tt.from || payload.To != tt.wantStatus {
+				t.Errorf("Payload = %+v, want %v -> %v", e.Payload, tt.from, tt.wantStatus)
+			}
+			if p.LastEditorID == nil || *p.LastEditorID != testActorID {
+				t.Errorf("LastEditorID = %v, want %v", p.LastEditorID, testActorID)
+			}
+		})
+	}
+}
+
+func TestPost_Archive_KeepsPublicationTime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := newTransitionTestPost(t, StatusPublished, now)
+	publishedAt := *p.PublishedAt
+
+	if err := p.Archive(&testActorID, now); err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+
+	if p.PublishedAt == nil || !p.PublishedAt.Equal(publishedAt) {
+		t.Errorf("PublishedAt = %v, want %v", p.PublishedAt, publishedAt)
+	}
+}
+
+func TestPost_Schedule_RejectsPastTime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	p := newTransitionTestPost(t, StatusDraft, now)
+
+	err := p.Schedule(now.Add(-time.Minute), &testActorID, now)
+	if !IsErrValidation(err) {
+		t.Fatalf("Schedule() error = %v, want validation error", err)
+	}
+	if p.Status != StatusDraft {
+		t.Errorf("Status = %v, want %v", p.Status, StatusDraft)
+	}
+}
+
+func TestPost_PublishScheduled(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		from    PublicationStatus
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "overdue", from: StatusScheduled, now: now.Add(2 * time.Hour), wantErr: false},
+		{name: "exactly on time", from: StatusScheduled, now: now.Add(time.Hour), wantErr: false},
+		{name: "not yet due", from: StatusScheduled, now: now.Add(30 * time.Minute), wantErr: true},
+		{name: "draft", from: StatusDraft, now: now.Add(time.Hour), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTransitionTestPost(t, tt.from, now)
+			scheduledAt := p.ScheduledAt
+
+			err := p.PublishScheduled(tt.now)
+			if tt.wantErr {
+				if _, ok := AsErrInvalidTransition(err); !ok {
+					t.Fatalf("error = %v, want ErrInvalidTransition", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+
+			if p.Status != StatusPublished {
+				t.Errorf("Status = %v, want %v", p.Status, StatusPublished)
+			}
+			if p.ScheduledAt != nil {
+				t.Errorf("ScheduledAt = %v, want nil", p.ScheduledAt)
+			}
+			if !EqualPtr(p.PublishedAt, scheduledAt) {
+				t.Errorf("PublishedAt = %v, want scheduled time %v", p.PublishedAt, scheduledAt)
+			}
+			if len(p.Events) != 1 || p.Events[0].Type != PostEventTypePublishPost {
+				t.Fatalf("Events = %v, want one PublishPost", p.Events)
+			}
+			if p.Events[0].ActorID != nil {
+				t.Errorf("ActorID = %v, want nil for the scheduler", p.Events[0].ActorID)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package post
+
+import (
+	"encoding/json"
+
+	"github.com/ss49919201/myblog/api/internal/post/id"
+)
+
+// UserID identifies the user acting on a post.
+type UserID id.UUID
+
+func (u UserID) String() string {
+	return id.UUID(u).String()
+}
+
+func (u UserID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + u.String() + `"`), nil
+}
+
+func (u *UserID) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseUserID(s)
+	if err != nil {
+		return err
+	}
+
+	*u = parsed
+	return nil
+}
+
+func ParseUserID(userID string) (UserID, error) {
+	parsedID, err := id.ParseUUID(userID)
+	if err != nil {
+		return UserID{}, err
+	}
+
+	return UserID(parsedID), nil
+}
@@ -1,10 +1,48 @@
 package post
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
+
+// ValidationCode is a stable, machine-readable name of the validation rule
+// that failed. Together with the field it tells clients which input to
+// highlight and why.
+type ValidationCode string
+
+const (
+	// 汎用
+	ValidationCodeRequired     ValidationCode = "required"
+	ValidationCodeInvalidValue ValidationCode = "invalid_value"
+	ValidationCodeInvalidType  ValidationCode = "invalid_type"
+	ValidationCodeOutOfRange   ValidationCode = "out_of_range"
+	ValidationCodeMalformed    ValidationCode = "malformed"
+	ValidationCodeReadOnly     ValidationCode = "read_only"
+	ValidationCodeUnknownField ValidationCode = "unknown_field"
+	ValidationCodeNotRemovable ValidationCode = "not_removable"
+
+	// 投稿の内容
+	ValidationCodeLength              ValidationCode = "length"
+	ValidationCodeForbiddenCharacters ValidationCode = "forbidden_characters"
+	ValidationCodeInvalidHTML         ValidationCode = "invalid_html"
+	ValidationCodeInvalidFormat       ValidationCode = "invalid_format"
+	ValidationCodeReserved            ValidationCode = "reserved"
+	ValidationCodeNotInFuture         ValidationCode = "not_in_future"
+	ValidationCodeInvalidState        ValidationCode = "invalid_state"
+
+	// 編集ルール
+	ValidationCodeRequiredByCategory ValidationCode = "required_by_category"
+	ValidationCodeMinTags            ValidationCode = "min_tags"
+	ValidationCodeMaxExternalLinks   ValidationCode = "max_external_links"
+	ValidationCodeLeadTime           ValidationCode = "lead_time"
+	ValidationCodePublishWindow      ValidationCode = "publish_window"
+	ValidationCodeDailyQuota         ValidationCode = "daily_quota"
+)
 
 // ErrValidation represents a validation error
 type ErrValidation struct {
 	Field   string
+	Code    ValidationCode
 	Message string
 }
 
@@ -13,9 +51,10 @@ func (e *ErrValidation) Error() string {
 }
 
 // NewValidationError creates a new validation error
-func NewValidationError(field, message string) *ErrValidation {
+func NewValidationError(field string, code ValidationCode, message string) *ErrValidation {
 	return &ErrValidation{
 		Field:   field,
+		Code:    code,
 		Message: message,
 	}
 }
@@ -26,7 +65,8 @@ func IsErrValidation(err error) bool {
 	return errors.As(err, &validationErr)
 }
 
-// AsErrValidation attempts to convert error to ErrValidation
+// AsErrValidation attempts to convert error to ErrValidation.
+// For ValidationErrors it returns the first error.
 func AsErrValidation(err error) (*ErrValidation, bool) {
 	if err == nil {
 		return nil, false
@@ -38,4 +78,64 @@ func AsErrValidation(err error) (*ErrValidation, bool) {
 	}
 
 	return nil, false
-}
\ No newline at end of file
+}
+
+// ValidationErrors reports every failed rule of an input at once, so that
+// clients can point out all bad fields together.
+type ValidationErrors []*ErrValidation
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Add records the validation errors in err and returns nil. Any other error
+// is returned as is, so that the caller can stop on failures that are not
+// caused by the input.
+func (e *ValidationErrors) Add(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	all := ValidationErrorsOf(err)
+	if len(all) == 0 {
+		return err
+	}
+
+	*e = append(*e, all...)
+	return nil
+}
+
+// Err returns the recorded errors, or nil if there are none.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ValidationErrorsOf returns every validation error in err, or nil if err is
+// not a validation error.
+func ValidationErrorsOf(err error) []*ErrValidation {
+	var all ValidationErrors
+	if errors.As(err, &all) {
+		return all
+	}
+
+	if validationErr, ok := AsErrValidation(err); ok {
+		return []*ErrValidation{validationErr}
+	}
+
+	return nil
+}
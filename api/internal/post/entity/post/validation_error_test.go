@@ -0,0 +1,96 @@
+package post
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestValidationErrors_Add(t *testing.T) {
+	var errs ValidationErrors
+
+	if err := errs.Add(nil); err != nil {
+		t.Errorf("Add(nil) = %v, want nil", err)
+	}
+	if err := errs.Add(NewValidationError("title", ValidationCodeLength, "title is too long")); err != nil {
+		t.Errorf("Add(validation error) = %v, want nil", err)
+	}
+	nested := ValidationErrors{
+		NewValidationError("body", ValidationCodeLength, "body is too short"),
+		NewValidationError("tags", ValidationCodeMinTags, "too few tags"),
+	}
+	if err := errs.Add(fmt.Errorf("wrapped: %w", nested)); err != nil {
+		t.Errorf("Add(wrapped ValidationErrors) = %v, want nil", err)
+	}
+
+	other := errors.New("database is down")
+	if err := errs.Add(other); err != other {
+		t.Errorf("Add(other) = %v, want it returned as is", err)
+	}
+
+	if len(errs) != 3 {
+		t.Fatalf("len(errs) = %d, want 3", len(errs))
+	}
+	for i, want := range []string{"title", "body", "tags"} {
+		if errs[i].Field != want {
+			t.Errorf("errs[%d].Field = %q, want %q", i, errs[i].Field, want)
+		}
+	}
+}
+
+func TestValidationErrors_Err(t *testing.T) {
+	var empty ValidationErrors
+	if err := empty.Err(); err != nil {
+		t.Errorf("Err() of empty = %v, want nil", err)
+	}
+
+	errs := ValidationErrors{
+		NewValidationError("title", ValidationCodeLength, "title is too long"),
+		NewValidationError("body", ValidationCodeInvalidHTML, "body contains invalid HTML tags"),
+	}
+	err := errs.Err()
+
+	first, ok := AsErrValidation(err)
+	if !ok || first.Field != "title" {
+		t.Errorf("AsErrValidation() = %v, %v, want the title error", first, ok)
+	}
+	if all := ValidationErrorsOf(err); len(all) != 2 {
+		t.Errorf("len(ValidationErrorsOf()) = %d, want 2", len(all))
+	}
+	if got, want := err.Error(), "title is too long; body contains invalid HTML tags"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestValidationErrorsOf(t *testing.T) {
+	single := NewValidationError("slug", ValidationCodeReserved, "slug must not be a UUID")
+
+	if all := ValidationErrorsOf(single); len(all) != 1 || all[0] != single {
+		t.Errorf("ValidationErrorsOf(single) = %v, want [single]", all)
+	}
+	if all := ValidationErrorsOf(errors.New("other")); all != nil {
+		t.Errorf("ValidationErrorsOf(other) = %v, want nil", all)
+	}
+}
+
+func TestConstruct_ReportsEveryInvalidField(t *testing.T) {
+	slug := "Not A Slug!"
+	_, err := Construct("", "", BodyFormatMarkdown, "unknown", nil, "技術", nil, nil, nil, &slug, false, false, false, testAuthorID)
+
+	all := ValidationErrorsOf(err)
+	fields := map[string]ValidationCode{}
+	for _, e := range all {
+		fields[e.Field] = e.Code
+	}
+
+	want := map[string]ValidationCode{
+		"title":  ValidationCodeLength,
+		"body":   ValidationCodeLength,
+		"status": ValidationCodeInvalidValue,
+	}
+	for field, code := range want {
+		if fields[field] != code {
+			t.Errorf("code for %s = %q, want %q (errors: %v)", field, fields[field], code, err)
+		}
+	}
+}
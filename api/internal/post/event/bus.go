@@ -0,0 +1,186 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+var ErrBusClosed = errors.New("event bus is closed")
+
+// Subscriber consumes events published on a Bus.
+type Subscriber interface {
+	// Name identifies the subscriber in logs and metrics.
+	Name() string
+	HandleEvent(ctx context.Context, e post.PostEvent) error
+}
+
+// SubscriberMetrics is a snapshot of the counters kept for one subscriber.
+type SubscriberMetrics struct {
+	Queued    int64 `json:"queued"`
+	InFlight  int64 `json:"inFlight"`
+	Succeeded int64 `json:"succeeded"`
+	// Failed counts errors and panics; Panicked counts the panics alone.
+	Failed   int64 `json:"failed"`
+	Panicked int64 `json:"panicked"`
+	// Dropped counts events that were never queued because the dispatching
+	// context ended while the queue was full.
+	Dropped int64 `json:"dropped"`
+}
+
+// Bus is an in-memory EventDispatcher that fans events out to subscribers.
+// Every subscriber has its own bounded queue and worker pool, so a slow or
+// failing subscriber never delays or breaks the others.
+type Bus struct {
+	mu          sync.RWMutex
+	closed      bool
+	subscribers []*subscription
+	wg          sync.WaitGroup
+}
+
+type subscription struct {
+	subscriber Subscriber
+	queue      chan post.PostEvent
+
+	queued    atomic.Int64
+	inFlight  atomic.Int64
+	succeeded atomic.Int64
+	failed    atomic.Int64
+	panicked  atomic.Int64
+	dropped   atomic.Int64
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers s with workers goroutines consuming a queue of
+// queueSize events. It must be called before events are dispatched.
+func (b *Bus) Subscribe(s Subscriber, workers int, queueSize int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub := &subscription{
+		subscriber: s,
+		queue:      make(chan post.PostEvent, queueSize),
+	}
+	b.subscribers = append(b.subscribers, sub)
+
+	for range workers {
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			for e := range sub.queue {
+				sub.queued.Add(-1)
+				sub.handle(e)
+			}
+		}()
+	}
+}
+
+// DispatchEvents enqueues events for every subscriber. When a queue is full
+// it blocks until there is room or ctx is done, so callers such as the outbox
+// relay get backpressure instead of silently dropped events.
+func (b *Bus) DispatchEvents(ctx context.Context, events []post.PostEvent) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrBusClosed
+	}
+
+	for _, e := range events {
+		for _, sub := range b.subscribers {
+			sub.queued.Add(1)
+			select {
+			case sub.queue <- e:
+			case <-ctx.Done():
+				sub.queued.Add(-1)
+				sub.dropped.Add(1)
+				return fmt.Errorf("enqueue %s for %s: %w", e.Type, sub.subscriber.Name(), ctx.Err())
+			}
+		}
+	}
+
+	return nil
+}
+
+// Close stops accepting events and waits until every queued event has been
+// handled, or until ctx is done.
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, sub := range b.subscribers {
+			close(sub.queue)
+		}
+	}
+	b.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("event bus did not drain: %w", ctx.Err())
+	}
+}
+
+// Metrics returns the current counters keyed by subscriber name.
+func (b *Bus) Metrics() map[string]SubscriberMetrics {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	metrics := make(map[string]SubscriberMetrics, len(b.subscribers))
+	for _, sub := range b.subscribers {
+		metrics[sub.subscriber.Name()] = SubscriberMetrics{
+			Queued:    sub.queued.Load(),
+			InFlight:  sub.inFlight.Load(),
+			Succeeded: sub.succeeded.Load(),
+			Failed:    sub.failed.Load(),
+			Panicked:  sub.panicked.Load(),
+			Dropped:   sub.dropped.Load(),
+		}
+	}
+	return metrics
+}
+
+func (s *subscription) handle(e post.PostEvent) {
+	s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+
+	// 購読者のエラーやパニックは他の購読者に波及させない
+	err := func() (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				s.panicked.Add(1)
+				err = fmt.Errorf("subscriber panicked: %v", recovered)
+			}
+		}()
+		// キューに入った時点で受け付け済みなので、呼び出し元の ctx とは切り離す
+		return s.subscriber.HandleEvent(context.Background(), e)
+	}()
+
+	if err != nil {
+		s.failed.Add(1)
+		slog.Error("event subscriber failed",
+			slog.String("subscriber", s.subscriber.Name()),
+			slog.String("event", e.ID.String()),
+			slog.String("type", e.Type.String()),
+			slog.String("err", err.Error()),
+		)
+		return
+	}
+
+	s.succeeded.Add(1)
+}
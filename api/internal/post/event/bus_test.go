@@ -0,0 +1,140 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	entityevent "github.com/ss49919201/myblog/api/internal/post/entity/event"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+type recordingSubscriber struct {
+	name    string
+	handle  func(e post.PostEvent) error
+	mu      sync.Mutex
+	handled []post.PostEvent
+}
+
+func (s *recordingSubscriber) Name() string {
+	return s.name
+}
+
+func (s *recordingSubscriber) HandleEvent(ctx context.Context, e post.PostEvent) error {
+	s.mu.Lock()
+	s.handled = append(s.handled, e)
+	s.mu.Unlock()
+	if s.handle != nil {
+		return s.handle(e)
+	}
+	return nil
+}
+
+func (s *recordingSubscriber) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.handled)
+}
+
+func newBusTestEvents(n int) []post.PostEvent {
+	events := make([]post.PostEvent, n)
+	for i := range events {
+		events[i] = post.PostEvent{ID: entityevent.GenerateID(), AggregateID: post.NewPostID(), Type: post.PostEventTypeUpdatePost}
+	}
+	return events
+}
+
+func TestBus_FansOutAndIsolatesFailures(t *testing.T) {
+	bus := NewBus()
+	healthy := &recordingSubscriber{name: "healthy"}
+	failing := &recordingSubscriber{name: "failing", handle: func(post.PostEvent) error { return errors.New("boom") }}
+	panicking := &recordingSubscriber{name: "panicking", handle: func(post.PostEvent) error { panic("boom") }}
+	bus.Subscribe(healthy, 2, 10)
+	bus.Subscribe(failing, 1, 10)
+	bus.Subscribe(panicking, 1, 10)
+
+	if err := bus.DispatchEvents(context.Background(), newBusTestEvents(5)); err != nil {
+		t.Fatalf("DispatchEvents() error = %v", err)
+	}
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	for _, s := range []*recordingSubscriber{healthy, failing, panicking} {
+		if s.count() != 5 {
+			t.Errorf("%s handled %d events, want 5", s.name, s.count())
+		}
+	}
+
+	metrics := bus.Metrics()
+	want := map[string]SubscriberMetrics{
+		"healthy":   {Succeeded: 5},
+		"failing":   {Failed: 5},
+		"panicking": {Failed: 5, Panicked: 5},
+	}
+	for name, m := range want {
+		if metrics[name] != m {
+			t.Errorf("Metrics()[%q] = %+v, want %+v", name, metrics[name], m)
+		}
+	}
+}
+
+func TestBus_CloseDrainsQueuedEvents(t *testing.T) {
+	bus := NewBus()
+	release := make(chan struct{})
+	slow := &recordingSubscriber{name: "slow", handle: func(post.PostEvent) error {
+		<-release
+		return nil
+	}}
+	bus.Subscribe(slow, 1, 10)
+
+	if err := bus.DispatchEvents(context.Background(), newBusTestEvents(3)); err != nil {
+		t.Fatalf("DispatchEvents() error = %v", err)
+	}
+
+	// 処理中のイベントが終わるまで Close はタイムアウトする
+	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bus.Close(timeout); err == nil {
+		t.Fatal("Close() returned before queued events were handled")
+	}
+
+	if err := bus.DispatchEvents(context.Background(), newBusTestEvents(1)); !errors.Is(err, ErrBusClosed) {
+		t.Errorf("DispatchEvents() after Close error = %v, want ErrBusClosed", err)
+	}
+
+	close(release)
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if slow.count() != 3 {
+		t.Errorf("handled %d events, want all 3 queued events", slow.count())
+	}
+}
+
+func TestBus_DispatchBlocksWhenQueueIsFull(t *testing.T) {
+	bus := NewBus()
+	release := make(chan struct{})
+	blocked := &recordingSubscriber{name: "blocked", handle: func(post.PostEvent) error {
+		<-release
+		return nil
+	}}
+	bus.Subscribe(blocked, 1, 1)
+	defer func() {
+		close(release)
+		_ = bus.Close(context.Background())
+	}()
+
+	// 1件はワーカーが処理中、1件はキューに入り、3件目で詰まる
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := bus.DispatchEvents(ctx, newBusTestEvents(3))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DispatchEvents() error = %v, want context.DeadlineExceeded", err)
+	}
+	if m := bus.Metrics()["blocked"]; m.Dropped != 1 || m.Queued != 1 {
+		t.Errorf("Metrics() = %+v, want one queued and one dropped event", m)
+	}
+}
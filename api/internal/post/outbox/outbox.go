@@ -0,0 +1,69 @@
+// Package outbox relays post events written to the outbox table to handlers.
+//
+// Events are stored by rdb.OutboxEventDispatcher in the same transaction as
+// the post row, so an event exists if and only if the change was committed.
+// The Relay then delivers each event at least once: handlers must tolerate
+// receiving the same event ID more than once.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/event"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// Status is the delivery state of an outbox message.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusDelivered Status = "delivered"
+	// StatusDead marks a message that failed MaxAttempts times.
+	// It is kept for inspection and is never retried automatically.
+	StatusDead Status = "dead"
+)
+
+// ErrSettled is returned by Store.MarkRetry and Store.MarkDead when the
+// message is no longer pending at the attempt it was claimed with, because
+// another relay claimed and settled it in the meantime.
+var ErrSettled = errors.New("outbox message was settled by another relay")
+
+// Message is an event waiting in the outbox.
+type Message struct {
+	Event post.PostEvent
+	// Attempts is the number of failed deliveries when the message was
+	// claimed. It identifies the claim when the failure is recorded.
+	Attempts int
+}
+
+// Store reads and updates outbox messages. Every method must be called inside
+// repository.Transactor.RunInTx.
+type Store interface {
+	// ClaimDue locks up to limit pending messages whose next attempt is at or
+	// before now, skipping messages locked by another relay.
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]Message, error)
+	MarkDelivered(ctx context.Context, id event.ID, deliveredAt time.Time) error
+	// MarkRetry records a failed attempt and when the next one is due.
+	// attempts must be the claimed Message.Attempts plus one; if the message
+	// is no longer pending with the claimed count, it returns ErrSettled and
+	// changes nothing.
+	MarkRetry(ctx context.Context, id event.ID, attempts int, nextAttemptAt time.Time, lastErr string) error
+	// MarkDead moves the message to StatusDead under the same condition as
+	// MarkRetry.
+	MarkDead(ctx context.Context, id event.ID, attempts int, lastErr string) error
+}
+
+// Handler receives events relayed from the outbox.
+type Handler interface {
+	HandleEvent(ctx context.Context, e post.PostEvent) error
+}
+
+// HandlerFunc adapts a function to Handler.
+type HandlerFunc func(ctx context.Context, e post.PostEvent) error
+
+func (f HandlerFunc) HandleEvent(ctx context.Context, e post.PostEvent) error {
+	return f(ctx, e)
+}
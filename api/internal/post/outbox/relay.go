@@ -0,0 +1,223 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type RelayConfig struct {
+	// Interval is how long the relay sleeps when the outbox is drained.
+	Interval  time.Duration
+	BatchSize int
+	// MaxAttempts is the number of failed deliveries after which a message
+	// is moved to StatusDead.
+	MaxAttempts int
+	// Backoff returns the delay before the next attempt after attempts failures.
+	Backoff func(attempts int) time.Duration
+}
+
+func DefaultRelayConfig() RelayConfig {
+	return RelayConfig{
+		Interval:    time.Second,
+		BatchSize:   50,
+		MaxAttempts: 10,
+		Backoff:     ExponentialBackoff(time.Second, 10*time.Minute),
+	}
+}
+
+// ExponentialBackoff doubles the delay after every failure, starting at base
+// and never exceeding max.
+func ExponentialBackoff(base, max time.Duration) func(attempts int) time.Duration {
+	return func(attempts int) time.Duration {
+		delay := base
+		for i := 1; i < attempts; i++ {
+			delay *= 2
+			if delay >= max {
+				return max
+			}
+		}
+		return delay
+	}
+}
+
+// Relay delivers outbox messages to the registered handlers.
+// Several replicas can run a Relay at once; messages are claimed with
+// SELECT ... FOR UPDATE SKIP LOCKED.
+//
+// Each message is claimed in its own transaction. Handlers added with
+// Register run inside it, so their writes commit together with the message
+// being marked delivered and roll back together when any of them fails.
+// Handlers added with RegisterAfterCommit run once the message is committed
+// as delivered, without holding the row lock or a connection.
+type Relay struct {
+	store       Store
+	tx          repository.Transactor
+	clock       clock.Clock
+	config      RelayConfig
+	handlers    []Handler
+	afterCommit []Handler
+}
+
+func NewRelay(store Store, tx repository.Transactor, clk clock.Clock, config RelayConfig) *Relay {
+	return &Relay{store: store, tx: tx, clock: clk, config: config}
+}
+
+// Register adds a handler that writes in the relay's transaction.
+// It must be called before Run.
+func (r *Relay) Register(h Handler) {
+	r.handlers = append(r.handlers, h)
+}
+
+// RegisterAfterCommit adds a handler that is called after a message has been
+// committed as delivered, such as a hand-off to the in-memory event bus. Its
+// errors are logged and the message is not retried. It must be called before
+// Run.
+func (r *Relay) RegisterAfterCommit(h Handler) {
+	r.afterCommit = append(r.afterCommit, h)
+}
+
+// Run relays messages until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	slog.Info("outbox relay started", slog.Int("handlers", len(r.handlers)))
+
+	for {
+		processed, err := r.Tick(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to relay outbox messages", slog.String("err", err.Error()))
+		}
+
+		// 取りこぼしがありそうな間は待たずに次のバッチを処理する
+		if err == nil && processed == r.config.BatchSize {
+			if ctx.Err() != nil {
+				slog.Info("outbox relay stopped")
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("outbox relay stopped")
+			return
+		case <-r.clock.After(r.config.Interval):
+		}
+	}
+}
+
+// Tick processes up to BatchSize due messages and returns how many were
+// claimed.
+func (r *Relay) Tick(ctx context.Context) (int, error) {
+	processed := 0
+
+	for processed < r.config.BatchSize {
+		now := r.clock.Now()
+
+		var (
+			m           Message
+			claimed     bool
+			deliveryErr error
+		)
+		err := r.tx.RunInTx(ctx, func(ctx context.Context) error {
+			claimed, deliveryErr = false, nil
+
+			messages, err := r.store.ClaimDue(ctx, now, 1)
+			if err != nil {
+				return err
+			}
+			if len(messages) == 0 {
+				return nil
+			}
+			m, claimed = messages[0], true
+
+			// 失敗したハンドラーがあれば、他のハンドラーの書き込みもロールバックする
+			if deliveryErr = r.deliver(ctx, r.handlers, m); deliveryErr != nil {
+				return deliveryErr
+			}
+			return r.store.MarkDelivered(ctx, m.Event.ID, now)
+		})
+		if !claimed {
+			return processed, err
+		}
+		processed++
+
+		if deliveryErr != nil {
+			// ロールバックで行ロックが外れているので、他のリレーが先に処理していれば上書きしない
+			err := r.tx.RunInTx(ctx, func(ctx context.Context) error {
+				return r.settleFailure(ctx, m, deliveryErr, now)
+			})
+			if errors.Is(err, ErrSettled) {
+				slog.Warn("outbox message was settled by another relay",
+					slog.String("id", m.Event.ID.String()),
+					slog.String("type", m.Event.Type.String()),
+				)
+				continue
+			}
+			if err != nil {
+				return processed, err
+			}
+			continue
+		}
+		if err != nil {
+			return processed, err
+		}
+
+		if err := r.deliver(ctx, r.afterCommit, m); err != nil {
+			slog.Error("outbox message handler failed after commit",
+				slog.String("id", m.Event.ID.String()),
+				slog.String("type", m.Event.Type.String()),
+				slog.String("err", err.Error()),
+			)
+		}
+	}
+
+	return processed, nil
+}
+
+// deliver passes the message to every handler. A failure in one handler does
+// not stop the others, but the whole message is retried.
+func (r *Relay) deliver(ctx context.Context, handlers []Handler, m Message) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("handler panicked: %v", recovered)
+		}
+	}()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h.HandleEvent(ctx, m.Event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// settleFailure schedules the next attempt of m, or moves it to the dead
+// letter once MaxAttempts is reached. It runs after the claim was rolled back,
+// so the store only applies it while m is still at the claimed attempt.
+func (r *Relay) settleFailure(ctx context.Context, m Message, deliveryErr error, now time.Time) error {
+	attempts := m.Attempts + 1
+	if attempts >= r.config.MaxAttempts {
+		slog.Error("outbox message moved to dead letter",
+			slog.String("id", m.Event.ID.String()),
+			slog.String("type", m.Event.Type.String()),
+			slog.Int("attempts", attempts),
+			slog.String("err", deliveryErr.Error()),
+		)
+		return r.store.MarkDead(ctx, m.Event.ID, attempts, deliveryErr.Error())
+	}
+
+	slog.Warn("outbox message delivery failed",
+		slog.String("id", m.Event.ID.String()),
+		slog.String("type", m.Event.Type.String()),
+		slog.Int("attempts", attempts),
+		slog.String("err", deliveryErr.Error()),
+	)
+	return r.store.MarkRetry(ctx, m.Event.ID, attempts, now.Add(r.config.Backoff(attempts)), deliveryErr.Error())
+}
@@ -0,0 +1,299 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock/clocktest"
+	"github.com/ss49919201/myblog/api/internal/post/entity/event"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+type storedMessage struct {
+	message       Message
+	status        Status
+	nextAttemptAt time.Time
+	lastErr       string
+}
+
+// memoryStore is an in-memory Store and Transactor.
+type memoryStore struct {
+	messages map[event.ID]*storedMessage
+	inTx     bool
+}
+
+func newMemoryStore(now time.Time, events ...post.PostEvent) *memoryStore {
+	s := &memoryStore{messages: map[event.ID]*storedMessage{}}
+	for _, e := range events {
+		s.messages[e.ID] = &storedMessage{message: Message{Event: e}, status: StatusPending, nextAttemptAt: now}
+	}
+	return s
+}
+
+func (s *memoryStore) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.inTx = true
+	defer func() { s.inTx = false }()
+	return fn(ctx)
+}
+
+func (s *memoryStore) ClaimDue(ctx context.Context, now time.Time, limit int) ([]Message, error) {
+	var due []*storedMessage
+	for _, m := range s.messages {
+		if m.status == StatusPending && !m.nextAttemptAt.After(now) {
+			due = append(due, m)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].nextAttemptAt.Before(due[j].nextAttemptAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	messages := make([]Message, 0, len(due))
+	for _, m := range due {
+		messages = append(messages, m.message)
+	}
+	return messages, nil
+}
+
+func (s *memoryStore) MarkDelivered(ctx context.Context, id event.ID, deliveredAt time.Time) error {
+	s.messages[id].status = StatusDelivered
+	return nil
+}
+
+func (s *memoryStore) MarkRetry(ctx context.Context, id event.ID, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	m := s.messages[id]
+	if m.status != StatusPending || m.message.Attempts != attempts-1 {
+		return ErrSettled
+	}
+	m.message.Attempts = attempts
+	m.nextAttemptAt = nextAttemptAt
+	m.lastErr = lastErr
+	return nil
+}
+
+func (s *memoryStore) MarkDead(ctx context.Context, id event.ID, attempts int, lastErr string) error {
+	m := s.messages[id]
+	if m.status != StatusPending || m.message.Attempts != attempts-1 {
+		return ErrSettled
+	}
+	m.status = StatusDead
+	m.message.Attempts = attempts
+	m.lastErr = lastErr
+	return nil
+}
+
+func newTestEvent() post.PostEvent {
+	return post.PostEvent{ID: event.GenerateID(), AggregateID: post.NewPostID(), Type: post.PostEventTypePublishPost}
+}
+
+func testConfig() RelayConfig {
+	return RelayConfig{
+		Interval:    time.Second,
+		BatchSize:   10,
+		MaxAttempts: 3,
+		Backoff:     ExponentialBackoff(time.Minute, time.Hour),
+	}
+}
+
+func TestRelay_Tick_DeliversToEveryHandler(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	e := newTestEvent()
+	store := newMemoryStore(now, e)
+	relay := NewRelay(store, store, clocktest.NewFake(now), testConfig())
+
+	var got []string
+	relay.Register(HandlerFunc(func(ctx context.Context, e post.PostEvent) error {
+		got = append(got, "first:"+e.ID.String())
+		return nil
+	}))
+	relay.Register(HandlerFunc(func(ctx context.Context, e post.PostEvent) error {
+		got = append(got, "second:"+e.ID.String())
+		return nil
+	}))
+
+	processed, err := relay.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+	if processed != 1 {
+		t.Errorf("processed = %d, want 1", processed)
+	}
+	if len(got) != 2 {
+		t.Errorf("handlers received %v, want both handlers called once", got)
+	}
+	if status := store.messages[e.ID].status; status != StatusDelivered {
+		t.Errorf("status = %v, want %v", status, StatusDelivered)
+	}
+
+	// 配信済みのメッセージは再配信されない
+	processed, err = relay.Tick(context.Background())
+	if err != nil || processed != 0 {
+		t.Errorf("second Tick() = %d, %v, want 0, nil", processed, err)
+	}
+}
+
+func TestRelay_Tick_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	clk := clocktest.NewFake(now)
+	e := newTestEvent()
+	store := newMemoryStore(now, e)
+	relay := NewRelay(store, store, clk, testConfig())
+
+	calls := 0
+	relay.Register(HandlerFunc(func(ctx context.Context, e post.PostEvent) error {
+		calls++
+		return errors.New("downstream unavailable")
+	}))
+
+	steps := []struct {
+		advance       time.Duration
+		wantCalls     int
+		wantStatus    Status
+		wantAttempts  int
+		wantNextRetry time.Duration
+	}{
+		{advance: 0, wantCalls: 1, wantStatus: StatusPending, wantAttempts: 1, wantNextRetry: time.Minute},
+		// バックオフ期間中は配信しない
+		{advance: 30 * time.Second, wantCalls: 1, wantStatus: StatusPending, wantAttempts: 1},
+		{advance: 30 * time.Second, wantCalls: 2, wantStatus: StatusPending, wantAttempts: 2, wantNextRetry: 2 * time.Minute},
+		{advance: 2 * time.Minute, wantCalls: 3, wantStatus: StatusDead, wantAttempts: 3},
+		// dead letter は再試行しない
+		{advance: time.Hour, wantCalls: 3, wantStatus: StatusDead, wantAttempts: 3},
+	}
+
+	for i, step := range steps {
+		clk.Advance(step.advance)
+		if _, err := relay.Tick(context.Background()); err != nil {
+			t.Fatalf("step %d: Tick() error = %v", i, err)
+		}
+
+		m := store.messages[e.ID]
+		if calls != step.wantCalls {
+			t.Errorf("step %d: calls = %d, want %d", i, calls, step.wantCalls)
+		}
+		if m.status != step.wantStatus {
+			t.Errorf("step %d: status = %v, want %v", i, m.status, step.wantStatus)
+		}
+		if m.message.Attempts != step.wantAttempts {
+			t.Errorf("step %d: attempts = %d, want %d", i, m.message.Attempts, step.wantAttempts)
+		}
+		if step.wantNextRetry != 0 && !m.nextAttemptAt.Equal(clk.Now().Add(step.wantNextRetry)) {
+			t.Errorf("step %d: nextAttemptAt = %v, want %v", i, m.nextAttemptAt, clk.Now().Add(step.wantNextRetry))
+		}
+		if m.lastErr != "downstream unavailable" {
+			t.Errorf("step %d: lastErr = %q", i, m.lastErr)
+		}
+	}
+}
+
+func TestRelay_Tick_AfterCommitHandlersSeeDeliveredMessagesOnly(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ok, failing := newTestEvent(), newTestEvent()
+	store := newMemoryStore(now, ok, failing)
+	relay := NewRelay(store, store, clocktest.NewFake(now), testConfig())
+
+	relay.Register(HandlerFunc(func(ctx context.Context, e post.PostEvent) error {
+		if e.ID == failing.ID {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	}))
+
+	var got []event.ID
+	relay.RegisterAfterCommit(HandlerFunc(func(ctx context.Context, e post.PostEvent) error {
+		if store.inTx {
+			t.Error("after-commit handler called inside the transaction")
+		}
+		got = append(got, e.ID)
+		return errors.New("subscriber failed")
+	}))
+
+	processed, err := relay.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+	if processed != 2 {
+		t.Errorf("processed = %d, want 2", processed)
+	}
+	if len(got) != 1 || got[0] != ok.ID {
+		t.Errorf("after-commit handler received %v, want only %v", got, ok.ID)
+	}
+
+	// コミット後のハンドラーの失敗では再配信しない
+	if status := store.messages[ok.ID].status; status != StatusDelivered {
+		t.Errorf("status = %v, want %v", status, StatusDelivered)
+	}
+	if m := store.messages[failing.ID]; m.status != StatusPending || m.message.Attempts != 1 {
+		t.Errorf("failing message = %+v, want pending with one failed attempt", m)
+	}
+}
+
+func TestRelay_Tick_RecoversFromHandlerPanic(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	e := newTestEvent()
+	store := newMemoryStore(now, e)
+	relay := NewRelay(store, store, clocktest.NewFake(now), testConfig())
+	relay.Register(HandlerFunc(func(ctx context.Context, e post.PostEvent) error {
+		panic("boom")
+	}))
+
+	if _, err := relay.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+
+	m := store.messages[e.ID]
+	if m.status != StatusPending || m.message.Attempts != 1 {
+		t.Errorf("message = %+v, want pending with one failed attempt", m)
+	}
+}
+
+func TestRelay_Tick_DoesNotOverwriteMessageSettledByAnotherRelay(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	e := newTestEvent()
+	store := newMemoryStore(now, e)
+	relay := NewRelay(store, store, clocktest.NewFake(now), testConfig())
+
+	// 失敗を記録する前に、他のリレーがメッセージを配信済みにした状況を再現する
+	relay.Register(HandlerFunc(func(ctx context.Context, e post.PostEvent) error {
+		store.messages[e.ID].status = StatusDelivered
+		return errors.New("downstream unavailable")
+	}))
+
+	processed, err := relay.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+	if processed != 1 {
+		t.Errorf("processed = %d, want 1", processed)
+	}
+
+	m := store.messages[e.ID]
+	if m.status != StatusDelivered || m.message.Attempts != 0 || m.lastErr != "" {
+		t.Errorf("message = %+v, want delivered by the other relay and left untouched", m)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 10*time.Second)
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 100, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/event"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/outbox"
+)
+
+// OutboxEventDispatcher implements event.EventDispatcher by writing events to
+// the outbox table. Called inside Transactor.RunInTx, the events are committed
+// atomically with the post change; outbox.Relay delivers them afterwards.
+type OutboxEventDispatcher struct {
+	db *sql.DB
+}
+
+func NewOutboxEventDispatcher(db *sql.DB) *OutboxEventDispatcher {
+	return &OutboxEventDispatcher{db: db}
+}
+
+func (d *OutboxEventDispatcher) DispatchEvents(ctx context.Context, events []post.PostEvent) error {
+	query := `INSERT INTO outbox (id, aggregate_id, event_type, payload, status, attempts, next_attempt_at, created_at) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, 'pending', 0, ?, ?)`
+
+	now := time.Now()
+	for _, e := range events {
+		// payload には外部にも公開できる JSON エンベロープをそのまま保存する
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		if _, err := Conn(ctx, d.db).ExecContext(ctx, query, e.ID.String(), e.AggregateID.String(), e.Type.String(), payload, now, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type OutboxStoreImpl struct {
+	db *sql.DB
+}
+
+func NewOutboxStore(db *sql.DB) outbox.Store {
+	return &OutboxStoreImpl{db: db}
+}
+
+func (s *OutboxStoreImpl) ClaimDue(ctx context.Context, now time.Time, limit int) ([]outbox.Message, error) {
+	query := `SELECT BIN_TO_UUID(id), payload, attempts FROM outbox WHERE status = 'pending' AND next_attempt_at <= ? ORDER BY next_attempt_at, created_at LIMIT ? FOR UPDATE SKIP LOCKED`
+
+	rows, err := Conn(ctx, s.db).QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []outbox.Message
+	undecodable := map[string]error{}
+	for rows.Next() {
+		var idStr string
+		var payload []byte
+		var attempts int
+		if err := rows.Scan(&idStr, &payload, &attempts); err != nil {
+			return nil, err
+		}
+
+		var e post.PostEvent
+		if err := json.Unmarshal(payload, &e); err != nil {
+			undecodable[idStr] = err
+			continue
+		}
+
+		messages = append(messages, outbox.Message{Event: e, Attempts: attempts})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// 読めないメッセージは何度試しても配信できないので、即座に dead にする
+	for idStr, decodeErr := range undecodable {
+		query := `UPDATE outbox SET status = 'dead', last_error = ? WHERE id = UUID_TO_BIN(?)`
+		if err := s.exec(ctx, query, "undecodable payload: "+decodeErr.Error(), idStr); err != nil {
+			return nil, err
+		}
+	}
+
+	return messages, nil
+}
+
+func (s *OutboxStoreImpl) MarkDelivered(ctx context.Context, id event.ID, deliveredAt time.Time) error {
+	query := `UPDATE outbox SET status = 'delivered', delivered_at = ? WHERE id = UUID_TO_BIN(?)`
+
+	return s.exec(ctx, query, deliveredAt, id.String())
+}
+
+// MarkRetry と MarkDead は配信失敗でロールバックした後に呼ばれるため、
+// 取得時の attempts のまま pending である場合だけ更新する
+func (s *OutboxStoreImpl) MarkRetry(ctx context.Context, id event.ID, attempts int, nextAttemptAt time.Time, lastErr string) error {
+	query := `UPDATE outbox SET attempts = ?, next_attempt_at = ?, last_error = ? WHERE id = UUID_TO_BIN(?) AND status = 'pending' AND attempts = ?`
+
+	return s.settle(ctx, query, attempts, nextAttemptAt, lastErr, id.String(), attempts-1)
+}
+
+func (s *OutboxStoreImpl) MarkDead(ctx context.Context, id event.ID, attempts int, lastErr string) error {
+	query := `UPDATE outbox SET status = 'dead', attempts = ?, last_error = ? WHERE id = UUID_TO_BIN(?) AND status = 'pending' AND attempts = ?`
+
+	return s.settle(ctx, query, attempts, lastErr, id.String(), attempts-1)
+}
+
+func (s *OutboxStoreImpl) settle(ctx context.Context, query string, args ...any) error {
+	result, err := Conn(ctx, s.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return outbox.ErrSettled
+	}
+
+	return nil
+}
+
+func (s *OutboxStoreImpl) exec(ctx context.Context, query string, args ...any) error {
+	result, err := Conn(ctx, s.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("outbox message not found")
+	}
+
+	return nil
+}
@@ -8,20 +8,24 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/ss49919201/myblog/api/internal/post/entity/post"
 	"github.com/ss49919201/myblog/api/internal/post/repository"
 )
 
 type PostRepositoryImpl struct {
-	db *sql.DB
+	db         *sql.DB
+	slugPolicy SlugDeletePolicy
 }
 
-func NewPostRepository(db *sql.DB) repository.PostRepository {
-	return &PostRepositoryImpl{db: db}
+// NewPostRepository returns a repository that handles the slugs of deleted
+// posts according to slugPolicy.
+func NewPostRepository(db *sql.DB, slugPolicy SlugDeletePolicy) repository.PostRepository {
+	return &PostRepositoryImpl{db: db, slugPolicy: slugPolicy}
 }
 
 func (r *PostRepositoryImpl) Create(ctx context.Context, p *post.Post) error {
-	query := `INSERT INTO posts (id, title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at) VALUES (UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO posts (id, title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, author_id, last_editor_id, review_status, body_format, rendered_body) VALUES (UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?)`
 
 	// tagsをJSON文字列に変換
 	var tagsJSON *string
@@ -30,7 +34,7 @@ func (r *PostRepositoryImpl) Create(ctx context.Context, p *post.Post) error {
 		tagsJSON = &tagsStr
 	}
 
-	_, err := r.db.ExecContext(ctx, query, 
+	_, err := Conn(ctx, r.db).ExecContext(ctx, query, 
 		p.ID.String(), 
 		p.Title, 
 		p.Body, 
@@ -46,26 +50,87 @@ func (r *PostRepositoryImpl) Create(ctx context.Context, p *post.Post) error {
 		p.EmergencyFlag, 
 		p.CreatedAt, 
 		p.PublishedAt,
+		userIDArg(p.AuthorID),
+		userIDArg(p.LastEditorID),
+		p.ReviewStatus,
+		p.BodyFormat,
+		p.RenderedBody,
 	)
-	return err
+	return slugConflictOr(err, p)
 }
 
+const selectPostColumns = `BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body`
+
 func (r *PostRepositoryImpl) FindByID(ctx context.Context, id post.PostID) (*post.Post, error) {
-	query := `SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts WHERE id = UUID_TO_BIN(?)`
+	query := `SELECT ` + selectPostColumns + ` FROM posts WHERE id = UUID_TO_BIN(?) AND deleted_at IS NULL`
 
-	row := r.db.QueryRowContext(ctx, query, id.String())
+	row := Conn(ctx, r.db).QueryRowContext(ctx, query, id.String())
 
-	var idStr, title, body, status, category string
+	p, err := scanPost(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("post not found")
+		}
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// FindDueScheduledForUpdate locks up to limit scheduled posts whose scheduled
+// time is at or before now, leaving out the posts in exclude. Rows locked by
+// another replica are skipped, so it must be called inside Transactor.RunInTx.
+func (r *PostRepositoryImpl) FindDueScheduledForUpdate(ctx context.Context, now time.Time, limit int, exclude []post.PostID) ([]*post.Post, error) {
+	args := []any{now}
+	excludeCond := ""
+	if len(exclude) > 0 {
+		excludeCond = ` AND id NOT IN (` + strings.TrimSuffix(strings.Repeat("UUID_TO_BIN(?), ", len(exclude)), ", ") + `)`
+		for _, id := range exclude {
+			args = append(args, id.String())
+		}
+	}
+	args = append(args, limit)
+
+	query := `SELECT ` + selectPostColumns + ` FROM posts WHERE status = 'scheduled' AND scheduled_at <= ? AND deleted_at IS NULL` + excludeCond + ` ORDER BY scheduled_at LIMIT ? FOR UPDATE SKIP LOCKED`
+
+	rows, err := Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*post.Post
+	for rows.Next() {
+		p, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPost reads a row selected with selectPostColumns, followed by any
+// columns scanned into extra.
+func scanPost(row rowScanner, extra ...any) (*post.Post, error) {
+	var idStr, title, body, status, category, reviewStatus, bodyFormat string
 	var scheduledAt, publishedAt *time.Time
-	var tagsJSON, featuredImageURL, metaDescription, slug *string
+	var tagsJSON, featuredImageURL, metaDescription, slug, authorIDStr, lastEditorIDStr, renderedBody *string
 	var snsAutoPost, externalNotification, emergencyFlag bool
 	var createdAt time.Time
+	var version int
 
-	err := row.Scan(&idStr, &title, &body, &status, &scheduledAt, &category, &tagsJSON, &featuredImageURL, &metaDescription, &slug, &snsAutoPost, &externalNotification, &emergencyFlag, &createdAt, &publishedAt)
+	dest := []any{&idStr, &title, &body, &status, &scheduledAt, &category, &tagsJSON, &featuredImageURL, &metaDescription, &slug, &snsAutoPost, &externalNotification, &emergencyFlag, &createdAt, &publishedAt, &authorIDStr, &lastEditorIDStr, &version, &reviewStatus, &bodyFormat, &renderedBody}
+	err := row.Scan(append(dest, extra...)...)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("post not found")
-		}
 		return nil, err
 	}
 
@@ -82,16 +147,23 @@ func (r *PostRepositoryImpl) FindByID(ctx context.Context, id post.PostID) (*pos
 		}
 	}
 
-	p, err := post.Reconstruct(postID, title, body, post.PublicationStatus(status), scheduledAt, category, tags, featuredImageURL, metaDescription, slug, snsAutoPost, externalNotification, emergencyFlag, createdAt, publishedAt)
+	authorID, err := parseUserIDPtr(authorIDStr)
+	if err != nil {
+		return nil, err
+	}
+	lastEditorID, err := parseUserIDPtr(lastEditorIDStr)
 	if err != nil {
 		return nil, err
 	}
 
-	return p, nil
+	return post.Reconstruct(postID, title, body, post.BodyFormat(bodyFormat), stringValue(renderedBody), post.PublicationStatus(status), scheduledAt, category, tags, featuredImageURL, metaDescription, slug, snsAutoPost, externalNotification, emergencyFlag, createdAt, publishedAt, authorID, lastEditorID, version, post.ReviewStatus(reviewStatus))
 }
 
+// Update stores p only if the row is still at p.Version, and increments the
+// version on success. A row changed since p was read is reported as
+// *post.ErrVersionConflict. Posts in the trash are not updated.
 func (r *PostRepositoryImpl) Update(ctx context.Context, p *post.Post) error {
-	query := `UPDATE posts SET title = ?, body = ?, status = ?, scheduled_at = ?, category = ?, tags = ?, featured_image_url = ?, meta_description = ?, slug = ?, sns_auto_post = ?, external_notification = ?, emergency_flag = ?, published_at = ? WHERE id = UUID_TO_BIN(?)`
+	query := `UPDATE posts SET title = ?, body = ?, status = ?, scheduled_at = ?, category = ?, tags = ?, featured_image_url = ?, meta_description = ?, slug = ?, sns_auto_post = ?, external_notification = ?, emergency_flag = ?, published_at = ?, last_editor_id = UUID_TO_BIN(?), review_status = ?, body_format = ?, rendered_body = ?, version = version + 1 WHERE id = UUID_TO_BIN(?) AND version = ? AND deleted_at IS NULL`
 
 	// tagsをJSON文字列に変換
 	var tagsJSON *string
@@ -100,7 +172,13 @@ func (r *PostRepositoryImpl) Update(ctx context.Context, p *post.Post) error {
 		tagsJSON = &tagsStr
 	}
 
-	result, err := r.db.ExecContext(ctx, query, 
+	// 履歴に残す slug は更新前に読み、更新が行に当たってから書き込む
+	previousSlug, err := storedSlug(ctx, r.db, p)
+	if err != nil {
+		return err
+	}
+
+	result, err := Conn(ctx, r.db).ExecContext(ctx, query, 
 		p.Title, 
 		p.Body, 
 		p.Status, 
@@ -114,10 +192,15 @@ func (r *PostRepositoryImpl) Update(ctx context.Context, p *post.Post) error {
 		p.ExternalNotification, 
 		p.EmergencyFlag, 
 		p.PublishedAt, 
+		userIDArg(p.LastEditorID),
+		p.ReviewStatus,
+		p.BodyFormat,
+		p.RenderedBody,
 		p.ID.String(),
+		p.Version,
 	)
 	if err != nil {
-		return err
+		return slugConflictOr(err, p)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -126,16 +209,24 @@ func (r *PostRepositoryImpl) Update(ctx context.Context, p *post.Post) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("post not found")
+		return r.versionConflictOrNotFound(ctx, p.ID, false)
 	}
 
-	return nil
+	p.Version++
+
+	if err := recordPreviousSlug(ctx, r.db, p, previousSlug); err != nil {
+		return err
+	}
+
+	return reclaimSlug(ctx, r.db, p)
 }
 
-func (r *PostRepositoryImpl) Delete(ctx context.Context, id post.PostID) error {
-	query := `DELETE FROM posts WHERE id = UUID_TO_BIN(?)`
+// Delete moves p to the trash, recording p.DeletedAt and p.DeletedBy, only if
+// the row is still at p.Version. The row is removed later by Purge.
+func (r *PostRepositoryImpl) Delete(ctx context.Context, p *post.Post) error {
+	query := `UPDATE posts SET deleted_at = ?, deleted_by = UUID_TO_BIN(?), version = version + 1 WHERE id = UUID_TO_BIN(?) AND version = ? AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query, id.String())
+	result, err := Conn(ctx, r.db).ExecContext(ctx, query, p.DeletedAt, userIDArg(p.DeletedBy), p.ID.String(), p.Version)
 	if err != nil {
 		return err
 	}
@@ -146,20 +237,39 @@ func (r *PostRepositoryImpl) Delete(ctx context.Context, id post.PostID) error {
 	}
 
 	if rowsAffected == 0 {
-		return errors.New("post not found")
+		return r.versionConflictOrNotFound(ctx, p.ID, false)
 	}
 
+	p.Version++
+
 	return nil
 }
 
+// versionConflictOrNotFound explains why a conditional write matched no row:
+// either there is no such post in or out of the trash, as trashed asks, or
+// another request has changed it.
+func (r *PostRepositoryImpl) versionConflictOrNotFound(ctx context.Context, id post.PostID, trashed bool) error {
+	query := `SELECT version FROM posts WHERE id = UUID_TO_BIN(?) AND (deleted_at IS NOT NULL) = ?`
+
+	var current int
+	if err := Conn(ctx, r.db).QueryRowContext(ctx, query, id.String(), trashed).Scan(&current); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("post not found")
+		}
+		return err
+	}
+
+	return &post.ErrVersionConflict{PostID: id, Current: current}
+}
+
 func (r *PostRepositoryImpl) CountScheduledSameDayByCategory(ctx context.Context, category string, scheduledAt time.Time) (int, error) {
 	// 同じ日付の0時～23:59:59の範囲でカウント
 	startOfDay := time.Date(scheduledAt.Year(), scheduledAt.Month(), scheduledAt.Day(), 0, 0, 0, 0, scheduledAt.Location())
 	endOfDay := startOfDay.Add(24 * time.Hour).Add(-1 * time.Nanosecond)
 
-	query := `SELECT COUNT(*) FROM posts WHERE category = ? AND status = 'scheduled' AND scheduled_at >= ? AND scheduled_at <= ?`
+	query := `SELECT COUNT(*) FROM posts WHERE category = ? AND status = 'scheduled' AND scheduled_at >= ? AND scheduled_at <= ? AND deleted_at IS NULL`
 
-	row := r.db.QueryRowContext(ctx, query, category, startOfDay, endOfDay)
+	row := Conn(ctx, r.db).QueryRowContext(ctx, query, category, startOfDay, endOfDay)
 
 	var count int
 	err := row.Scan(&count)
@@ -169,3 +279,64 @@ func (r *PostRepositoryImpl) CountScheduledSameDayByCategory(ctx context.Context
 
 	return count, nil
 }
+
+// SlugTaken reports whether a post other than exclude uses slug, either as its
+// current slug or as an old one kept for redirects, or whether slug belonged
+// to a deleted post and was tombstoned. Posts in the trash keep their slugs
+// until they are purged.
+func (r *PostRepositoryImpl) SlugTaken(ctx context.Context, slug string, exclude post.PostID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM posts WHERE slug = ? AND id <> UUID_TO_BIN(?))
+		OR EXISTS (SELECT 1 FROM post_slug_history WHERE slug = ? AND (post_id <> UUID_TO_BIN(?) OR tombstoned_at IS NOT NULL))`
+
+	var taken bool
+	if err := Conn(ctx, r.db).QueryRowContext(ctx, query, slug, exclude.String(), slug, exclude.String()).Scan(&taken); err != nil {
+		return false, err
+	}
+
+	return taken, nil
+}
+
+// mysqlErrDuplicateEntry is the MySQL error number of a unique key violation.
+const mysqlErrDuplicateEntry = 1062
+
+// slugConflictOr turns a violation of uk_slug into *post.ErrSlugConflict so a
+// slug taken by a concurrent request is reported like any other conflict.
+func slugConflictOr(err error, p *post.Post) error {
+	var mysqlErr *mysql.MySQLError
+	if p.Slug != nil && errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry && strings.Contains(mysqlErr.Message, "uk_slug") {
+		return &post.ErrSlugConflict{Slug: *p.Slug}
+	}
+
+	return err
+}
+
+// userIDArg converts an optional user ID into a UUID_TO_BIN argument.
+func userIDArg(userID *post.UserID) *string {
+	if userID == nil {
+		return nil
+	}
+
+	s := userID.String()
+	return &s
+}
+
+func parseUserIDPtr(s *string) (*post.UserID, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	userID, err := post.ParseUserID(*s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userID, nil
+}
+
+// stringValue returns the value of a nullable column, or "" for NULL.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
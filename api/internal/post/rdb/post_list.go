@@ -0,0 +1,235 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+const (
+	DefaultPostListLimit = 20
+	MaxPostListLimit     = 100
+)
+
+// PostSort is a sort option of the post list. A leading "-" sorts descending.
+type PostSort string
+
+const (
+	PostSortCreatedAtDesc   PostSort = "-createdAt"
+	PostSortCreatedAtAsc    PostSort = "createdAt"
+	PostSortPublishedAtDesc PostSort = "-publishedAt"
+	PostSortPublishedAtAsc  PostSort = "publishedAt"
+)
+
+func ParsePostSort(s string) (PostSort, error) {
+	switch sort := PostSort(s); sort {
+	case PostSortCreatedAtDesc, PostSortCreatedAtAsc, PostSortPublishedAtDesc, PostSortPublishedAtAsc:
+		return sort, nil
+	}
+	return "", errors.New("sort must be one of -createdAt, createdAt, -publishedAt, publishedAt")
+}
+
+func (s PostSort) field() FieldFindPosts {
+	if s == PostSortPublishedAtAsc || s == PostSortPublishedAtDesc {
+		return PublishedAt
+	}
+	return CreatedAt
+}
+
+func (s PostSort) desc() bool {
+	return s == PostSortCreatedAtDesc || s == PostSortPublishedAtDesc
+}
+
+// PostListFilter narrows the post list. Nil fields do not filter.
+// Date ranges include From and exclude To.
+type PostListFilter struct {
+	Status        *post.PublicationStatus
+	ReviewStatus  *post.ReviewStatus
+	Category      *string
+	Tag           *string
+	AuthorID      *post.UserID
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	PublishedFrom *time.Time
+	PublishedTo   *time.Time
+	// PublishedUntil keeps posts published at or before the time, unlike
+	// PublishedTo which excludes it.
+	PublishedUntil *time.Time
+}
+
+type PostListQuery struct {
+	Filter PostListFilter
+	Sort   PostSort
+	// Limit is the page size, between 1 and MaxPostListLimit.
+	Limit int
+	// Cursor is the NextCursor of the previous page, or "" for the first page.
+	Cursor       string
+	IncludeTotal bool
+}
+
+type PostPage struct {
+	Items []*post.Post
+	// NextCursor is nil on the last page.
+	NextCursor *string
+	// Total is the number of posts matching the filter, counted only when
+	// IncludeTotal is set.
+	Total *int
+}
+
+// ErrInvalidCursor is returned when a cursor was not issued for the
+// requested sort.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// postCursor is the position after the last post of a page. It is sent to
+// clients as opaque base64url JSON.
+type postCursor struct {
+	Sort PostSort  `json:"s"`
+	At   time.Time `json:"t"`
+	ID   string    `json:"i"`
+}
+
+func encodePostCursor(c postCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePostCursor(s string, sort PostSort) (postCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return postCursor{}, ErrInvalidCursor
+	}
+
+	var c postCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Sort != sort {
+		return postCursor{}, ErrInvalidCursor
+	}
+	if _, err := post.ParsePostID(c.ID); err != nil {
+		return postCursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// FindPostPage returns one page of posts ordered by q.Sort with the post ID
+// as tie breaker, so pages stay stable while posts are added.
+// Sorting by publishedAt lists only posts that have a publication time.
+func FindPostPage(ctx context.Context, db *sql.DB, q PostListQuery) (*PostPage, error) {
+	sort := q.Sort
+	if sort == "" {
+		sort = PostSortCreatedAtDesc
+	}
+
+	filter := postListCriteria(q.Filter, sort)
+
+	page := &PostPage{}
+	if q.IncludeTotal {
+		total, err := CountPosts(ctx, db, filter)
+		if err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	var cursor *postCursor
+	if q.Cursor != "" {
+		c, err := decodePostCursor(q.Cursor, sort)
+		if err != nil {
+			return nil, err
+		}
+		cursor = &c
+	}
+
+	// 次のページがあるかを判定するために 1 件多く取得する
+	posts, err := FindPosts(ctx, db, postPageCriteria(filter, sort, cursor, q.Limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(posts) > q.Limit {
+		posts = posts[:q.Limit]
+		last := posts[len(posts)-1]
+		at := last.CreatedAt
+		if sort.field() == PublishedAt {
+			at = *last.PublishedAt
+		}
+		next := encodePostCursor(postCursor{Sort: sort, At: at, ID: last.ID.String()})
+		page.NextCursor = &next
+	}
+	page.Items = posts
+
+	return page, nil
+}
+
+// postPageCriteria selects up to limit posts matching filter after cursor.
+func postPageCriteria(filter CriteriaFindPosts, sort PostSort, cursor *postCursor, limit int) CriteriaFindPosts {
+	criteria := NewCriteriaFindPosts().And(filter)
+	if cursor != nil {
+		criteria.And(afterCursor(sort, *cursor))
+	}
+
+	return criteria.
+		OrderBy(sort.field(), sort.desc()).
+		OrderBy(ID, sort.desc()).
+		Limit(limit)
+}
+
+func postListCriteria(f PostListFilter, sort PostSort) CriteriaFindPosts {
+	c := NewCriteriaFindPosts()
+
+	if f.Status != nil {
+		c.Eq(ExprEqStatus(*f.Status))
+	}
+	if f.ReviewStatus != nil {
+		c.Where(ExprEqual(ReviewStatus, *f.ReviewStatus))
+	}
+	if f.Category != nil {
+		c.Eq(ExprEqCategory(*f.Category))
+	}
+	if f.Tag != nil {
+		c.Where(ExprContainsTag(*f.Tag))
+	}
+	if f.AuthorID != nil {
+		c.Eq(ExprEqAuthorID(f.AuthorID.String()))
+	}
+	if f.CreatedFrom != nil {
+		c.Where(ExprGreaterOrEqual(CreatedAt, *f.CreatedFrom))
+	}
+	if f.CreatedTo != nil {
+		c.Where(ExprLessThan(CreatedAt, *f.CreatedTo))
+	}
+	if f.PublishedFrom != nil {
+		c.Where(ExprGreaterOrEqual(PublishedAt, *f.PublishedFrom))
+	}
+	if f.PublishedTo != nil {
+		c.Where(ExprLessThan(PublishedAt, *f.PublishedTo))
+	}
+	if f.PublishedUntil != nil {
+		c.Where(ExprLessOrEqual(PublishedAt, *f.PublishedUntil))
+	}
+	if sort.field() == PublishedAt {
+		c.Where(ExprIsNotNull(PublishedAt))
+	}
+
+	return c
+}
+
+// afterCursor matches the posts that come after cursor in sort order:
+// (key < t) OR (key = t AND id < cursor id) when descending.
+func afterCursor(sort PostSort, cursor postCursor) CriteriaFindPosts {
+	after, afterID := ExprGreaterThan(sort.field(), cursor.At), ExprGreaterThan(ID, cursor.ID)
+	if sort.desc() {
+		after, afterID = ExprLessThan(sort.field(), cursor.At), ExprLessThan(ID, cursor.ID)
+	}
+
+	return Or(
+		NewCriteriaFindPosts().Where(after),
+		NewCriteriaFindPosts().
+			Where(ExprEqual(sort.field(), cursor.At)).
+			Where(afterID),
+	)
+}
@@ -0,0 +1,158 @@
+package rdb
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+func TestPostCursor_RoundTrip(t *testing.T) {
+	want := postCursor{
+		Sort: PostSortPublishedAtDesc,
+		At:   time.Date(2025, 1, 2, 3, 4, 5, 600000, time.UTC),
+		ID:   post.NewPostID().String(),
+	}
+
+	got, err := decodePostCursor(encodePostCursor(want), PostSortPublishedAtDesc)
+	if err != nil {
+		t.Fatalf("decodePostCursor: %v", err)
+	}
+	if !got.At.Equal(want.At) || got.ID != want.ID || got.Sort != want.Sort {
+		t.Errorf("decoded %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodePostCursor_Invalid(t *testing.T) {
+	valid := encodePostCursor(postCursor{Sort: PostSortCreatedAtDesc, At: time.Now(), ID: post.NewPostID().String()})
+
+	tests := []struct {
+		name   string
+		cursor string
+		sort   PostSort
+	}{
+		{name: "not base64", cursor: "!!!", sort: PostSortCreatedAtDesc},
+		{name: "not json", cursor: base64.RawURLEncoding.EncodeToString([]byte("nope")), sort: PostSortCreatedAtDesc},
+		{name: "other sort", cursor: valid, sort: PostSortCreatedAtAsc},
+		{name: "bad id", cursor: encodePostCursor(postCursor{Sort: PostSortCreatedAtDesc, ID: "x"}), sort: PostSortCreatedAtDesc},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodePostCursor(tt.cursor, tt.sort); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("err = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestParsePostSort(t *testing.T) {
+	for _, s := range []string{"-createdAt", "createdAt", "-publishedAt", "publishedAt"} {
+		if _, err := ParsePostSort(s); err != nil {
+			t.Errorf("ParsePostSort(%q): %v", s, err)
+		}
+	}
+	if _, err := ParsePostSort("title"); err == nil {
+		t.Error("ParsePostSort(title) succeeded, want error")
+	}
+}
+
+func TestPostPageCriteria(t *testing.T) {
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	status := post.StatusPublished
+	reviewStatus := post.ReviewStatusPending
+	tag := "go"
+
+	tests := []struct {
+		name     string
+		filter   PostListFilter
+		sort     PostSort
+		cursor   *postCursor
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "first page",
+			sort:     PostSortCreatedAtDesc,
+			wantSQL:  " ORDER BY created_at DESC, id DESC LIMIT ?",
+			wantArgs: []any{21},
+		},
+		{
+			name:     "filtered page after cursor",
+			filter:   PostListFilter{Status: &status, Tag: &tag},
+			sort:     PostSortCreatedAtDesc,
+			cursor:   &postCursor{Sort: PostSortCreatedAtDesc, At: at, ID: "cursor-id"},
+			wantSQL:  " AND ((status = ? AND JSON_CONTAINS(tags, JSON_QUOTE(?))) AND ((created_at < ? OR (created_at = ? AND id < UUID_TO_BIN(?))))) ORDER BY created_at DESC, id DESC LIMIT ?",
+			wantArgs: []any{"published", "go", at, at, "cursor-id", 21},
+		},
+		{
+			name:     "review queue",
+			filter:   PostListFilter{ReviewStatus: &reviewStatus},
+			sort:     PostSortCreatedAtAsc,
+			wantSQL:  " AND (review_status = ?) ORDER BY created_at, id LIMIT ?",
+			wantArgs: []any{"pending", 21},
+		},
+		{
+			name:     "ascending by publication time",
+			sort:     PostSortPublishedAtAsc,
+			cursor:   &postCursor{Sort: PostSortPublishedAtAsc, At: at, ID: "cursor-id"},
+			wantSQL:  " AND (published_at IS NOT NULL AND ((published_at > ? OR (published_at = ? AND id > UUID_TO_BIN(?))))) ORDER BY published_at, id LIMIT ?",
+			wantArgs: []any{at, at, "cursor-id", 21},
+		},
+	}
+
+	const selectPosts = "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL"
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := postPageCriteria(postListCriteria(tt.filter, tt.sort), tt.sort, tt.cursor, 21).Build()
+			if gotSQL != selectPosts+tt.wantSQL {
+				t.Errorf("Build() gotSQL = %v, want %v", gotSQL, selectPosts+tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("Build() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPublicPostCriteria(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	id := post.NewPostID().String()
+
+	tests := []struct {
+		name     string
+		key      string
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "slug",
+			key:      "hello-world",
+			wantSQL:  " WHERE deleted_at IS NULL AND status = ? AND published_at <= ? AND (slug = ?) LIMIT ?",
+			wantArgs: []any{"published", now, "hello-world", 1},
+		},
+		{
+			name:     "id of a post without slug",
+			key:      id,
+			wantSQL:  " WHERE deleted_at IS NULL AND status = ? AND published_at <= ? AND (((slug = ? OR (id = UUID_TO_BIN(?) AND slug IS NULL)))) LIMIT ?",
+			wantArgs: []any{"published", now, id, id, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := publicPostCriteria(tt.key, now).Build()
+			if _, where, _ := strings.Cut(gotSQL, " FROM posts"); where != tt.wantSQL {
+				t.Errorf("Build() gotSQL = %v, want suffix %v", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("Build() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// PublicPostFilter restricts f to the posts readers may see at now: posts in
+// published status whose publication time has come.
+func PublicPostFilter(f PostListFilter, now time.Time) PostListFilter {
+	status := post.StatusPublished
+	f.Status = &status
+	f.PublishedUntil = &now
+	return f
+}
+
+// FindPublicPost returns the post readers see at key, which is the slug of
+// the post or, for posts without a slug, its id. Drafts, scheduled and
+// archived posts are reported as not found.
+func FindPublicPost(ctx context.Context, db *sql.DB, key string, now time.Time) (*post.Post, error) {
+	posts, err := FindPosts(ctx, db, publicPostCriteria(key, now))
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return nil, errors.New("post not found")
+	}
+
+	return posts[0], nil
+}
+
+// FindPublicPostByID returns the post with id if readers can see it at now,
+// whether or not it has a slug.
+func FindPublicPostByID(ctx context.Context, db *sql.DB, id post.PostID, now time.Time) (*post.Post, error) {
+	posts, err := FindPosts(ctx, db, postListCriteria(PublicPostFilter(PostListFilter{}, now), PostSortCreatedAtDesc).
+		Where(ExprEqual(ID, id.String())).
+		Limit(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return nil, errors.New("post not found")
+	}
+
+	return posts[0], nil
+}
+
+func publicPostCriteria(key string, now time.Time) CriteriaFindPosts {
+	bySlug := NewCriteriaFindPosts().Where(ExprEqual(Slug, key))
+	if id, err := post.ParsePostID(key); err == nil {
+		bySlug = Or(
+			bySlug,
+			NewCriteriaFindPosts().Where(ExprEqual(ID, id.String())).Where(ExprIsNull(Slug)),
+		)
+	}
+
+	return postListCriteria(PublicPostFilter(PostListFilter{}, now), PostSortCreatedAtDesc).
+		And(bySlug).
+		Limit(1)
+}
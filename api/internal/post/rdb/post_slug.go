@@ -0,0 +1,25 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// FindPostBySlug returns the post using slug in any status. slug is
+// normalized first, so "Hello World" finds the post with slug "hello-world".
+func FindPostBySlug(ctx context.Context, db *sql.DB, slug string) (*post.Post, error) {
+	posts, err := FindPosts(ctx, db, NewCriteriaFindPosts().
+		Where(ExprEqual(Slug, post.NormalizeSlug(slug))).
+		Limit(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return nil, errors.New("post not found")
+	}
+
+	return posts[0], nil
+}
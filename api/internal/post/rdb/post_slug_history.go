@@ -0,0 +1,129 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// SlugDeletePolicy decides what happens to the slugs of a deleted post.
+type SlugDeletePolicy string
+
+const (
+	// SlugDeleteRelease frees the slugs so that new posts can use them.
+	SlugDeleteRelease SlugDeletePolicy = "release"
+	// SlugDeleteTombstone keeps the slugs reserved. Requests for them are
+	// answered with 410 Gone instead of reaching an unrelated new post.
+	SlugDeleteTombstone SlugDeletePolicy = "tombstone"
+)
+
+// ParseSlugDeletePolicy parses a policy name. An empty name is
+// SlugDeleteRelease.
+func ParseSlugDeletePolicy(s string) (SlugDeletePolicy, error) {
+	switch policy := SlugDeletePolicy(s); policy {
+	case "":
+		return SlugDeleteRelease, nil
+	case SlugDeleteRelease, SlugDeleteTombstone:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown slug delete policy %q", s)
+	}
+}
+
+// SlugHistory is a slug a post used before, kept so that old links keep
+// working.
+type SlugHistory struct {
+	Slug   string
+	PostID post.PostID
+	// TombstonedAt is set when the post was deleted under SlugDeleteTombstone.
+	TombstonedAt *time.Time
+}
+
+// FindSlugHistory returns the old slug matching slug after normalization.
+func FindSlugHistory(ctx context.Context, db *sql.DB, slug string) (*SlugHistory, error) {
+	query := `SELECT slug, BIN_TO_UUID(post_id), tombstoned_at FROM post_slug_history WHERE slug = ?`
+
+	var h SlugHistory
+	var postIDStr string
+	err := db.QueryRowContext(ctx, query, post.NormalizeSlug(slug)).Scan(&h.Slug, &postIDStr, &h.TombstonedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("slug history not found")
+		}
+		return nil, err
+	}
+
+	h.PostID, err = post.ParsePostID(postIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &h, nil
+}
+
+// storedSlug returns the slug of p as stored at p.Version. A row at another
+// version is reported as having no slug, since the version-conditional UPDATE
+// that follows will not touch it.
+func storedSlug(ctx context.Context, db *sql.DB, p *post.Post) (*string, error) {
+	query := `SELECT slug FROM posts WHERE id = UUID_TO_BIN(?) AND version = ? AND deleted_at IS NULL`
+
+	var slug *string
+	err := Conn(ctx, db).QueryRowContext(ctx, query, p.ID.String(), p.Version).Scan(&slug)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return slug, err
+}
+
+// recordPreviousSlug keeps previous, the slug p was stored with, in the
+// history when p has been saved with a different one. It must run only
+// after the UPDATE affected the row.
+func recordPreviousSlug(ctx context.Context, db *sql.DB, p *post.Post, previous *string) error {
+	if previous == nil || post.EqualPtr(previous, p.Slug) {
+		return nil
+	}
+
+	query := `INSERT INTO post_slug_history (slug, post_id) VALUES (?, UUID_TO_BIN(?))
+		ON DUPLICATE KEY UPDATE post_id = VALUES(post_id), created_at = CURRENT_TIMESTAMP, tombstoned_at = NULL`
+
+	_, err := Conn(ctx, db).ExecContext(ctx, query, *previous, p.ID.String())
+	return err
+}
+
+// reclaimSlug removes the current slug of p from the history when the post
+// went back to a slug it used before, so it is not redirected to itself.
+func reclaimSlug(ctx context.Context, db *sql.DB, p *post.Post) error {
+	if p.Slug == nil {
+		return nil
+	}
+
+	query := `DELETE FROM post_slug_history WHERE slug = ? AND post_id = UUID_TO_BIN(?)`
+
+	_, err := Conn(ctx, db).ExecContext(ctx, query, *p.Slug, p.ID.String())
+	return err
+}
+
+// retireSlugs applies policy to the current and old slugs of the post with
+// id. It must run before the post row is deleted.
+func retireSlugs(ctx context.Context, db *sql.DB, id post.PostID, policy SlugDeletePolicy) error {
+	if policy != SlugDeleteTombstone {
+		_, err := Conn(ctx, db).ExecContext(ctx, `DELETE FROM post_slug_history WHERE post_id = UUID_TO_BIN(?)`, id.String())
+		return err
+	}
+
+	// 現在の slug も履歴に移してから、投稿の slug をまとめて墓標にする
+	current := `INSERT INTO post_slug_history (slug, post_id)
+		SELECT slug, id FROM posts WHERE id = UUID_TO_BIN(?) AND slug IS NOT NULL
+		ON DUPLICATE KEY UPDATE post_id = VALUES(post_id)`
+	if _, err := Conn(ctx, db).ExecContext(ctx, current, id.String()); err != nil {
+		return err
+	}
+
+	tombstone := `UPDATE post_slug_history SET tombstoned_at = CURRENT_TIMESTAMP WHERE post_id = UUID_TO_BIN(?) AND tombstoned_at IS NULL`
+	_, err := Conn(ctx, db).ExecContext(ctx, tombstone, id.String())
+	return err
+}
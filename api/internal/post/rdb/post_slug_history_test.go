@@ -0,0 +1,27 @@
+package rdb
+
+import "testing"
+
+func TestParseSlugDeletePolicy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    SlugDeletePolicy
+		wantErr bool
+	}{
+		{in: "", want: SlugDeleteRelease},
+		{in: "release", want: SlugDeleteRelease},
+		{in: "tombstone", want: SlugDeleteTombstone},
+		{in: "keep", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSlugDeletePolicy(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSlugDeletePolicy(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSlugDeletePolicy(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+const selectTrashedPostColumns = selectPostColumns + `, deleted_at, BIN_TO_UUID(deleted_by)`
+
+// scanTrashedPost reads a row selected with selectTrashedPostColumns.
+func scanTrashedPost(row rowScanner) (*post.Post, error) {
+	var deletedAt *time.Time
+	var deletedByStr *string
+
+	p, err := scanPost(row, &deletedAt, &deletedByStr)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedBy, err := parseUserIDPtr(deletedByStr)
+	if err != nil {
+		return nil, err
+	}
+	p.DeletedAt = deletedAt
+	p.DeletedBy = deletedBy
+
+	return p, nil
+}
+
+// trashSort is the order of the trash. It only tags the cursors of trash
+// pages, so that they cannot be used with the post list.
+const trashSort PostSort = "-deletedAt"
+
+// FindTrashedPage returns one page of the trash, most recently deleted first,
+// with the post ID as tie breaker.
+func (r *PostRepositoryImpl) FindTrashedPage(ctx context.Context, q repository.TrashQuery) (*repository.TrashPage, error) {
+	query := `SELECT ` + selectTrashedPostColumns + ` FROM posts WHERE deleted_at IS NOT NULL`
+	args := []any{}
+
+	if q.AuthorID != nil {
+		query += ` AND author_id = UUID_TO_BIN(?)`
+		args = append(args, q.AuthorID.String())
+	}
+	if q.Cursor != "" {
+		cursor, err := decodePostCursor(q.Cursor, trashSort)
+		if err != nil {
+			return nil, err
+		}
+		query += ` AND (deleted_at < ? OR (deleted_at = ? AND id < UUID_TO_BIN(?)))`
+		args = append(args, cursor.At, cursor.At, cursor.ID)
+	}
+
+	// 次のページがあるかを判定するために 1 件多く取得する
+	query += ` ORDER BY deleted_at DESC, id DESC LIMIT ?`
+	args = append(args, q.Limit+1)
+
+	rows, err := Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := []*post.Post{}
+	for rows.Next() {
+		p, err := scanTrashedPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	page := &repository.TrashPage{}
+	if len(posts) > q.Limit {
+		posts = posts[:q.Limit]
+		last := posts[len(posts)-1]
+		next := encodePostCursor(postCursor{Sort: trashSort, At: *last.DeletedAt, ID: last.ID.String()})
+		page.NextCursor = &next
+	}
+	page.Items = posts
+
+	return page, nil
+}
+
+func (r *PostRepositoryImpl) FindTrashedByID(ctx context.Context, id post.PostID) (*post.Post, error) {
+	query := `SELECT ` + selectTrashedPostColumns + ` FROM posts WHERE id = UUID_TO_BIN(?) AND deleted_at IS NOT NULL`
+
+	p, err := scanTrashedPost(Conn(ctx, r.db).QueryRowContext(ctx, query, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("post not found")
+		}
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Restore takes p out of the trash only if the row is still at p.Version.
+func (r *PostRepositoryImpl) Restore(ctx context.Context, p *post.Post) error {
+	query := `UPDATE posts SET deleted_at = NULL, deleted_by = NULL, version = version + 1 WHERE id = UUID_TO_BIN(?) AND version = ? AND deleted_at IS NOT NULL`
+
+	result, err := Conn(ctx, r.db).ExecContext(ctx, query, p.ID.String(), p.Version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return r.versionConflictOrNotFound(ctx, p.ID, true)
+	}
+
+	p.Version++
+
+	return nil
+}
+
+// FindPurgeableForUpdate locks up to limit posts moved to the trash at or
+// before deletedBefore, leaving out the posts in exclude. Rows locked by
+// another replica are skipped, so it must be called inside Transactor.RunInTx.
+func (r *PostRepositoryImpl) FindPurgeableForUpdate(ctx context.Context, deletedBefore time.Time, limit int, exclude []post.PostID) ([]*post.Post, error) {
+	args := []any{deletedBefore}
+	excludeCond := ""
+	if len(exclude) > 0 {
+		excludeCond = ` AND id NOT IN (` + strings.TrimSuffix(strings.Repeat("UUID_TO_BIN(?), ", len(exclude)), ", ") + `)`
+		for _, id := range exclude {
+			args = append(args, id.String())
+		}
+	}
+	args = append(args, limit)
+
+	query := `SELECT ` + selectTrashedPostColumns + ` FROM posts WHERE deleted_at <= ?` + excludeCond + ` ORDER BY deleted_at LIMIT ? FOR UPDATE SKIP LOCKED`
+
+	rows, err := Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*post.Post
+	for rows.Next() {
+		p, err := scanTrashedPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// Purge removes a post in the trash for good. Its slugs are released or
+// tombstoned according to the repository's SlugDeletePolicy, and its
+// revisions are removed with it.
+func (r *PostRepositoryImpl) Purge(ctx context.Context, id post.PostID) error {
+	query := `DELETE FROM posts WHERE id = UUID_TO_BIN(?) AND deleted_at IS NOT NULL`
+
+	if err := retireSlugs(ctx, r.db, id, r.slugPolicy); err != nil {
+		return err
+	}
+
+	result, err := Conn(ctx, r.db).ExecContext(ctx, query, id.String())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("post not found")
+	}
+
+	return nil
+}
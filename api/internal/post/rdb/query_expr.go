@@ -0,0 +1,443 @@
+package rdb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCriteria is returned by FindPosts and CountPosts when the criteria
+// refer to an unknown field or compare a field with a value of the wrong type.
+// The offending parts are left out of the generated SQL.
+var ErrInvalidCriteria = errors.New("invalid criteria")
+
+type fieldKind int
+
+const (
+	// kindUUID is a BINARY(16) column holding a UUID. It is selected with
+	// BIN_TO_UUID and compared with UUID_TO_BIN(?).
+	kindUUID fieldKind = iota + 1
+	kindString
+	kindTime
+	kindBool
+	kindInt
+	kindJSON
+)
+
+// postFields is the whitelist of columns the criteria may refer to, in the
+// order FindPosts selects them.
+var postFields = []struct {
+	field FieldFindPosts
+	kind  fieldKind
+}{
+	{ID, kindUUID},
+	{Title, kindString},
+	{Body, kindString},
+	{Status, kindString},
+	{ScheduledAt, kindTime},
+	{Category, kindString},
+	{Tags, kindJSON},
+	{FeaturedImageURL, kindString},
+	{MetaDescription, kindString},
+	{Slug, kindString},
+	{SNSAutoPost, kindBool},
+	{ExternalNotification, kindBool},
+	{EmergencyFlag, kindBool},
+	{CreatedAt, kindTime},
+	{PublishedAt, kindTime},
+	{AuthorID, kindUUID},
+	{LastEditorID, kindUUID},
+	{Version, kindInt},
+	{ReviewStatus, kindString},
+	{BodyFormat, kindString},
+	{RenderedBody, kindString},
+}
+
+func (f FieldFindPosts) kind() (fieldKind, bool) {
+	for _, pf := range postFields {
+		if pf.field == f {
+			return pf.kind, true
+		}
+	}
+	return 0, false
+}
+
+// selectExpr is the SELECT list entry of the field.
+func (f FieldFindPosts) selectExpr() string {
+	if kind, _ := f.kind(); kind == kindUUID {
+		return "BIN_TO_UUID(" + string(f) + ")"
+	}
+	return string(f)
+}
+
+// placeholder is the bind parameter of a value compared with the field.
+func (f FieldFindPosts) placeholder() string {
+	if kind, _ := f.kind(); kind == kindUUID {
+		return "UUID_TO_BIN(?)"
+	}
+	return "?"
+}
+
+func allPostFields() []FieldFindPosts {
+	fields := make([]FieldFindPosts, 0, len(postFields))
+	for _, pf := range postFields {
+		fields = append(fields, pf.field)
+	}
+	return fields
+}
+
+// Value is a Go value that can be compared with a column.
+// time.Time is compared with TIMESTAMP columns, strings with text and UUID
+// columns, bools with BOOLEAN columns and ints with INT columns.
+type Value interface {
+	~string | ~bool | ~int | time.Time
+}
+
+// normalizeValue converts named types such as post.PublicationStatus to
+// their underlying type so that they are bound as plain values.
+func normalizeValue(v any) any {
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int:
+		return int(rv.Int())
+	}
+	return v
+}
+
+// checkValue reports whether v can be compared with f.
+func checkValue(f FieldFindPosts, v any) error {
+	kind, ok := f.kind()
+	if !ok {
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidCriteria, f)
+	}
+
+	var valid bool
+	switch v.(type) {
+	case string:
+		valid = kind == kindString || kind == kindUUID
+	case bool:
+		valid = kind == kindBool
+	case time.Time:
+		valid = kind == kindTime
+	case int:
+		valid = kind == kindInt
+	}
+	if !valid {
+		return fmt.Errorf("%w: cannot compare %s with %T", ErrInvalidCriteria, f, v)
+	}
+
+	return nil
+}
+
+// invalidExpr replaces an expression that failed validation. It matches no
+// rows so that a broken filter never widens a query.
+type invalidExpr struct {
+	field string
+	err   error
+}
+
+func (e *invalidExpr) Field() string {
+	return e.field
+}
+
+func (e *invalidExpr) ValueAsAny() any {
+	return nil
+}
+
+func (e *invalidExpr) condition() (string, []any) {
+	return "1 = 0", nil
+}
+
+func (e *invalidExpr) validationErr() error {
+	return e.err
+}
+
+// exprCompare compares a field with a value using one of the operators below.
+type exprCompare struct {
+	field    FieldFindPosts
+	operator string
+	value    any
+}
+
+func (e *exprCompare) Field() string {
+	return string(e.field)
+}
+
+func (e *exprCompare) ValueAsAny() any {
+	return e.value
+}
+
+func (e *exprCompare) condition() (string, []any) {
+	return string(e.field) + " " + e.operator + " " + e.field.placeholder(), []any{e.value}
+}
+
+func compare(field FieldFindPosts, operator string, v any) Expr {
+	v = normalizeValue(v)
+	if err := checkValue(field, v); err != nil {
+		return &invalidExpr{field: string(field), err: err}
+	}
+	return &exprCompare{field: field, operator: operator, value: v}
+}
+
+// ExprEqual matches rows where field = v.
+func ExprEqual[T Value](field FieldFindPosts, v T) Expr {
+	return compare(field, "=", v)
+}
+
+// ExprNotEqual matches rows where field <> v. Like in SQL, NULL never matches.
+func ExprNotEqual[T Value](field FieldFindPosts, v T) Expr {
+	return compare(field, "<>", v)
+}
+
+// ExprGreaterThan matches rows where field > v.
+func ExprGreaterThan[T Value](field FieldFindPosts, v T) Expr {
+	return compare(field, ">", v)
+}
+
+// ExprGreaterOrEqual matches rows where field >= v.
+func ExprGreaterOrEqual[T Value](field FieldFindPosts, v T) Expr {
+	return compare(field, ">=", v)
+}
+
+// ExprLessThan matches rows where field < v.
+func ExprLessThan[T Value](field FieldFindPosts, v T) Expr {
+	return compare(field, "<", v)
+}
+
+// ExprLessOrEqual matches rows where field <= v.
+func ExprLessOrEqual[T Value](field FieldFindPosts, v T) Expr {
+	return compare(field, "<=", v)
+}
+
+type exprBetween struct {
+	field    FieldFindPosts
+	from, to any
+}
+
+func (e *exprBetween) Field() string {
+	return string(e.field)
+}
+
+func (e *exprBetween) ValueAsAny() any {
+	return []any{e.from, e.to}
+}
+
+func (e *exprBetween) condition() (string, []any) {
+	placeholder := e.field.placeholder()
+	return string(e.field) + " BETWEEN " + placeholder + " AND " + placeholder, []any{e.from, e.to}
+}
+
+// ExprBetween matches rows where from <= field <= to.
+func ExprBetween[T Value](field FieldFindPosts, from, to T) Expr {
+	f, t := normalizeValue(from), normalizeValue(to)
+	for _, v := range []any{f, t} {
+		if err := checkValue(field, v); err != nil {
+			return &invalidExpr{field: string(field), err: err}
+		}
+	}
+	return &exprBetween{field: field, from: f, to: t}
+}
+
+type exprIn struct {
+	field  FieldFindPosts
+	values []any
+	not    bool
+}
+
+func (e *exprIn) Field() string {
+	return string(e.field)
+}
+
+func (e *exprIn) ValueAsAny() any {
+	return e.values
+}
+
+func (e *exprIn) condition() (string, []any) {
+	// 空の IN は構文エラーになるため、常に偽（NOT IN なら常に真）の条件にする
+	if len(e.values) == 0 {
+		if e.not {
+			return "1 = 1", nil
+		}
+		return "1 = 0", nil
+	}
+
+	placeholders := make([]string, len(e.values))
+	for i := range e.values {
+		placeholders[i] = e.field.placeholder()
+	}
+
+	operator := " IN ("
+	if e.not {
+		operator = " NOT IN ("
+	}
+	return string(e.field) + operator + strings.Join(placeholders, ", ") + ")", e.values
+}
+
+func in[T Value](field FieldFindPosts, values []T, not bool) Expr {
+	if _, ok := field.kind(); !ok {
+		return &invalidExpr{field: string(field), err: fmt.Errorf("%w: unknown field %q", ErrInvalidCriteria, field)}
+	}
+
+	normalized := make([]any, 0, len(values))
+	for _, v := range values {
+		nv := normalizeValue(v)
+		if err := checkValue(field, nv); err != nil {
+			return &invalidExpr{field: string(field), err: err}
+		}
+		normalized = append(normalized, nv)
+	}
+
+	return &exprIn{field: field, values: normalized, not: not}
+}
+
+// ExprIn matches rows where field is one of values. No values match nothing.
+func ExprIn[T Value](field FieldFindPosts, values ...T) Expr {
+	return in(field, values, false)
+}
+
+// ExprNotIn matches rows where field is none of values.
+func ExprNotIn[T Value](field FieldFindPosts, values ...T) Expr {
+	return in(field, values, true)
+}
+
+type exprIsNull struct {
+	field FieldFindPosts
+	not   bool
+}
+
+func (e *exprIsNull) Field() string {
+	return string(e.field)
+}
+
+func (e *exprIsNull) ValueAsAny() any {
+	return nil
+}
+
+func (e *exprIsNull) condition() (string, []any) {
+	if e.not {
+		return string(e.field) + " IS NOT NULL", nil
+	}
+	return string(e.field) + " IS NULL", nil
+}
+
+func isNull(field FieldFindPosts, not bool) Expr {
+	if _, ok := field.kind(); !ok {
+		return &invalidExpr{field: string(field), err: fmt.Errorf("%w: unknown field %q", ErrInvalidCriteria, field)}
+	}
+	return &exprIsNull{field: field, not: not}
+}
+
+// ExprIsNull matches rows where field is NULL.
+func ExprIsNull(field FieldFindPosts) Expr {
+	return isNull(field, false)
+}
+
+// ExprIsNotNull matches rows where field is not NULL.
+func ExprIsNotNull(field FieldFindPosts) Expr {
+	return isNull(field, true)
+}
+
+type exprLike struct {
+	field   FieldFindPosts
+	pattern string
+}
+
+func (e *exprLike) Field() string {
+	return string(e.field)
+}
+
+func (e *exprLike) ValueAsAny() any {
+	return e.pattern
+}
+
+func (e *exprLike) condition() (string, []any) {
+	return string(e.field) + " LIKE ?", []any{e.pattern}
+}
+
+// ExprLike matches a text field against a LIKE pattern, where % matches any
+// sequence and _ any single character.
+func ExprLike(field FieldFindPosts, pattern string) Expr {
+	if kind, ok := field.kind(); !ok || kind != kindString {
+		return &invalidExpr{field: string(field), err: fmt.Errorf("%w: %q is not a text field", ErrInvalidCriteria, field)}
+	}
+	return &exprLike{field: field, pattern: pattern}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ExprHasPrefix matches a text field starting with prefix. Wildcards in
+// prefix are matched literally.
+func ExprHasPrefix(field FieldFindPosts, prefix string) Expr {
+	return ExprLike(field, likeEscaper.Replace(prefix)+"%")
+}
+
+// exprContainsTag matches posts whose JSON tags array contains the tag.
+type exprContainsTag struct {
+	value string
+}
+
+func (e *exprContainsTag) Field() string {
+	return string(Tags)
+}
+
+func (e *exprContainsTag) ValueAsAny() any {
+	return e.value
+}
+
+func (e *exprContainsTag) condition() (string, []any) {
+	return "JSON_CONTAINS(tags, JSON_QUOTE(?))", []any{e.value}
+}
+
+func ExprContainsTag(v string) Expr {
+	return &exprContainsTag{value: v}
+}
+
+type exprNot struct {
+	expr Expr
+}
+
+func (e *exprNot) Field() string {
+	return e.expr.Field()
+}
+
+func (e *exprNot) ValueAsAny() any {
+	return e.expr.ValueAsAny()
+}
+
+func (e *exprNot) condition() (string, []any) {
+	condition, args := conditionOf(e.expr)
+	return "NOT (" + condition + ")", args
+}
+
+func (e *exprNot) validationErr() error {
+	return exprErr(e.expr)
+}
+
+// ExprNot negates expr.
+func ExprNot(expr Expr) Expr {
+	return &exprNot{expr: expr}
+}
+
+// errExpr is implemented by expressions that can carry a validation error.
+type errExpr interface {
+	validationErr() error
+}
+
+func exprErr(expr Expr) error {
+	if e, ok := expr.(errExpr); ok {
+		return e.validationErr()
+	}
+	if _, ok := expr.(conditionExpr); ok {
+		return nil
+	}
+	// Eq で渡された外部の式はフィールド名がそのまま SQL になるため検証する
+	if _, ok := FieldFindPosts(expr.Field()).kind(); !ok {
+		return fmt.Errorf("%w: unknown field %q", ErrInvalidCriteria, expr.Field())
+	}
+	return nil
+}
@@ -4,18 +4,43 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/ss49919201/myblog/api/internal/post/entity/post"
 )
 
+// FieldFindPosts is a column of the posts table. Only the fields declared
+// below are accepted by the criteria.
 type FieldFindPosts string
 
 const (
-	ID                 FieldFindPosts = "id"
-	Name               FieldFindPosts = "name"
-	PublishedAtMillSec FieldFindPosts = "published_at"
+	ID                   FieldFindPosts = "id"
+	Title                FieldFindPosts = "title"
+	Body                 FieldFindPosts = "body"
+	Status               FieldFindPosts = "status"
+	ScheduledAt          FieldFindPosts = "scheduled_at"
+	Category             FieldFindPosts = "category"
+	Tags                 FieldFindPosts = "tags"
+	FeaturedImageURL     FieldFindPosts = "featured_image_url"
+	MetaDescription      FieldFindPosts = "meta_description"
+	Slug                 FieldFindPosts = "slug"
+	SNSAutoPost          FieldFindPosts = "sns_auto_post"
+	ExternalNotification FieldFindPosts = "external_notification"
+	EmergencyFlag        FieldFindPosts = "emergency_flag"
+	CreatedAt            FieldFindPosts = "created_at"
+	PublishedAt          FieldFindPosts = "published_at"
+	AuthorID             FieldFindPosts = "author_id"
+	LastEditorID         FieldFindPosts = "last_editor_id"
+	Version              FieldFindPosts = "version"
+	ReviewStatus         FieldFindPosts = "review_status"
+	BodyFormat           FieldFindPosts = "body_format"
+	RenderedBody         FieldFindPosts = "rendered_body"
+
+	// Deprecated: Use PublishedAt.
+	PublishedAtMillSec = PublishedAt
 )
 
 type exprEqID struct {
@@ -34,6 +59,10 @@ func (e *exprEqID) ValueAsAny() any {
 	return e.value
 }
 
+func (e *exprEqID) placeholder() string {
+	return ID.placeholder()
+}
+
 func ExprEqID(v string) ExprEq[string] {
 	return &exprEqID{value: v}
 }
@@ -50,14 +79,80 @@ func (e *exprEqPublishedAtMillSec) Value() int64 {
 	return e.value
 }
 
+// ValueAsAny converts the milliseconds to the time the DATETIME column is
+// compared with.
 func (e *exprEqPublishedAtMillSec) ValueAsAny() any {
-	return e.value
+	return time.UnixMilli(e.value).UTC()
 }
 
 func ExprEqPublishedAtMillSec(v int64) ExprEq[int64] {
 	return &exprEqPublishedAtMillSec{value: v}
 }
 
+type exprEqAuthorID struct {
+	value string
+}
+
+func (e *exprEqAuthorID) Field() string {
+	return "author_id"
+}
+
+func (e *exprEqAuthorID) Value() string {
+	return e.value
+}
+
+func (e *exprEqAuthorID) ValueAsAny() any {
+	return e.value
+}
+
+func (e *exprEqAuthorID) placeholder() string {
+	return AuthorID.placeholder()
+}
+
+func ExprEqAuthorID(v string) ExprEq[string] {
+	return &exprEqAuthorID{value: v}
+}
+
+type exprEqStatus struct {
+	value post.PublicationStatus
+}
+
+func (e *exprEqStatus) Field() string {
+	return "status"
+}
+
+func (e *exprEqStatus) Value() post.PublicationStatus {
+	return e.value
+}
+
+func (e *exprEqStatus) ValueAsAny() any {
+	return string(e.value)
+}
+
+func ExprEqStatus(v post.PublicationStatus) ExprEq[post.PublicationStatus] {
+	return &exprEqStatus{value: v}
+}
+
+type exprEqCategory struct {
+	value string
+}
+
+func (e *exprEqCategory) Field() string {
+	return "category"
+}
+
+func (e *exprEqCategory) Value() string {
+	return e.value
+}
+
+func (e *exprEqCategory) ValueAsAny() any {
+	return e.value
+}
+
+func ExprEqCategory(v string) ExprEq[string] {
+	return &exprEqCategory{value: v}
+}
+
 type ExprEq[T any] interface {
 	Field() string
 	Value() T
@@ -69,17 +164,83 @@ type Expr interface {
 	ValueAsAny() any
 }
 
+// placeholderExpr is implemented by expressions whose bind parameter needs a
+// conversion function, such as UUID columns stored as BINARY(16).
+// Its method is unexported so that only this package can shape the SQL.
+type placeholderExpr interface {
+	placeholder() string
+}
+
+func placeholderOf(expr Expr) string {
+	if p, ok := expr.(placeholderExpr); ok {
+		return p.placeholder()
+	}
+	return "?"
+}
+
+// conditionExpr is implemented by expressions that are not an equality, such
+// as comparisons and JSON membership. condition returns the SQL condition
+// and the arguments for its placeholders. Its method is unexported so that
+// expressions defined outside this package cannot put raw SQL into the
+// query; they are compared for equality with a whitelisted field.
+type conditionExpr interface {
+	condition() (string, []any)
+}
+
+func conditionOf(expr Expr) (string, []any) {
+	if exprErr(expr) != nil {
+		return "1 = 0", nil
+	}
+	if c, ok := expr.(conditionExpr); ok {
+		return c.condition()
+	}
+	return expr.Field() + " = " + placeholderOf(expr), []any{expr.ValueAsAny()}
+}
+
 type CriteriaFindPosts interface {
 	Eq(expr Expr) CriteriaFindPosts
+	// Where adds any expression, including comparisons such as ExprLessThan.
+	Where(expr Expr) CriteriaFindPosts
 	And(conditions ...CriteriaFindPosts) CriteriaFindPosts
 	Or(conditions ...CriteriaFindPosts) CriteriaFindPosts
+	// Not adds the negation of each condition.
+	Not(conditions ...CriteriaFindPosts) CriteriaFindPosts
+	// Select restricts the selected columns. By default every field is
+	// selected in the order of the table.
+	Select(fields ...FieldFindPosts) CriteriaFindPosts
+	// OrderBy appends a sort key. Keys are applied in the order they are added.
+	OrderBy(field FieldFindPosts, desc bool) CriteriaFindPosts
+	// Limit caps the number of rows. Zero means no limit.
+	Limit(n int) CriteriaFindPosts
+	// Offset skips the first n rows.
+	Offset(n int) CriteriaFindPosts
 	Build() (string, []any)
+	// BuildCount returns a query counting the matching rows, ignoring
+	// projection, ordering, limit and offset.
+	BuildCount() (string, []any)
+	// Fields returns the selected fields in SELECT order.
+	Fields() []FieldFindPosts
+	// Err returns the first invalid field or value the criteria were given.
+	// Build leaves those out of the SQL, so a query must not run when Err
+	// is not nil.
+	Err() error
+}
+
+type orderByFindPosts struct {
+	field FieldFindPosts
+	desc  bool
 }
 
 type criteriaFindPosts struct {
 	exprs         []Expr
 	andConditions []CriteriaFindPosts
 	orConditions  []CriteriaFindPosts
+	notConditions []CriteriaFindPosts
+	fields        []FieldFindPosts
+	orderBy       []orderByFindPosts
+	limit         int
+	offset        int
+	errs          []error
 }
 
 func NewCriteriaFindPosts() CriteriaFindPosts {
@@ -103,6 +264,11 @@ func (c *criteriaFindPosts) Eq(expr Expr) CriteriaFindPosts {
 	return c
 }
 
+func (c *criteriaFindPosts) Where(expr Expr) CriteriaFindPosts {
+	c.exprs = append(c.exprs, expr)
+	return c
+}
+
 func (c *criteriaFindPosts) And(conditions ...CriteriaFindPosts) CriteriaFindPosts {
 	c.andConditions = append(c.andConditions, conditions...)
 	return c
@@ -113,19 +279,140 @@ func (c *criteriaFindPosts) Or(conditions ...CriteriaFindPosts) CriteriaFindPost
 	return c
 }
 
+func (c *criteriaFindPosts) Not(conditions ...CriteriaFindPosts) CriteriaFindPosts {
+	c.notConditions = append(c.notConditions, conditions...)
+	return c
+}
+
+func (c *criteriaFindPosts) Select(fields ...FieldFindPosts) CriteriaFindPosts {
+	for _, f := range fields {
+		if _, ok := f.kind(); !ok {
+			c.errs = append(c.errs, fmt.Errorf("%w: unknown field %q", ErrInvalidCriteria, f))
+			continue
+		}
+		c.fields = append(c.fields, f)
+	}
+	return c
+}
+
+func (c *criteriaFindPosts) OrderBy(field FieldFindPosts, desc bool) CriteriaFindPosts {
+	if _, ok := field.kind(); !ok {
+		c.errs = append(c.errs, fmt.Errorf("%w: unknown field %q", ErrInvalidCriteria, field))
+		return c
+	}
+	c.orderBy = append(c.orderBy, orderByFindPosts{field: field, desc: desc})
+	return c
+}
+
+func (c *criteriaFindPosts) Limit(n int) CriteriaFindPosts {
+	if n < 0 {
+		c.errs = append(c.errs, fmt.Errorf("%w: negative limit %d", ErrInvalidCriteria, n))
+		return c
+	}
+	c.limit = n
+	return c
+}
+
+func (c *criteriaFindPosts) Offset(n int) CriteriaFindPosts {
+	if n < 0 {
+		c.errs = append(c.errs, fmt.Errorf("%w: negative offset %d", ErrInvalidCriteria, n))
+		return c
+	}
+	c.offset = n
+	return c
+}
+
+func (c *criteriaFindPosts) Fields() []FieldFindPosts {
+	if len(c.fields) == 0 {
+		return allPostFields()
+	}
+	return c.fields
+}
+
+func (c *criteriaFindPosts) Err() error {
+	if len(c.errs) > 0 {
+		return c.errs[0]
+	}
+	for _, expr := range c.exprs {
+		if err := exprErr(expr); err != nil {
+			return err
+		}
+	}
+	for _, conditions := range [][]CriteriaFindPosts{c.andConditions, c.orConditions, c.notConditions} {
+		for _, condition := range conditions {
+			if err := condition.Err(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (c *criteriaFindPosts) Build() (string, []any) {
 	return buildQuery(c)
 }
 
+func (c *criteriaFindPosts) BuildCount() (string, []any) {
+	return buildCountQuery(c)
+}
+
+// notTrashed leaves posts in the trash out of every query built from criteria.
+// Trashed posts are only read through the functions in post_trash.go.
+const notTrashed = "deleted_at IS NULL"
+
 func buildQuery(criteria *criteriaFindPosts) (string, []any) {
-	baseQuery := "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts"
+	fields := criteria.Fields()
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		columns = append(columns, f.selectExpr())
+	}
+
+	query := "SELECT " + strings.Join(columns, ", ") + " FROM posts WHERE " + notTrashed
+	args := []any{}
+
+	whereClause, whereArgs := buildWhereClause(criteria)
+	if whereClause != "" {
+		query += " AND " + whereClause
+		args = append(args, whereArgs...)
+	}
+
+	if len(criteria.orderBy) > 0 {
+		keys := make([]string, 0, len(criteria.orderBy))
+		for _, o := range criteria.orderBy {
+			key := string(o.field)
+			if o.desc {
+				key += " DESC"
+			}
+			keys = append(keys, key)
+		}
+		query += " ORDER BY " + strings.Join(keys, ", ")
+	}
+
+	switch {
+	case criteria.limit > 0 && criteria.offset > 0:
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, criteria.limit, criteria.offset)
+	case criteria.limit > 0:
+		query += " LIMIT ?"
+		args = append(args, criteria.limit)
+	case criteria.offset > 0:
+		// MySQL は LIMIT なしの OFFSET を受け付けないため上限値を指定する
+		query += " LIMIT 18446744073709551615 OFFSET ?"
+		args = append(args, criteria.offset)
+	}
+
+	return query, args
+}
+
+func buildCountQuery(criteria *criteriaFindPosts) (string, []any) {
+	query := "SELECT COUNT(*) FROM posts WHERE " + notTrashed
 
 	whereClause, args := buildWhereClause(criteria)
 	if whereClause == "" {
-		return baseQuery, []any{}
+		return query, []any{}
 	}
 
-	return baseQuery + " WHERE " + whereClause, args
+	return query + " AND " + whereClause, append([]any{}, args...)
 }
 
 func buildWhereClause(criteria *criteriaFindPosts) (string, []any) {
@@ -134,8 +421,9 @@ func buildWhereClause(criteria *criteriaFindPosts) (string, []any) {
 
 	// Handle simple expressions
 	for _, expr := range criteria.exprs {
-		whereParts = append(whereParts, expr.Field()+" = ?")
-		args = append(args, expr.ValueAsAny())
+		condition, conditionArgs := conditionOf(expr)
+		whereParts = append(whereParts, condition)
+		args = append(args, conditionArgs...)
 	}
 
 	// Handle AND conditions
@@ -156,6 +444,17 @@ func buildWhereClause(criteria *criteriaFindPosts) (string, []any) {
 		}
 	}
 
+	// Handle NOT conditions
+	for _, condition := range criteria.notConditions {
+		if cond, ok := condition.(*criteriaFindPosts); ok {
+			part, condArgs := buildWhereClause(cond)
+			if part != "" {
+				whereParts = append(whereParts, "NOT ("+part+")")
+				args = append(args, condArgs...)
+			}
+		}
+	}
+
 	if len(whereParts) == 0 {
 		return "", []any{}
 	}
@@ -246,7 +545,14 @@ func buildOrConditions(conditions []CriteriaFindPosts) (string, []any) {
 	return query, args
 }
 
+// FindPosts returns the posts matching criteria. When the criteria select a
+// subset of fields, only those fields of the returned posts are set and the
+// posts are not validated.
 func FindPosts(ctx context.Context, db *sql.DB, criteria CriteriaFindPosts) ([]*post.Post, error) {
+	if err := criteria.Err(); err != nil {
+		return nil, err
+	}
+
 	query, args := criteria.Build()
 
 	rows, err := db.QueryContext(ctx, query, args...)
@@ -257,36 +563,26 @@ func FindPosts(ctx context.Context, db *sql.DB, criteria CriteriaFindPosts) ([]*
 
 	posts := make([]*post.Post, 0)
 
-	for rows.Next() {
-		var id, title, body, status, category string
-		var scheduledAt, publishedAt *time.Time
-		var tagsJSON, featuredImageURL, metaDescription, slug *string
-		var snsAutoPost, externalNotification, emergencyFlag bool
-		var createdAt time.Time
-
-		err := rows.Scan(&id, &title, &body, &status, &scheduledAt, &category, &tagsJSON, &featuredImageURL, &metaDescription, &slug, &snsAutoPost, &externalNotification, &emergencyFlag, &createdAt, &publishedAt)
-		if err != nil {
-			return nil, err
+	if fields := criteria.Fields(); !slices.Equal(fields, allPostFields()) {
+		for rows.Next() {
+			p, err := scanProjectedPost(rows, fields)
+			if err != nil {
+				return nil, err
+			}
+			posts = append(posts, p)
 		}
-
-		postID, err := post.ParsePostID(id)
-		if err != nil {
+		if err := rows.Err(); err != nil {
 			return nil, err
 		}
+		return posts, nil
+	}
 
-		// tagsをパース
-		var tags []string
-		if tagsJSON != nil {
-			if err := json.Unmarshal([]byte(*tagsJSON), &tags); err != nil {
-				tags = []string{}
-			}
-		}
-
-		p, err := post.Reconstruct(postID, title, body, post.PublicationStatus(status), scheduledAt, category, tags, featuredImageURL, metaDescription, slug, snsAutoPost, externalNotification, emergencyFlag, createdAt, publishedAt)
+	// 全フィールドの選択は selectPostColumns と同じ並びになる
+	for rows.Next() {
+		p, err := scanPost(rows)
 		if err != nil {
 			return nil, err
 		}
-
 		posts = append(posts, p)
 	}
 
@@ -297,54 +593,110 @@ func FindPosts(ctx context.Context, db *sql.DB, criteria CriteriaFindPosts) ([]*
 	return posts, nil
 }
 
-// FindAllPosts retrieves all posts ordered by created_at DESC
-func FindAllPosts(ctx context.Context, db *sql.DB) ([]*post.Post, error) {
-	query := "SELECT BIN_TO_UUID(id) as id, title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts ORDER BY created_at DESC"
+// scanProjectedPost scans a row selected with CriteriaFindPosts.Select into
+// the matching fields of a post.
+func scanProjectedPost(row rowScanner, fields []FieldFindPosts) (*post.Post, error) {
+	var p post.Post
+	var id, category, tagsJSON, authorID, lastEditorID, renderedBody *string
+	var status, reviewStatus, bodyFormat string
+
+	dest := make([]any, 0, len(fields))
+	for _, f := range fields {
+		switch f {
+		case ID:
+			dest = append(dest, &id)
+		case Title:
+			dest = append(dest, &p.Title)
+		case Body:
+			dest = append(dest, &p.Body)
+		case Status:
+			dest = append(dest, &status)
+		case ScheduledAt:
+			dest = append(dest, &p.ScheduledAt)
+		case Category:
+			dest = append(dest, &category)
+		case Tags:
+			dest = append(dest, &tagsJSON)
+		case FeaturedImageURL:
+			dest = append(dest, &p.FeaturedImageURL)
+		case MetaDescription:
+			dest = append(dest, &p.MetaDescription)
+		case Slug:
+			dest = append(dest, &p.Slug)
+		case SNSAutoPost:
+			dest = append(dest, &p.SNSAutoPost)
+		case ExternalNotification:
+			dest = append(dest, &p.ExternalNotification)
+		case EmergencyFlag:
+			dest = append(dest, &p.EmergencyFlag)
+		case CreatedAt:
+			dest = append(dest, &p.CreatedAt)
+		case PublishedAt:
+			dest = append(dest, &p.PublishedAt)
+		case AuthorID:
+			dest = append(dest, &authorID)
+		case LastEditorID:
+			dest = append(dest, &lastEditorID)
+		case Version:
+			dest = append(dest, &p.Version)
+		case ReviewStatus:
+			dest = append(dest, &reviewStatus)
+		case BodyFormat:
+			dest = append(dest, &bodyFormat)
+		case RenderedBody:
+			dest = append(dest, &renderedBody)
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidCriteria, f)
+		}
+	}
 
-	rows, err := db.QueryContext(ctx, query)
-	if err != nil {
+	if err := row.Scan(dest...); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	posts := make([]*post.Post, 0)
-
-	for rows.Next() {
-		var id, title, body, status, category string
-		var scheduledAt, publishedAt *time.Time
-		var tagsJSON, featuredImageURL, metaDescription, slug *string
-		var snsAutoPost, externalNotification, emergencyFlag bool
-		var createdAt time.Time
-
-		err := rows.Scan(&id, &title, &body, &status, &scheduledAt, &category, &tagsJSON, &featuredImageURL, &metaDescription, &slug, &snsAutoPost, &externalNotification, &emergencyFlag, &createdAt, &publishedAt)
+	if id != nil {
+		postID, err := post.ParsePostID(*id)
 		if err != nil {
 			return nil, err
 		}
-
-		postID, err := post.ParsePostID(id)
-		if err != nil {
-			return nil, err
+		p.ID = postID
+	}
+	p.Status = post.PublicationStatus(status)
+	p.ReviewStatus = post.ReviewStatus(reviewStatus)
+	p.BodyFormat = post.BodyFormat(bodyFormat)
+	p.RenderedBody = stringValue(renderedBody)
+	if category != nil {
+		p.Category = *category
+	}
+	if tagsJSON != nil {
+		if err := json.Unmarshal([]byte(*tagsJSON), &p.Tags); err != nil {
+			p.Tags = []string{}
 		}
+	}
 
-		// tagsをパース
-		var tags []string
-		if tagsJSON != nil {
-			if err := json.Unmarshal([]byte(*tagsJSON), &tags); err != nil {
-				tags = []string{}
-			}
-		}
+	var err error
+	if p.AuthorID, err = parseUserIDPtr(authorID); err != nil {
+		return nil, err
+	}
+	if p.LastEditorID, err = parseUserIDPtr(lastEditorID); err != nil {
+		return nil, err
+	}
 
-		p, err := post.Reconstruct(postID, title, body, post.PublicationStatus(status), scheduledAt, category, tags, featuredImageURL, metaDescription, slug, snsAutoPost, externalNotification, emergencyFlag, createdAt, publishedAt)
-		if err != nil {
-			return nil, err
-		}
+	return &p, nil
+}
 
-		posts = append(posts, p)
+// CountPosts returns the number of posts matching criteria.
+func CountPosts(ctx context.Context, db *sql.DB, criteria CriteriaFindPosts) (int, error) {
+	if err := criteria.Err(); err != nil {
+		return 0, err
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, err
+	query, args := criteria.BuildCount()
+
+	var count int
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
 	}
 
-	return posts, nil
+	return count, nil
 }
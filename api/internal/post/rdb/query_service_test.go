@@ -1,11 +1,18 @@
 package rdb
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
 )
 
 func TestCriteriaFindPosts_Build(t *testing.T) {
+	publishedAt := time.UnixMilli(1640995200000).UTC()
+
 	tests := []struct {
 		name     string
 		criteria CriteriaFindPosts
@@ -15,28 +22,28 @@ func TestCriteriaFindPosts_Build(t *testing.T) {
 		{
 			name:     "no criteria",
 			criteria: NewCriteriaFindPosts(),
-			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts",
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL",
 			wantArgs: []any{},
 		},
 		{
 			name:     "single string equality",
 			criteria: NewCriteriaFindPosts().Eq(ExprEqID("test-id")),
-			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts WHERE id = ?",
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND id = UUID_TO_BIN(?)",
 			wantArgs: []any{"test-id"},
 		},
 		{
 			name:     "single int64 equality",
 			criteria: NewCriteriaFindPosts().Eq(ExprEqPublishedAtMillSec(1640995200000)),
-			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts WHERE published_at = ?",
-			wantArgs: []any{int64(1640995200000)},
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND published_at = ?",
+			wantArgs: []any{publishedAt},
 		},
 		{
 			name: "multiple criteria",
 			criteria: NewCriteriaFindPosts().
 				Eq(ExprEqID("test-id")).
 				Eq(ExprEqPublishedAtMillSec(1640995200000)),
-			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts WHERE id = ? AND published_at = ?",
-			wantArgs: []any{"test-id", int64(1640995200000)},
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND id = UUID_TO_BIN(?) AND published_at = ?",
+			wantArgs: []any{"test-id", publishedAt},
 		},
 		{
 			name: "nested AND condition",
@@ -45,8 +52,8 @@ func TestCriteriaFindPosts_Build(t *testing.T) {
 					NewCriteriaFindPosts().Eq(ExprEqID("test-id")),
 					NewCriteriaFindPosts().Eq(ExprEqPublishedAtMillSec(1640995200000)),
 				),
-			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts WHERE (id = ? AND published_at = ?)",
-			wantArgs: []any{"test-id", int64(1640995200000)},
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND (id = UUID_TO_BIN(?) AND published_at = ?)",
+			wantArgs: []any{"test-id", publishedAt},
 		},
 		{
 			name: "nested OR condition",
@@ -55,7 +62,7 @@ func TestCriteriaFindPosts_Build(t *testing.T) {
 					NewCriteriaFindPosts().Eq(ExprEqID("test-id-1")),
 					NewCriteriaFindPosts().Eq(ExprEqID("test-id-2")),
 				),
-			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts WHERE (id = ? OR id = ?)",
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND (id = UUID_TO_BIN(?) OR id = UUID_TO_BIN(?))",
 			wantArgs: []any{"test-id-1", "test-id-2"},
 		},
 		{
@@ -66,8 +73,8 @@ func TestCriteriaFindPosts_Build(t *testing.T) {
 					NewCriteriaFindPosts().Eq(ExprEqID("test-id-1")),
 					NewCriteriaFindPosts().Eq(ExprEqID("test-id-2")),
 				),
-			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts WHERE published_at = ? AND (id = ? OR id = ?)",
-			wantArgs: []any{int64(1640995200000), "test-id-1", "test-id-2"},
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND published_at = ? AND (id = UUID_TO_BIN(?) OR id = UUID_TO_BIN(?))",
+			wantArgs: []any{publishedAt, "test-id-1", "test-id-2"},
 		},
 		{
 			name: "complex nested conditions",
@@ -79,8 +86,62 @@ func TestCriteriaFindPosts_Build(t *testing.T) {
 					),
 					NewCriteriaFindPosts().Eq(ExprEqPublishedAtMillSec(1640995200000)),
 				),
-			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at FROM posts WHERE (((id = ? OR id = ?)) AND published_at = ?)",
-			wantArgs: []any{"id-1", "id-2", int64(1640995200000)},
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND (((id = UUID_TO_BIN(?) OR id = UUID_TO_BIN(?))) AND published_at = ?)",
+			wantArgs: []any{"id-1", "id-2", publishedAt},
+		},
+		{
+			name: "author equality",
+			criteria: NewCriteriaFindPosts().
+				Eq(ExprEqAuthorID("0f000000-0000-4000-8000-000000000002")),
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND author_id = UUID_TO_BIN(?)",
+			wantArgs: []any{"0f000000-0000-4000-8000-000000000002"},
+		},
+		{
+			name: "author drafts",
+			criteria: NewCriteriaFindPosts().
+				Eq(ExprEqAuthorID("0f000000-0000-4000-8000-000000000002")).
+				Eq(ExprEqStatus(post.StatusDraft)),
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND author_id = UUID_TO_BIN(?) AND status = ?",
+			wantArgs: []any{"0f000000-0000-4000-8000-000000000002", "draft"},
+		},
+		{
+			name: "category and tag",
+			criteria: NewCriteriaFindPosts().
+				Eq(ExprEqCategory("tech")).
+				Where(ExprContainsTag("go")),
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND category = ? AND JSON_CONTAINS(tags, JSON_QUOTE(?))",
+			wantArgs: []any{"tech", "go"},
+		},
+		{
+			name: "date ranges",
+			criteria: NewCriteriaFindPosts().
+				Where(ExprGreaterOrEqual(CreatedAt, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))).
+				Where(ExprLessThan(CreatedAt, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))).
+				Where(ExprGreaterOrEqual(PublishedAt, time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC))).
+				Where(ExprLessThan(PublishedAt, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))),
+			wantSQL: "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND created_at >= ? AND created_at < ? AND published_at >= ? AND published_at < ?",
+			wantArgs: []any{
+				time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "order and limit",
+			criteria: NewCriteriaFindPosts().
+				Eq(ExprEqStatus(post.StatusPublished)).
+				OrderBy(CreatedAt, true).
+				OrderBy(ID, false).
+				Limit(21),
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL AND status = ? ORDER BY created_at DESC, id LIMIT ?",
+			wantArgs: []any{"published", 21},
+		},
+		{
+			name:     "limit without conditions",
+			criteria: NewCriteriaFindPosts().Limit(10),
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, body, status, scheduled_at, category, tags, featured_image_url, meta_description, slug, sns_auto_post, external_notification, emergency_flag, created_at, published_at, BIN_TO_UUID(author_id), BIN_TO_UUID(last_editor_id), version, review_status, body_format, rendered_body FROM posts WHERE deleted_at IS NULL LIMIT ?",
+			wantArgs: []any{10},
 		},
 	}
 
@@ -97,6 +158,51 @@ func TestCriteriaFindPosts_Build(t *testing.T) {
 	}
 }
 
+// FindPosts は全フィールドの行を scanPost で読むため、列の並びが一致していること
+func TestCriteriaFindPosts_BuildSelectsPostColumns(t *testing.T) {
+	query, _ := NewCriteriaFindPosts().Build()
+	if want := "SELECT " + selectPostColumns + " FROM posts"; !strings.HasPrefix(query, want) {
+		t.Errorf("Build() = %q, want it to start with %q", query, want)
+	}
+}
+
+func TestCriteriaFindPosts_BuildCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria CriteriaFindPosts
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "no criteria",
+			criteria: NewCriteriaFindPosts(),
+			wantSQL:  "SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL",
+			wantArgs: []any{},
+		},
+		{
+			name: "ignores order and limit",
+			criteria: NewCriteriaFindPosts().
+				Eq(ExprEqStatus(post.StatusDraft)).
+				OrderBy(CreatedAt, true).
+				Limit(20),
+			wantSQL:  "SELECT COUNT(*) FROM posts WHERE deleted_at IS NULL AND status = ?",
+			wantArgs: []any{"draft"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := tt.criteria.BuildCount()
+			if gotSQL != tt.wantSQL {
+				t.Errorf("BuildCount() gotSQL = %v, want %v", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("BuildCount() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
 func TestExprEqID(t *testing.T) {
 	expr := ExprEqID("test-value")
 
@@ -124,7 +230,236 @@ func TestExprEqPublishedAtMillSec(t *testing.T) {
 		t.Errorf("ExprEqPublishedAtMillSec.Value() = %v, want %v", expr.Value(), int64(1640995200000))
 	}
 
-	if expr.ValueAsAny() != int64(1640995200000) {
-		t.Errorf("ExprEqPublishedAtMillSec.ValueAsAny() = %v, want %v", expr.ValueAsAny(), int64(1640995200000))
+	if want := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC); expr.ValueAsAny() != want {
+		t.Errorf("ExprEqPublishedAtMillSec.ValueAsAny() = %v, want %v", expr.ValueAsAny(), want)
+	}
+}
+
+func TestCriteriaFindPosts_BuildExpressions(t *testing.T) {
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     Expr
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"equal", ExprEqual(Title, "hello"), "title = ?", []any{"hello"}},
+		{"equal uuid", ExprEqual(AuthorID, "0f000000-0000-4000-8000-000000000002"), "author_id = UUID_TO_BIN(?)", []any{"0f000000-0000-4000-8000-000000000002"}},
+		{"equal named string type", ExprEqual(Status, post.StatusDraft), "status = ?", []any{"draft"}},
+		{"equal bool", ExprEqual(EmergencyFlag, true), "emergency_flag = ?", []any{true}},
+		{"equal int", ExprEqual(Version, 3), "version = ?", []any{3}},
+		{"not equal", ExprNotEqual(Status, post.StatusArchived), "status <> ?", []any{"archived"}},
+		{"greater than", ExprGreaterThan(ScheduledAt, at), "scheduled_at > ?", []any{at}},
+		{"greater or equal", ExprGreaterOrEqual(CreatedAt, at), "created_at >= ?", []any{at}},
+		{"less than", ExprLessThan(ID, "0f000000-0000-4000-8000-000000000001"), "id < UUID_TO_BIN(?)", []any{"0f000000-0000-4000-8000-000000000001"}},
+		{"less or equal", ExprLessOrEqual(PublishedAt, at), "published_at <= ?", []any{at}},
+		{"between", ExprBetween(PublishedAt, at, later), "published_at BETWEEN ? AND ?", []any{at, later}},
+		{"in", ExprIn(Status, post.StatusDraft, post.StatusScheduled), "status IN (?, ?)", []any{"draft", "scheduled"}},
+		{"in uuid", ExprIn(LastEditorID, "a", "b"), "last_editor_id IN (UUID_TO_BIN(?), UUID_TO_BIN(?))", []any{"a", "b"}},
+		{"in empty", ExprIn[string](Category), "1 = 0", []any{}},
+		{"not in", ExprNotIn(Category, "news"), "category NOT IN (?)", []any{"news"}},
+		{"not in empty", ExprNotIn[string](Category), "1 = 1", []any{}},
+		{"is null", ExprIsNull(Slug), "slug IS NULL", []any{}},
+		{"is not null", ExprIsNotNull(PublishedAt), "published_at IS NOT NULL", []any{}},
+		{"like", ExprLike(Title, "%go_"), "title LIKE ?", []any{"%go_"}},
+		{"has prefix escapes wildcards", ExprHasPrefix(Slug, `100%_a\b`), "slug LIKE ?", []any{`100\%\_a\\b%`}},
+		{"contains tag", ExprContainsTag("go"), "JSON_CONTAINS(tags, JSON_QUOTE(?))", []any{"go"}},
+		{"not", ExprNot(ExprIn(Status, post.StatusDraft)), "NOT (status IN (?))", []any{"draft"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria := NewCriteriaFindPosts().Select(ID).Where(tt.expr)
+
+			gotSQL, gotArgs := criteria.Build()
+			if want := "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND " + tt.wantSQL; gotSQL != want {
+				t.Errorf("Build() gotSQL = %v, want %v", gotSQL, want)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("Build() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+			}
+			if err := criteria.Err(); err != nil {
+				t.Errorf("Err() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestCriteriaFindPosts_BuildClauses(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria CriteriaFindPosts
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "projection",
+			criteria: NewCriteriaFindPosts().Select(ID, Title, AuthorID),
+			wantSQL:  "SELECT BIN_TO_UUID(id), title, BIN_TO_UUID(author_id) FROM posts WHERE deleted_at IS NULL",
+			wantArgs: []any{},
+		},
+		{
+			name: "not conditions",
+			criteria: NewCriteriaFindPosts().
+				Select(ID).
+				Where(ExprEqual(Category, "tech")).
+				Not(
+					NewCriteriaFindPosts().Where(ExprEqual(Status, post.StatusArchived)),
+					NewCriteriaFindPosts().Where(ExprContainsTag("draft")).Where(ExprIsNull(PublishedAt)),
+				),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND category = ? AND NOT (status = ?) AND NOT (JSON_CONTAINS(tags, JSON_QUOTE(?)) AND published_at IS NULL)",
+			wantArgs: []any{"tech", "archived", "draft"},
+		},
+		{
+			name:     "limit and offset",
+			criteria: NewCriteriaFindPosts().Select(ID).OrderBy(Title, false).Limit(10).Offset(20),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL ORDER BY title LIMIT ? OFFSET ?",
+			wantArgs: []any{10, 20},
+		},
+		{
+			name:     "offset without limit",
+			criteria: NewCriteriaFindPosts().Select(ID).Offset(5),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL LIMIT 18446744073709551615 OFFSET ?",
+			wantArgs: []any{5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := tt.criteria.Build()
+			if gotSQL != tt.wantSQL {
+				t.Errorf("Build() gotSQL = %v, want %v", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("Build() gotArgs = %v, want %v", gotArgs, tt.wantArgs)
+			}
+			if err := tt.criteria.Err(); err != nil {
+				t.Errorf("Err() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+type exprEqUnknown struct{}
+
+func (e *exprEqUnknown) Field() string   { return "name" }
+func (e *exprEqUnknown) ValueAsAny() any { return "x" }
+
+func TestCriteriaFindPosts_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria CriteriaFindPosts
+		wantSQL  string
+	}{
+		{
+			name:     "unknown field in equality",
+			criteria: NewCriteriaFindPosts().Select(ID).Eq(&exprEqUnknown{}),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0",
+		},
+		{
+			name:     "unknown field in comparison",
+			criteria: NewCriteriaFindPosts().Select(ID).Where(ExprEqual(FieldFindPosts("name"), "x")),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0",
+		},
+		{
+			name:     "time compared with text",
+			criteria: NewCriteriaFindPosts().Select(ID).Where(ExprGreaterThan(Title, time.Now())),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0",
+		},
+		{
+			name:     "string compared with bool",
+			criteria: NewCriteriaFindPosts().Select(ID).Where(ExprEqual(SNSAutoPost, "true")),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0",
+		},
+		{
+			name:     "int compared with text",
+			criteria: NewCriteriaFindPosts().Select(ID).Where(ExprEqual(Title, 1)),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0",
+		},
+		{
+			name:     "string compared with json",
+			criteria: NewCriteriaFindPosts().Select(ID).Where(ExprEqual(Tags, "go")),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0",
+		},
+		{
+			name:     "invalid value in IN",
+			criteria: NewCriteriaFindPosts().Select(ID).Where(ExprIn(CreatedAt, "yesterday")),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0",
+		},
+		{
+			name:     "like on non text field",
+			criteria: NewCriteriaFindPosts().Select(ID).Where(ExprLike(ID, "0f%")),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0",
+		},
+		{
+			name:     "negated invalid expression still matches nothing",
+			criteria: NewCriteriaFindPosts().Select(ID).Where(ExprNot(ExprIsNull(FieldFindPosts("name")))),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0",
+		},
+		{
+			name:     "invalid nested condition",
+			criteria: NewCriteriaFindPosts().Select(ID).Or(NewCriteriaFindPosts().Where(ExprEqual(FieldFindPosts("name"), "x"))),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND (1 = 0)",
+		},
+		{
+			name:     "unknown select field",
+			criteria: NewCriteriaFindPosts().Select(ID, FieldFindPosts("name")),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL",
+		},
+		{
+			name:     "unknown order field",
+			criteria: NewCriteriaFindPosts().Select(ID).OrderBy(FieldFindPosts("name; DROP TABLE posts"), false),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL",
+		},
+		{
+			name:     "negative limit",
+			criteria: NewCriteriaFindPosts().Select(ID).Limit(-1),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL",
+		},
+		{
+			name:     "negative offset",
+			criteria: NewCriteriaFindPosts().Select(ID).Offset(-1),
+			wantSQL:  "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.criteria.Err(); !errors.Is(err, ErrInvalidCriteria) {
+				t.Errorf("Err() = %v, want %v", err, ErrInvalidCriteria)
+			}
+			if gotSQL, _ := tt.criteria.Build(); gotSQL != tt.wantSQL {
+				t.Errorf("Build() gotSQL = %v, want %v", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+// exprRawSQL mimics an expression defined outside the package that tries to
+// shape the SQL through methods named like the package's own.
+type exprRawSQL struct {
+	field string
+}
+
+func (e *exprRawSQL) Field() string              { return e.field }
+func (e *exprRawSQL) ValueAsAny() any            { return "x" }
+func (e *exprRawSQL) Condition() (string, []any) { return "1 = 1", nil }
+func (e *exprRawSQL) Placeholder() string        { return "? OR 1 = 1" }
+func (e *exprRawSQL) Err() error                 { return nil }
+
+func TestCriteriaFindPosts_ForeignExpressionsAreEqualities(t *testing.T) {
+	criteria := NewCriteriaFindPosts().Select(ID).Where(&exprRawSQL{field: "status"})
+	if gotSQL, _ := criteria.Build(); gotSQL != "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND status = ?" {
+		t.Errorf("Build() gotSQL = %v", gotSQL)
+	}
+
+	criteria = NewCriteriaFindPosts().Select(ID).Where(&exprRawSQL{field: "1 = 1 OR name"})
+	if err := criteria.Err(); !errors.Is(err, ErrInvalidCriteria) {
+		t.Errorf("Err() = %v, want %v", err, ErrInvalidCriteria)
+	}
+	if gotSQL, _ := criteria.Build(); gotSQL != "SELECT BIN_TO_UUID(id) FROM posts WHERE deleted_at IS NULL AND 1 = 0" {
+		t.Errorf("Build() gotSQL = %v", gotSQL)
 	}
 }
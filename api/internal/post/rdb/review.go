@@ -0,0 +1,87 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type ReviewRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewReviewRepository(db *sql.DB) repository.ReviewRepository {
+	return &ReviewRepositoryImpl{db: db}
+}
+
+func (r *ReviewRepositoryImpl) Append(ctx context.Context, review *post.Review) error {
+	reasons, err := json.Marshal(review.Reasons)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO post_reviews (post_id, action, actor_id, comment, reasons, created_at) VALUES (UUID_TO_BIN(?), ?, UUID_TO_BIN(?), ?, ?, ?)`
+
+	_, err = Conn(ctx, r.db).ExecContext(ctx, query,
+		review.PostID.String(),
+		review.Action,
+		userIDArg(review.ActorID),
+		review.Comment,
+		reasons,
+		review.CreatedAt,
+	)
+	return err
+}
+
+func (r *ReviewRepositoryImpl) FindByPost(ctx context.Context, postID post.PostID) ([]*post.Review, error) {
+	query := `SELECT BIN_TO_UUID(post_id), action, BIN_TO_UUID(actor_id), comment, reasons, created_at FROM post_reviews WHERE post_id = UUID_TO_BIN(?) ORDER BY created_at DESC, id DESC`
+
+	rows, err := Conn(ctx, r.db).QueryContext(ctx, query, postID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []*post.Review{}
+	for rows.Next() {
+		var postIDStr, action string
+		var actorIDStr, comment *string
+		var reasonsJSON []byte
+		var createdAt time.Time
+
+		if err := rows.Scan(&postIDStr, &action, &actorIDStr, &comment, &reasonsJSON, &createdAt); err != nil {
+			return nil, err
+		}
+
+		reviewPostID, err := post.ParsePostID(postIDStr)
+		if err != nil {
+			return nil, err
+		}
+		actorID, err := parseUserIDPtr(actorIDStr)
+		if err != nil {
+			return nil, err
+		}
+
+		reasons := []post.ReviewReason{}
+		if reasonsJSON != nil {
+			if err := json.Unmarshal(reasonsJSON, &reasons); err != nil || reasons == nil {
+				reasons = []post.ReviewReason{}
+			}
+		}
+
+		reviews = append(reviews, &post.Review{
+			PostID:    reviewPostID,
+			Action:    post.ReviewAction(action),
+			ActorID:   actorID,
+			Comment:   comment,
+			Reasons:   reasons,
+			CreatedAt: createdAt,
+		})
+	}
+
+	return reviews, rows.Err()
+}
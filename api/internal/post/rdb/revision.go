@@ -0,0 +1,152 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type RevisionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewRevisionRepository(db *sql.DB) repository.RevisionRepository {
+	return &RevisionRepositoryImpl{db: db}
+}
+
+// Append numbers rev after the latest revision of its post. It must be
+// called inside Transactor.RunInTx so that the number stays locked until the
+// revision is inserted.
+func (r *RevisionRepositoryImpl) Append(ctx context.Context, rev *post.Revision) error {
+	next := `SELECT COALESCE(MAX(number), 0) + 1 FROM post_revisions WHERE post_id = UUID_TO_BIN(?) FOR UPDATE`
+
+	var number int
+	if err := Conn(ctx, r.db).QueryRowContext(ctx, next, rev.PostID.String()).Scan(&number); err != nil {
+		return err
+	}
+
+	tags, err := json.Marshal(rev.Tags)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO post_revisions (post_id, number, title, body, body_format, category, tags, featured_image_url, meta_description, slug, editor_id, restored_from, created_at) VALUES (UUID_TO_BIN(?), ?, ?, ?, ?, ?, ?, ?, ?, ?, UUID_TO_BIN(?), ?, ?)`
+
+	_, err = Conn(ctx, r.db).ExecContext(ctx, query,
+		rev.PostID.String(),
+		number,
+		rev.Title,
+		rev.Body,
+		rev.BodyFormat,
+		rev.Category,
+		tags,
+		rev.FeaturedImageURL,
+		rev.MetaDescription,
+		rev.Slug,
+		userIDArg(rev.EditorID),
+		rev.RestoredFrom,
+		rev.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	rev.Number = number
+	return nil
+}
+
+func (r *RevisionRepositoryImpl) FindByNumber(ctx context.Context, postID post.PostID, number int) (*post.Revision, error) {
+	return findRevision(ctx, Conn(ctx, r.db), postID, number)
+}
+
+const selectRevisionColumns = `BIN_TO_UUID(post_id), number, title, body, body_format, category, tags, featured_image_url, meta_description, slug, BIN_TO_UUID(editor_id), restored_from, created_at`
+
+func (r *RevisionRepositoryImpl) FindByPost(ctx context.Context, postID post.PostID) ([]*post.Revision, error) {
+	query := `SELECT ` + selectRevisionColumns + ` FROM post_revisions WHERE post_id = UUID_TO_BIN(?) ORDER BY number DESC`
+
+	rows, err := Conn(ctx, r.db).QueryContext(ctx, query, postID.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*post.Revision{}
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+
+	return revisions, rows.Err()
+}
+
+func findRevision(ctx context.Context, conn Executor, postID post.PostID, number int) (*post.Revision, error) {
+	query := `SELECT ` + selectRevisionColumns + ` FROM post_revisions WHERE post_id = UUID_TO_BIN(?) AND number = ?`
+
+	rev, err := scanRevision(conn.QueryRowContext(ctx, query, postID.String(), number))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &post.ErrRevisionNotFound{PostID: postID, Number: number}
+		}
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+func scanRevision(row rowScanner) (*post.Revision, error) {
+	var postIDStr, title, body, bodyFormat string
+	var category, featuredImageURL, metaDescription, slug, editorIDStr *string
+	var tagsJSON []byte
+	var number int
+	var restoredFrom *int
+	var createdAt time.Time
+
+	err := row.Scan(&postIDStr, &number, &title, &body, &bodyFormat, &category, &tagsJSON, &featuredImageURL, &metaDescription, &slug, &editorIDStr, &restoredFrom, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	postID, err := post.ParsePostID(postIDStr)
+	if err != nil {
+		return nil, err
+	}
+	editorID, err := parseUserIDPtr(editorIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	if tagsJSON != nil {
+		if err := json.Unmarshal(tagsJSON, &tags); err != nil || tags == nil {
+			tags = []string{}
+		}
+	}
+
+	rev := &post.Revision{
+		PostID:           postID,
+		Number:           number,
+		Title:            title,
+		Body:             body,
+		BodyFormat:       post.BodyFormat(bodyFormat),
+		Tags:             tags,
+		FeaturedImageURL: featuredImageURL,
+		MetaDescription:  metaDescription,
+		Slug:             slug,
+		EditorID:         editorID,
+		CreatedAt:        createdAt,
+		RestoredFrom:     restoredFrom,
+	}
+	if category != nil {
+		rev.Category = *category
+	}
+
+	return rev, nil
+}
@@ -10,7 +10,45 @@ import (
 type PostRepository interface {
 	Create(ctx context.Context, p *post.Post) error
 	FindByID(ctx context.Context, id post.PostID) (*post.Post, error)
+	// Update and Delete fail with *post.ErrVersionConflict when the post is no
+	// longer at the version the caller read.
 	Update(ctx context.Context, p *post.Post) error
-	Delete(ctx context.Context, id post.PostID) error
+	// Delete moves the post to the trash.
+	Delete(ctx context.Context, p *post.Post) error
 	CountScheduledSameDayByCategory(ctx context.Context, category string, scheduledAt time.Time) (int, error)
+	// FindDueScheduledForUpdate locks scheduled posts due at now, skipping rows
+	// already locked by another transaction and the posts in exclude.
+	FindDueScheduledForUpdate(ctx context.Context, now time.Time, limit int, exclude []post.PostID) ([]*post.Post, error)
+	// SlugTaken reports whether a post other than exclude uses slug.
+	SlugTaken(ctx context.Context, slug string, exclude post.PostID) (bool, error)
+
+	// FindByID and the other finders above never return posts in the trash.
+	FindTrashedByID(ctx context.Context, id post.PostID) (*post.Post, error)
+	// FindTrashedPage returns one page of the trash, most recently deleted
+	// first.
+	FindTrashedPage(ctx context.Context, q TrashQuery) (*TrashPage, error)
+	// Restore takes the post out of the trash. Like Update, it fails with
+	// *post.ErrVersionConflict when the post has changed since it was read.
+	Restore(ctx context.Context, p *post.Post) error
+	// FindPurgeableForUpdate locks posts trashed at or before deletedBefore,
+	// skipping rows already locked by another transaction and the posts in
+	// exclude.
+	FindPurgeableForUpdate(ctx context.Context, deletedBefore time.Time, limit int, exclude []post.PostID) ([]*post.Post, error)
+	// Purge removes a post in the trash for good.
+	Purge(ctx context.Context, id post.PostID) error
+}
+
+type TrashQuery struct {
+	// AuthorID restricts the page to the posts of one author when not nil.
+	AuthorID *post.UserID
+	// Limit is the page size.
+	Limit int
+	// Cursor is the NextCursor of the previous page, or "" for the first page.
+	Cursor string
+}
+
+type TrashPage struct {
+	Items []*post.Post
+	// NextCursor is nil on the last page.
+	NextCursor *string
 }
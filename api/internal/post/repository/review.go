@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+type ReviewRepository interface {
+	// Append stores a step of the approval workflow of a post.
+	Append(ctx context.Context, review *post.Review) error
+	// FindByPost returns the approval workflow of a post, newest first.
+	FindByPost(ctx context.Context, postID post.PostID) ([]*post.Review, error)
+}
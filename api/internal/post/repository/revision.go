@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+type RevisionRepository interface {
+	// Append stores rev as the next revision of its post and sets rev.Number.
+	Append(ctx context.Context, rev *post.Revision) error
+	// FindByNumber returns *post.ErrRevisionNotFound when the post has no
+	// revision with number.
+	FindByNumber(ctx context.Context, postID post.PostID, number int) (*post.Revision, error)
+	// FindByPost returns the revisions of a post, newest first.
+	FindByPost(ctx context.Context, postID post.PostID) ([]*post.Revision, error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// Transactor runs fn in a database transaction. Repository calls made with
+// the ctx passed to fn join that transaction; the transaction commits when fn
+// returns nil and rolls back otherwise.
+type Transactor interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+)
+
+const (
+	DefaultPurgeInterval  = time.Hour
+	DefaultTrashRetention = 30 * 24 * time.Hour
+)
+
+// TrashPurger removes trashed posts for good.
+// *usecase.PurgeTrashUsecase satisfies it.
+type TrashPurger interface {
+	Execute(ctx context.Context, input usecase.PurgeTrashInput) (*usecase.PurgeTrashOutput, error)
+}
+
+// Purger periodically purges posts that have been in the trash for longer
+// than the retention period. Like Scheduler, it is safe to run on several
+// replicas at once.
+type Purger struct {
+	purger    TrashPurger
+	clock     clock.Clock
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+}
+
+func NewPurger(purger TrashPurger, clk clock.Clock, interval, retention time.Duration, batchSize int) *Purger {
+	return &Purger{
+		purger:    purger,
+		clock:     clk,
+		interval:  interval,
+		retention: retention,
+		batchSize: batchSize,
+	}
+}
+
+// Run purges every interval until ctx is cancelled.
+func (p *Purger) Run(ctx context.Context) {
+	slog.Info("trash purger started", slog.Duration("interval", p.interval), slog.Duration("retention", p.retention))
+
+	for {
+		if _, err := p.Tick(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("failed to purge trash", slog.String("err", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("trash purger stopped")
+			return
+		case <-p.clock.After(p.interval):
+		}
+	}
+}
+
+// Tick purges every post trashed longer than the retention period ago, in
+// batches of batchSize, and returns how many posts were purged. Posts that
+// fail are logged and left for the next tick.
+func (p *Purger) Tick(ctx context.Context) (int, error) {
+	total := 0
+	var failed []post.PostID
+	for {
+		now := p.clock.Now()
+		output, err := p.purger.Execute(ctx, usecase.PurgeTrashInput{
+			DeletedBefore: now.Add(-p.retention),
+			Now:           now,
+			Limit:         p.batchSize,
+			Skip:          failed,
+		})
+		if err != nil {
+			return total, err
+		}
+
+		for _, purged := range output.Posts {
+			slog.Info("purged trashed post", slog.String("id", purged.ID.String()))
+		}
+		for _, f := range output.Failures {
+			slog.Error("failed to purge trashed post", slog.String("id", f.PostID.String()), slog.String("err", f.Err.Error()))
+			failed = append(failed, f.PostID)
+		}
+		total += len(output.Posts)
+
+		if len(output.Posts)+len(output.Failures) < p.batchSize {
+			return total, nil
+		}
+	}
+}
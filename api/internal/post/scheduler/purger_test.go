@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock/clocktest"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/id"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+)
+
+func newTrashedPost(t *testing.T, deletedAt time.Time) *post.Post {
+	t.Helper()
+
+	p := newScheduledPost(t, deletedAt.Add(-48*time.Hour))
+	p.Delete(post.UserID(id.GenerateUUID()), deletedAt)
+	p.Events = nil
+	return p
+}
+
+func TestPurger_Tick(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	retention := 7 * 24 * time.Hour
+	expired := newTrashedPost(t, now.Add(-retention-time.Minute))
+	onLimit := newTrashedPost(t, now.Add(-retention))
+	recent := newTrashedPost(t, now.Add(-time.Hour))
+	live := newScheduledPost(t, now.Add(time.Hour))
+
+	repo := &memoryRepo{posts: map[post.PostID]*post.Post{}}
+	for _, p := range []*post.Post{expired, onLimit, recent, live} {
+		repo.posts[p.ID] = p
+	}
+	dispatcher := &recordingDispatcher{events: make(chan post.PostEvent, 100)}
+	uc := usecase.NewPurgeTrashUsecase(repo, repo, dispatcher)
+
+	// バッチサイズより多い投稿も1回のTickで処理されること
+	purger := NewPurger(uc, clocktest.NewFake(now), time.Hour, retention, 1)
+
+	count, err := purger.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Tick() purged %d posts, want 2", count)
+	}
+
+	for _, p := range []*post.Post{expired, onLimit} {
+		if _, ok := repo.posts[p.ID]; ok {
+			t.Errorf("post %s was not purged", p.ID)
+		}
+	}
+	for _, p := range []*post.Post{recent, live} {
+		if _, ok := repo.posts[p.ID]; !ok {
+			t.Errorf("post %s was purged", p.ID)
+		}
+	}
+
+	if len(dispatcher.events) != 2 {
+		t.Fatalf("dispatched %d events, want 2", len(dispatcher.events))
+	}
+	if e := <-dispatcher.events; e.Type != post.PostEventTypePurgePost || e.ActorID != nil {
+		t.Errorf("event = %v by %v, want post.purged by the system", e.Type, e.ActorID)
+	}
+}
+
+func TestPurger_Tick_SkipsFailingPost(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	retention := 7 * 24 * time.Hour
+	broken := newTrashedPost(t, now.Add(-retention-2*time.Hour))
+	expired := newTrashedPost(t, now.Add(-retention-time.Hour))
+
+	repo := &memoryRepo{posts: map[post.PostID]*post.Post{broken.ID: broken, expired.ID: expired}, failPurge: broken.ID}
+	dispatcher := &recordingDispatcher{events: make(chan post.PostEvent, 100)}
+	uc := usecase.NewPurgeTrashUsecase(repo, repo, dispatcher)
+
+	// 最初に選ばれる投稿が失敗しても、残りの投稿は削除されること
+	purger := NewPurger(uc, clocktest.NewFake(now), time.Hour, retention, 1)
+
+	count, err := purger.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Tick() purged %d posts, want 1", count)
+	}
+	if _, ok := repo.posts[expired.ID]; ok {
+		t.Errorf("post %s was not purged", expired.ID)
+	}
+	if _, ok := repo.posts[broken.ID]; !ok {
+		t.Errorf("post %s was purged", broken.ID)
+	}
+}
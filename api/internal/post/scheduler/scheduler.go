@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+)
+
+const (
+	DefaultInterval  = 30 * time.Second
+	DefaultBatchSize = 50
+)
+
+// Publisher publishes scheduled posts that are due.
+// *usecase.PublishScheduledPostsUsecase satisfies it.
+type Publisher interface {
+	Execute(ctx context.Context, input usecase.PublishScheduledPostsInput) (*usecase.PublishScheduledPostsOutput, error)
+}
+
+// Scheduler polls for scheduled posts whose time has come and publishes them.
+// Several replicas can run a Scheduler against the same database; rows are
+// locked with SELECT ... FOR UPDATE SKIP LOCKED so each post is published once.
+type Scheduler struct {
+	publisher Publisher
+	clock     clock.Clock
+	interval  time.Duration
+	batchSize int
+}
+
+func NewScheduler(publisher Publisher, clk clock.Clock, interval time.Duration, batchSize int) *Scheduler {
+	return &Scheduler{
+		publisher: publisher,
+		clock:     clk,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run polls every interval until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	slog.Info("scheduler started", slog.Duration("interval", s.interval))
+
+	for {
+		// 起動直後にも溜まっている予約投稿を処理する
+		if _, err := s.Tick(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("failed to publish scheduled posts", slog.String("err", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("scheduler stopped")
+			return
+		case <-s.clock.After(s.interval):
+		}
+	}
+}
+
+// Tick publishes every post that is due now, in batches of batchSize,
+// and returns how many posts were published. Posts that fail are logged and
+// skipped for the rest of the tick; they stay scheduled and are retried on
+// the next tick.
+func (s *Scheduler) Tick(ctx context.Context) (int, error) {
+	total := 0
+	var failed []post.PostID
+	for {
+		output, err := s.publisher.Execute(ctx, usecase.PublishScheduledPostsInput{
+			Now:   s.clock.Now(),
+			Limit: s.batchSize,
+			Skip:  failed,
+		})
+		if err != nil {
+			return total, err
+		}
+
+		for _, p := range output.Posts {
+			slog.Info("published scheduled post", slog.String("id", p.ID.String()))
+		}
+		for _, f := range output.Failures {
+			slog.Error("failed to publish scheduled post", slog.String("id", f.PostID.String()), slog.String("err", f.Err.Error()))
+			failed = append(failed, f.PostID)
+		}
+		total += len(output.Posts)
+
+		if len(output.Posts)+len(output.Failures) < s.batchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock"
+	"github.com/ss49919201/myblog/api/internal/clock/clocktest"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+)
+
+// memoryRepo is an in-memory PostRepository and Transactor.
+type memoryRepo struct {
+	mu    sync.Mutex
+	posts map[post.PostID]*post.Post
+	// failUpdate makes Update fail for this post.
+	failUpdate post.PostID
+	// failPurge makes Purge fail for this post.
+	failPurge post.PostID
+}
+
+func (r *memoryRepo) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return fn(ctx)
+}
+
+func (r *memoryRepo) Create(ctx context.Context, p *post.Post) error {
+	r.posts[p.ID] = p
+	return nil
+}
+
+func (r *memoryRepo) FindByID(ctx context.Context, id post.PostID) (*post.Post, error) {
+	p, ok := r.posts[id]
+	if !ok || p.IsTrashed() {
+		return nil, errors.New("post not found")
+	}
+	return p, nil
+}
+
+func (r *memoryRepo) Update(ctx context.Context, p *post.Post) error {
+	if p.ID == r.failUpdate {
+		return errors.New("version conflict")
+	}
+	r.posts[p.ID] = p
+	return nil
+}
+
+func (r *memoryRepo) Delete(ctx context.Context, p *post.Post) error {
+	r.posts[p.ID] = p
+	return nil
+}
+
+func (r *memoryRepo) FindTrashedPage(ctx context.Context, q repository.TrashQuery) (*repository.TrashPage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *memoryRepo) FindTrashedByID(ctx context.Context, id post.PostID) (*post.Post, error) {
+	p, ok := r.posts[id]
+	if !ok || !p.IsTrashed() {
+		return nil, errors.New("post not found")
+	}
+	return p, nil
+}
+
+func (r *memoryRepo) Restore(ctx context.Context, p *post.Post) error {
+	r.posts[p.ID] = p
+	return nil
+}
+
+func (r *memoryRepo) FindPurgeableForUpdate(ctx context.Context, deletedBefore time.Time, limit int, exclude []post.PostID) ([]*post.Post, error) {
+	var purgeable []*post.Post
+	for _, p := range r.posts {
+		if p.IsTrashed() && !p.DeletedAt.After(deletedBefore) && !slices.Contains(exclude, p.ID) {
+			purgeable = append(purgeable, p)
+		}
+	}
+	sort.Slice(purgeable, func(i, j int) bool { return purgeable[i].DeletedAt.Before(*purgeable[j].DeletedAt) })
+	if len(purgeable) > limit {
+		purgeable = purgeable[:limit]
+	}
+	return purgeable, nil
+}
+
+func (r *memoryRepo) Purge(ctx context.Context, id post.PostID) error {
+	if id == r.failPurge {
+		return errors.New("foreign key constraint fails")
+	}
+	delete(r.posts, id)
+	return nil
+}
+
+func (r *memoryRepo) CountScheduledSameDayByCategory(ctx context.Context, category string, scheduledAt time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *memoryRepo) SlugTaken(ctx context.Context, slug string, exclude post.PostID) (bool, error) {
+	return false, nil
+}
+
+func (r *memoryRepo) FindDueScheduledForUpdate(ctx context.Context, now time.Time, limit int, exclude []post.PostID) ([]*post.Post, error) {
+	var due []*post.Post
+	for _, p := range r.posts {
+		if p.Status == post.StatusScheduled && !p.ScheduledAt.After(now) && !p.IsTrashed() && !slices.Contains(exclude, p.ID) {
+			due = append(due, p)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].ScheduledAt.Before(*due[j].ScheduledAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+type recordingDispatcher struct {
+	events chan post.PostEvent
+}
+
+func (d *recordingDispatcher) DispatchEvents(ctx context.Context, events []post.PostEvent) error {
+	for _, e := range events {
+		d.events <- e
+	}
+	return nil
+}
+
+func newScheduledPost(t *testing.T, scheduledAt time.Time) *post.Post {
+	t.Helper()
+
+	p, err := post.Reconstruct(
+		post.NewPostID(),
+		"Scheduled Title",
+		"This is a test post body with enough content to pass the 100 character minimum requirement for validation.",
+		post.BodyFormatMarkdown,
+		"",
+		post.StatusScheduled,
+		&scheduledAt,
+		"技術",
+		nil,
+		nil,
+		nil,
+		nil,
+		false,
+		false,
+		false,
+		scheduledAt.Add(-24*time.Hour),
+		&scheduledAt,
+		nil,
+		nil,
+		1,
+		post.ReviewStatusNone,
+	)
+	if err != nil {
+		t.Fatalf("Reconstruct() error = %v", err)
+	}
+	return p
+}
+
+func newTestScheduler(t *testing.T, clk clock.Clock, batchSize int, posts ...*post.Post) (*Scheduler, *memoryRepo, *recordingDispatcher) {
+	t.Helper()
+
+	repo := &memoryRepo{posts: map[post.PostID]*post.Post{}}
+	for _, p := range posts {
+		repo.posts[p.ID] = p
+	}
+	dispatcher := &recordingDispatcher{events: make(chan post.PostEvent, 100)}
+	uc := usecase.NewPublishScheduledPostsUsecase(repo, repo, dispatcher)
+
+	return NewScheduler(uc, clk, time.Minute, batchSize), repo, dispatcher
+}
+
+func TestScheduler_Tick(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	due := newScheduledPost(t, now.Add(-time.Minute))
+	onTime := newScheduledPost(t, now)
+	future := newScheduledPost(t, now.Add(time.Minute))
+
+	// バッチサイズより多い投稿も1回のTickで処理されること
+	s, repo, dispatcher := newTestScheduler(t, clocktest.NewFake(now), 1, due, onTime, future)
+
+	count, err := s.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Tick() published %d posts, want 2", count)
+	}
+
+	for _, p := range []*post.Post{due, onTime} {
+		got := repo.posts[p.ID]
+		if got.Status != post.StatusPublished {
+			t.Errorf("post %s status = %v, want %v", p.ID, got.Status, post.StatusPublished)
+		}
+	}
+	if got := repo.posts[future.ID]; got.Status != post.StatusScheduled {
+		t.Errorf("future post status = %v, want %v", got.Status, post.StatusScheduled)
+	}
+	if len(dispatcher.events) != 2 {
+		t.Errorf("dispatched %d events, want 2", len(dispatcher.events))
+	}
+}
+
+func TestScheduler_Tick_SkipsFailingPost(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	broken := newScheduledPost(t, now.Add(-2*time.Minute))
+	due := newScheduledPost(t, now.Add(-time.Minute))
+	onTime := newScheduledPost(t, now)
+
+	// 最初に選ばれる投稿が失敗しても、残りの投稿は公開されること
+	s, repo, _ := newTestScheduler(t, clocktest.NewFake(now), 1, broken, due, onTime)
+	repo.failUpdate = broken.ID
+
+	count, err := s.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Tick() published %d posts, want 2", count)
+	}
+	for _, p := range []*post.Post{due, onTime} {
+		if got := repo.posts[p.ID]; got.Status != post.StatusPublished {
+			t.Errorf("post %s status = %v, want %v", p.ID, got.Status, post.StatusPublished)
+		}
+	}
+}
+
+func TestScheduler_Run_PublishesWhenClockAdvances(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduled := newScheduledPost(t, now.Add(90*time.Second))
+	clk := clocktest.NewFake(now)
+	s, repo, dispatcher := newTestScheduler(t, clk, DefaultBatchSize, scheduled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	if !clk.BlockUntilWaiting(time.Second) {
+		t.Fatal("scheduler did not wait on the clock")
+	}
+	clk.Advance(time.Minute)
+	if !clk.BlockUntilWaiting(time.Second) {
+		t.Fatal("scheduler did not wait on the clock")
+	}
+
+	select {
+	case e := <-dispatcher.events:
+		t.Fatalf("unexpected event %v before the scheduled time", e)
+	default:
+	}
+
+	clk.Advance(time.Minute)
+
+	select {
+	case e := <-dispatcher.events:
+		if e.Type != post.PostEventTypePublishPost {
+			t.Errorf("event type = %v, want PublishPost", e.Type)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("scheduled post was not published")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if got := repo.posts[scheduled.ID]; got.Status != post.StatusPublished {
+		t.Errorf("status = %v, want %v", got.Status, post.StatusPublished)
+	}
+}
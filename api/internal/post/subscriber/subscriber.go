@@ -0,0 +1,123 @@
+// Package subscriber holds the event.Bus subscribers reacting to post changes.
+//
+// None of the downstream systems (search engine, cache, feed storage,
+// notification channel) exist yet, so each subscriber decides which events
+// are relevant and logs the work it would do. Replace the log call with the
+// real integration when it is introduced.
+package subscriber
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// SearchIndexer keeps the search index in sync with posts.
+type SearchIndexer struct{}
+
+func NewSearchIndexer() *SearchIndexer {
+	return &SearchIndexer{}
+}
+
+func (s *SearchIndexer) Name() string {
+	return "search-indexer"
+}
+
+func (s *SearchIndexer) HandleEvent(ctx context.Context, e post.PostEvent) error {
+	action := "reindex"
+	switch e.Type {
+	// 読者に見えなくなった投稿は索引から外す
+	case post.PostEventTypeDeletePost, post.PostEventTypePurgePost, post.PostEventTypeUnpublishPost, post.PostEventTypeArchivePost:
+		action = "remove"
+	}
+
+	slog.InfoContext(ctx, "search index updated",
+		slog.String("action", action),
+		slog.String("postId", e.AggregateID.String()),
+		slog.String("event", e.Type.String()),
+	)
+	return nil
+}
+
+// CacheInvalidator drops cached responses for a post whenever it changes.
+type CacheInvalidator struct{}
+
+func NewCacheInvalidator() *CacheInvalidator {
+	return &CacheInvalidator{}
+}
+
+func (c *CacheInvalidator) Name() string {
+	return "cache-invalidator"
+}
+
+func (c *CacheInvalidator) HandleEvent(ctx context.Context, e post.PostEvent) error {
+	slog.InfoContext(ctx, "post cache invalidated",
+		slog.String("postId", e.AggregateID.String()),
+		slog.String("event", e.Type.String()),
+	)
+	return nil
+}
+
+// FeedRegenerator rebuilds the public feeds when the set of published posts
+// may have changed.
+type FeedRegenerator struct{}
+
+func NewFeedRegenerator() *FeedRegenerator {
+	return &FeedRegenerator{}
+}
+
+func (f *FeedRegenerator) Name() string {
+	return "feed-regenerator"
+}
+
+func (f *FeedRegenerator) HandleEvent(ctx context.Context, e post.PostEvent) error {
+	if !affectsPublishedPosts(e) {
+		return nil
+	}
+
+	slog.InfoContext(ctx, "feed regenerated",
+		slog.String("postId", e.AggregateID.String()),
+		slog.String("event", e.Type.String()),
+	)
+	return nil
+}
+
+// Notifier tells readers about newly published posts.
+type Notifier struct{}
+
+func NewNotifier() *Notifier {
+	return &Notifier{}
+}
+
+func (n *Notifier) Name() string {
+	return "notifier"
+}
+
+func (n *Notifier) HandleEvent(ctx context.Context, e post.PostEvent) error {
+	if e.Type != post.PostEventTypePublishPost {
+		return nil
+	}
+
+	slog.InfoContext(ctx, "publication notified",
+		slog.String("postId", e.AggregateID.String()),
+	)
+	return nil
+}
+
+// affectsPublishedPosts reports whether e adds, changes or removes a post
+// that readers can see.
+func affectsPublishedPosts(e post.PostEvent) bool {
+	switch payload := e.Payload.(type) {
+	case *post.PostStatusChangedPayload:
+		return payload.From == post.StatusPublished || payload.To == post.StatusPublished
+	case *post.PostCreatedPayload:
+		return payload.Post.Status == post.StatusPublished
+	case *post.PostDeletedPayload:
+		return payload.Post.Status == post.StatusPublished
+	case *post.PostUpdatedPayload:
+		// 更新イベント単体では公開状態が分からないため、常に再生成する
+		return true
+	}
+	return false
+}
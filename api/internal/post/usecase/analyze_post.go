@@ -17,13 +17,18 @@ type AnalyzePostOutput struct {
 }
 
 type AnalyzePostUsecase struct {
+	policy Policy
 }
 
-func NewAnalyzePostUsecase() *AnalyzePostUsecase {
-	return &AnalyzePostUsecase{}
+func NewAnalyzePostUsecase(policy Policy) *AnalyzePostUsecase {
+	return &AnalyzePostUsecase{policy: policy}
 }
 
-func (u *AnalyzePostUsecase) Execute(ctx context.Context, input AnalyzePostInput) (*AnalyzePostOutput, error) {
+func (u *AnalyzePostUsecase) Execute(ctx context.Context, input AnalyzePostInput, userCtx UserContext) (*AnalyzePostOutput, error) {
+	if err := u.policy.Authorize(userCtx, ActionAnalyzePost, nil); err != nil {
+		return nil, err
+	}
+
 	postID, err := post.ParsePostID(input.ID)
 	if err != nil {
 		return nil, err
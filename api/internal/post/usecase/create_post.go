@@ -15,6 +15,8 @@ import (
 type CreatePostInput struct {
 	Title                string                    `json:"title"`
 	Body                 string                    `json:"body"`
+	// BodyFormat は省略時 Markdown として扱う
+	BodyFormat           post.BodyFormat           `json:"bodyFormat"`
 	Status               post.PublicationStatus    `json:"status"`
 	ScheduledAt          *time.Time                `json:"scheduledAt"`
 	Category             string                    `json:"category"`
@@ -27,8 +29,11 @@ type CreatePostInput struct {
 	EmergencyFlag        bool                      `json:"emergencyFlag"`
 }
 
+// UserContext is the verified identity of the caller, resolved from the
+// bearer token by the authentication middleware.
 type UserContext struct {
-	Role post.UserRole `json:"role"`
+	UserID post.UserID   `json:"userId"`
+	Role   post.UserRole `json:"role"`
 }
 
 type CreatePostOutput struct {
@@ -37,106 +42,79 @@ type CreatePostOutput struct {
 
 type CreatePostUsecase struct {
 	repo       repository.PostRepository
+	revisions  repository.RevisionRepository
+	reviews    repository.ReviewRepository
+	tx         repository.Transactor
 	dispatcher event.EventDispatcher
+	policy     Policy
+	rules      *RuleEngine
 }
 
-func NewCreatePostUsecase(repo repository.PostRepository, dispatcher event.EventDispatcher) *CreatePostUsecase {
-	return &CreatePostUsecase{repo: repo, dispatcher: dispatcher}
+func NewCreatePostUsecase(repo repository.PostRepository, revisions repository.RevisionRepository, reviews repository.ReviewRepository, tx repository.Transactor, dispatcher event.EventDispatcher, policy Policy, rules *RuleEngine) *CreatePostUsecase {
+	return &CreatePostUsecase{repo: repo, revisions: revisions, reviews: reviews, tx: tx, dispatcher: dispatcher, policy: policy, rules: rules}
 }
 
 func (u *CreatePostUsecase) Execute(ctx context.Context, input CreatePostInput, userCtx UserContext) (*CreatePostOutput, error) {
-	// 1. 基本バリデーション（常時）
-	// タイトル：必須、1-100文字、禁止文字チェック
-	if len(input.Title) < 1 || len(input.Title) > 100 {
-		return nil, post.NewValidationError("title", "title must be between 1 and 100 characters")
-	}
-	forbiddenChars := []string{"<", ">", "\"", "'", "&"}
-	for _, char := range forbiddenChars {
-		if strings.Contains(input.Title, char) {
-			return nil, post.NewValidationError("title", "title contains forbidden characters")
-		}
+	bodyFormat := input.BodyFormat
+	if bodyFormat == "" {
+		bodyFormat = post.BodyFormatMarkdown
 	}
 
-	// 内容：必須、100-5000文字、HTMLタグ検証
-	if len(input.Body) < 100 || len(input.Body) > 5000 {
-		return nil, post.NewValidationError("body", "body must be between 100 and 5000 characters")
+	target := postRuleTarget{
+		Title:            input.Title,
+		Body:             input.Body,
+		BodyFormat:       bodyFormat,
+		Status:           input.Status,
+		ScheduledAt:      input.ScheduledAt,
+		Category:         input.Category,
+		Tags:             input.Tags,
+		FeaturedImageURL: input.FeaturedImageURL,
+		MetaDescription:  input.MetaDescription,
+		EmergencyFlag:    input.EmergencyFlag,
+	}.normalized()
+
+	// 1. 権限チェック
+	if err := u.policy.Authorize(userCtx, ActionCreatePost, nil); err != nil {
+		return nil, err
 	}
-	if strings.Count(input.Body, "<") != strings.Count(input.Body, ">") {
-		return nil, post.NewValidationError("body", "body contains invalid HTML tags")
+	if err := authorizeStatus(u.policy, userCtx, input.Status, nil); err != nil {
+		return nil, err
 	}
 
-	// 2. 権限ベースバリデーション
-	switch userCtx.Role {
-	case post.RoleGeneral:
-		if input.Status != post.StatusDraft {
-			return nil, post.NewValidationError("status", "general users can only save as draft")
-		}
-	case post.RoleEditor:
-		if input.Status == post.StatusPublished {
-			return nil, post.NewValidationError("status", "editors can only schedule posts, not publish immediately")
-		}
-	case post.RoleAdmin:
-		// 管理者は全て可能
-	default:
-		return nil, post.NewValidationError("role", "invalid user role")
+	// 2-5. 基本・カテゴリ・時間制約・重複バリデーション（すべて評価して違反をまとめて返す）
+	var errs post.ValidationErrors
+	_ = errs.Add(validatePostContent(target, nil))
+	if input.Status != post.StatusDraft && input.Status != post.StatusScheduled && input.Status != post.StatusPublished {
+		_ = errs.Add(post.NewValidationError("status", post.ValidationCodeInvalidValue, "status must be draft, scheduled or published"))
 	}
-
-	// 3. カテゴリ依存バリデーション
-	switch input.Category {
-	case "ニュース":
-		if input.FeaturedImageURL == nil || *input.FeaturedImageURL == "" {
-			return nil, post.NewValidationError("featuredImageURL", "news category requires featured image")
-		}
-	case "技術":
-		if len(input.Tags) < 2 {
-			return nil, post.NewValidationError("tags", "tech category requires at least 2 tags")
-		}
-	case "お知らせ":
-		if input.ScheduledAt == nil {
-			return nil, post.NewValidationError("scheduledAt", "announcement category requires scheduled time")
-		}
+	if input.Slug != nil && strings.TrimSpace(*input.Slug) != "" {
+		_ = errs.Add(post.ValidateSlug(post.NormalizeSlug(*input.Slug)))
 	}
-
-	// 4. 時間制約バリデーション
-	now := time.Now()
-	if input.ScheduledAt != nil {
-		if input.ScheduledAt.Before(now.Add(30 * time.Minute)) {
-			return nil, post.NewValidationError("scheduledAt", "scheduled time must be at least 30 minutes from now")
-		}
+	if err := errs.Add(u.rules.validate(ctx, target, time.Now(), nil)); err != nil {
+		return nil, err
 	}
-
-	if !input.EmergencyFlag {
-		if input.Category == "ニュース" && input.Status == post.StatusPublished {
-			weekday := now.Weekday()
-			hour := now.Hour()
-			if weekday == time.Saturday || weekday == time.Sunday || hour < 9 || hour >= 18 {
-				return nil, post.NewValidationError("publishTime", "news can only be published during business hours (9-18, weekdays)")
-			}
-		}
+	if err := errs.Err(); err != nil {
+		return nil, err
 	}
 
-	// 5. 重複・関連性バリデーション
-	if input.ScheduledAt != nil {
-		count, err := u.repo.CountScheduledSameDayByCategory(ctx, input.Category, *input.ScheduledAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check scheduled posts: %w", err)
-		}
-		if count >= 5 {
-			return nil, post.NewValidationError("category", "too many posts scheduled for same day in this category")
-		}
+	// 承認が必要な投稿は、予約・公開せずに下書きとしてレビューに回す
+	status, scheduledAt := input.Status, input.ScheduledAt
+	reasons, err := u.rules.reviewReasons(ctx, target)
+	if err != nil {
+		return nil, err
 	}
-
-	externalLinkCount := strings.Count(input.Body, "http://") + strings.Count(input.Body, "https://")
-	if externalLinkCount >= 10 {
-		return nil, post.NewValidationError("body", "posts with 10+ external links require approval workflow")
+	routeToReview := len(reasons) > 0 && status != post.StatusDraft
+	if routeToReview {
+		status, scheduledAt = post.StatusDraft, nil
 	}
 
 	// 6. Post エンティティ作成（全パラメータ指定）
 	p, err := post.Construct(
 		input.Title,
 		input.Body,
-		input.Status,
-		input.ScheduledAt,
+		bodyFormat,
+		status,
+		scheduledAt,
 		input.Category,
 		input.Tags,
 		input.FeaturedImageURL,
@@ -145,18 +123,53 @@ func (u *CreatePostUsecase) Execute(ctx context.Context, input CreatePostInput,
 		input.SNSAutoPost,
 		input.ExternalNotification,
 		input.EmergencyFlag,
+		userCtx.UserID,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// 7. リトライ機能付き保存
+	var review *post.Review
+	if routeToReview {
+		if review, err = p.SubmitForReview(userCtx.UserID, reasons, nil, p.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	// slug の重複チェック（タイトルから生成した slug は連番で回避する）
+	generatedSlug := input.Slug == nil || strings.TrimSpace(*input.Slug) == ""
+	if err := ensureSlugAvailable(ctx, u.repo, p, generatedSlug); err != nil {
+		return nil, err
+	}
+
+	// 7. リトライ機能付き保存（投稿・最初のリビジョン・レビュー・イベントは同一トランザクションで書き込む）
 	var lastErr error
 	for attempt := 1; attempt <= 3; attempt++ {
-		if err := u.repo.Create(ctx, p); err == nil {
+		if err := u.tx.RunInTx(ctx, func(ctx context.Context) error {
+			if err := u.repo.Create(ctx, p); err != nil {
+				return err
+			}
+			if err := u.revisions.Append(ctx, p.NewRevision(p.AuthorID, p.CreatedAt)); err != nil {
+				return err
+			}
+			if review != nil {
+				if err := u.reviews.Append(ctx, review); err != nil {
+					return err
+				}
+			}
+			return u.dispatcher.DispatchEvents(ctx, p.Events)
+		}); err == nil {
+			lastErr = nil
 			break
 		} else {
 			lastErr = err
+			// 同時に同じ slug で保存された場合は再試行しても解消しない
+			if conflict, ok := post.AsErrSlugConflict(err); ok {
+				if conflict, err = slugConflict(ctx, u.repo, conflict.Slug, p.ID); err != nil {
+					return nil, err
+				}
+				return nil, conflict
+			}
 			if attempt < 3 {
 				time.Sleep(time.Duration(attempt) * time.Second)
 			}
@@ -166,11 +179,5 @@ func (u *CreatePostUsecase) Execute(ctx context.Context, input CreatePostInput,
 		return nil, fmt.Errorf("failed to save post after 3 attempts: %w", lastErr)
 	}
 
-	// 8. イベント配信（既存パターンに従う）
-	if err := u.dispatcher.DispatchEvents(ctx, p.Events); err != nil {
-		// イベント配信失敗はログに記録するが、処理は続行
-		// TODO: ログ出力を追加
-	}
-
 	return &CreatePostOutput{Post: p}, nil
 }
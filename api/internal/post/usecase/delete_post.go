@@ -2,28 +2,58 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/event"
 	"github.com/ss49919201/myblog/api/internal/post/repository"
 )
 
 type DeletePostInput struct {
 	ID string `json:"id"`
+	// ExpectedVersions are the versions the caller accepts, taken from
+	// If-Match. nil accepts any version.
+	ExpectedVersions []int `json:"-"`
 }
 
 type DeletePostUsecase struct {
-	repo repository.PostRepository
+	repo       repository.PostRepository
+	tx         repository.Transactor
+	dispatcher event.EventDispatcher
+	policy     Policy
 }
 
-func NewDeletePostUsecase(repo repository.PostRepository) *DeletePostUsecase {
-	return &DeletePostUsecase{repo: repo}
+func NewDeletePostUsecase(repo repository.PostRepository, tx repository.Transactor, dispatcher event.EventDispatcher, policy Policy) *DeletePostUsecase {
+	return &DeletePostUsecase{repo: repo, tx: tx, dispatcher: dispatcher, policy: policy}
 }
 
-func (u *DeletePostUsecase) Execute(ctx context.Context, input DeletePostInput) error {
+func (u *DeletePostUsecase) Execute(ctx context.Context, input DeletePostInput, userCtx UserContext) error {
 	postID, err := post.ParsePostID(input.ID)
 	if err != nil {
 		return err
 	}
 
-	return u.repo.Delete(ctx, postID)
+	existingPost, err := u.repo.FindByID(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.policy.Authorize(userCtx, ActionDeletePost, existingPost); err != nil {
+		return err
+	}
+
+	if err := checkVersion(existingPost, input.ExpectedVersions); err != nil {
+		return err
+	}
+
+	// 投稿はゴミ箱に移し、保持期間を過ぎてから PurgeTrashUsecase が物理削除する
+	existingPost.Delete(userCtx.UserID, time.Now())
+
+	// 削除とイベントは同一トランザクションで書き込む
+	return u.tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.Delete(ctx, existingPost); err != nil {
+			return err
+		}
+		return u.dispatcher.DispatchEvents(ctx, existingPost.Events)
+	})
 }
\ No newline at end of file
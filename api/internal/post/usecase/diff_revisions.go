@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+	"github.com/ss49919201/myblog/api/internal/textdiff"
+)
+
+type DiffRevisionsInput struct {
+	ID   string        `json:"id"`
+	From int           `json:"from"`
+	To   int           `json:"to"`
+	Mode textdiff.Mode `json:"mode"`
+}
+
+type DiffRevisionsOutput struct {
+	Diff *post.RevisionDiff `json:"diff"`
+}
+
+// DiffRevisionsUsecase compares two revisions of a post for those who may
+// read the post.
+type DiffRevisionsUsecase struct {
+	repo      repository.PostRepository
+	revisions repository.RevisionRepository
+	policy    Policy
+}
+
+func NewDiffRevisionsUsecase(repo repository.PostRepository, revisions repository.RevisionRepository, policy Policy) *DiffRevisionsUsecase {
+	return &DiffRevisionsUsecase{repo: repo, revisions: revisions, policy: policy}
+}
+
+func (u *DiffRevisionsUsecase) Execute(ctx context.Context, input DiffRevisionsInput, userCtx UserContext) (*DiffRevisionsOutput, error) {
+	postID, err := post.ParsePostID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := findReadablePost(ctx, u.repo, u.policy, userCtx, postID); err != nil {
+		return nil, err
+	}
+
+	from, err := u.revisions.FindByNumber(ctx, postID, input.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := u.revisions.FindByNumber(ctx, postID, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := post.DiffRevisions(from, to, input.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DiffRevisionsOutput{Diff: diff}, nil
+}
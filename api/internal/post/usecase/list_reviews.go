@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type ListReviewsInput struct {
+	ID string `json:"id"`
+}
+
+type ListReviewsOutput struct {
+	Reviews []*post.Review `json:"reviews"`
+}
+
+// ListReviewsUsecase lists the approval workflow of a post, newest first, to
+// those who may read the post.
+type ListReviewsUsecase struct {
+	repo    repository.PostRepository
+	reviews repository.ReviewRepository
+	policy  Policy
+}
+
+func NewListReviewsUsecase(repo repository.PostRepository, reviews repository.ReviewRepository, policy Policy) *ListReviewsUsecase {
+	return &ListReviewsUsecase{repo: repo, reviews: reviews, policy: policy}
+}
+
+func (u *ListReviewsUsecase) Execute(ctx context.Context, input ListReviewsInput, userCtx UserContext) (*ListReviewsOutput, error) {
+	postID, err := post.ParsePostID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := findReadablePost(ctx, u.repo, u.policy, userCtx, postID); err != nil {
+		return nil, err
+	}
+
+	reviews, err := u.reviews.FindByPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListReviewsOutput{Reviews: reviews}, nil
+}
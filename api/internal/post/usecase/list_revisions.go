@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type ListRevisionsInput struct {
+	ID string `json:"id"`
+}
+
+type ListRevisionsOutput struct {
+	Revisions []*post.Revision `json:"revisions"`
+}
+
+// ListRevisionsUsecase lists the revisions of a post, newest first, to those
+// who may read the post.
+type ListRevisionsUsecase struct {
+	repo      repository.PostRepository
+	revisions repository.RevisionRepository
+	policy    Policy
+}
+
+func NewListRevisionsUsecase(repo repository.PostRepository, revisions repository.RevisionRepository, policy Policy) *ListRevisionsUsecase {
+	return &ListRevisionsUsecase{repo: repo, revisions: revisions, policy: policy}
+}
+
+func (u *ListRevisionsUsecase) Execute(ctx context.Context, input ListRevisionsInput, userCtx UserContext) (*ListRevisionsOutput, error) {
+	postID, err := post.ParsePostID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := findReadablePost(ctx, u.repo, u.policy, userCtx, postID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := u.revisions.FindByPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListRevisionsOutput{Revisions: revisions}, nil
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+const (
+	DefaultTrashListLimit = 20
+	MaxTrashListLimit     = 100
+)
+
+type ListTrashInput struct {
+	// Limit is the page size, at most MaxTrashListLimit. Zero means
+	// DefaultTrashListLimit.
+	Limit int `json:"limit"`
+	// Cursor is the NextCursor of the previous page, or "" for the first page.
+	Cursor string `json:"cursor"`
+}
+
+type ListTrashOutput struct {
+	Posts      []*post.Post `json:"posts"`
+	NextCursor *string      `json:"nextCursor"`
+}
+
+// ListTrashUsecase lists the posts in the trash. Whoever may delete a post may
+// look into the trash, but callers who may not read other authors' posts only
+// see their own.
+type ListTrashUsecase struct {
+	repo   repository.PostRepository
+	policy Policy
+}
+
+func NewListTrashUsecase(repo repository.PostRepository, policy Policy) *ListTrashUsecase {
+	return &ListTrashUsecase{repo: repo, policy: policy}
+}
+
+func (u *ListTrashUsecase) Execute(ctx context.Context, input ListTrashInput, userCtx UserContext) (*ListTrashOutput, error) {
+	if err := u.policy.Authorize(userCtx, ActionDeletePost, nil); err != nil {
+		return nil, err
+	}
+
+	query := repository.TrashQuery{Limit: input.Limit, Cursor: input.Cursor}
+	if query.Limit <= 0 {
+		query.Limit = DefaultTrashListLimit
+	}
+	if err := u.policy.Authorize(userCtx, ActionReadPost, nil); err != nil {
+		query.AuthorID = &userCtx.UserID
+	}
+
+	page, err := u.repo.FindTrashedPage(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListTrashOutput{Posts: page.Items, NextCursor: page.NextCursor}, nil
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+// Action is an operation on posts that is subject to authorization.
+type Action string
+
+const (
+	ActionCreatePost   Action = "create"
+	ActionEditPost     Action = "edit"
+	ActionDeletePost   Action = "delete"
+	ActionPublishPost  Action = "publish"
+	ActionSchedulePost Action = "schedule"
+	ActionAnalyzePost  Action = "analyze"
+	ActionArchivePost  Action = "archive"
+
+	// ActionReadPost covers reading what only the people working on a post
+	// see, such as its revisions, its reviews and the trash. With a nil
+	// target it asks whether the caller may read the posts of other authors.
+	ActionReadPost Action = "read"
+
+	// ActionReviewPost covers approving and rejecting a post submitted for
+	// review. Submitting it only needs ActionEditPost.
+	ActionReviewPost Action = "review"
+
+	// ActionManageWebhooks covers registering, removing and inspecting
+	// webhook endpoints. It never has a target post.
+	ActionManageWebhooks Action = "manage_webhooks"
+
+	// ActionManageRules covers editing the editorial rules. It never has a
+	// target post.
+	ActionManageRules Action = "manage_rules"
+)
+
+// Policy decides whether a user may perform an action.
+// target is nil for actions that do not concern an existing post.
+// A denial is reported as *post.ErrForbidden.
+type Policy interface {
+	Authorize(userCtx UserContext, action Action, target *post.Post) error
+}
+
+// RolePolicy is the default Policy based on the caller's role.
+//
+//	action    general           editor                 admin
+//	read      own posts only    yes                    yes
+//	create    yes               yes                    yes
+//	edit      own drafts only   yes                    yes
+//	delete    own drafts only   drafts and scheduled   yes
+//	publish   no                no                     yes
+//	schedule  no                yes                    yes
+//	analyze   no                yes                    yes
+//	archive   no                yes                    yes
+//	review    no                others' posts only     yes
+//	webhooks  no                no                     yes
+//	rules     no                no                     yes
+type RolePolicy struct{}
+
+func NewRolePolicy() *RolePolicy {
+	return &RolePolicy{}
+}
+
+func (p *RolePolicy) Authorize(userCtx UserContext, action Action, target *post.Post) error {
+	switch userCtx.Role {
+	case post.RoleAdmin:
+		return nil
+	case post.RoleEditor:
+		return authorizeEditor(userCtx, action, target)
+	case post.RoleGeneral:
+		return authorizeGeneral(userCtx, action, target)
+	default:
+		return post.NewForbiddenError(string(action), "invalid user role")
+	}
+}
+
+func authorizeEditor(userCtx UserContext, action Action, target *post.Post) error {
+	switch action {
+	case ActionReadPost, ActionCreatePost, ActionEditPost, ActionSchedulePost, ActionAnalyzePost, ActionArchivePost:
+		return nil
+	case ActionDeletePost:
+		if target != nil && target.Status == post.StatusPublished {
+			return post.NewForbiddenError(string(action), "editors cannot delete published posts")
+		}
+		return nil
+	case ActionPublishPost:
+		return post.NewForbiddenError(string(action), "editors can only schedule posts, not publish immediately")
+	case ActionReviewPost:
+		if target != nil && target.IsAuthoredBy(userCtx.UserID) {
+			return post.NewForbiddenError(string(action), "editors cannot review their own posts")
+		}
+		return nil
+	}
+
+	return post.NewForbiddenError(string(action), "action is not allowed")
+}
+
+func authorizeGeneral(userCtx UserContext, action Action, target *post.Post) error {
+	switch action {
+	case ActionReadPost:
+		if target == nil || !target.IsAuthoredBy(userCtx.UserID) {
+			return post.NewForbiddenError(string(action), "general users can only read their own posts")
+		}
+		return nil
+	case ActionCreatePost:
+		return nil
+	case ActionEditPost, ActionDeletePost:
+		if target == nil {
+			return nil
+		}
+		if !target.IsAuthoredBy(userCtx.UserID) {
+			return post.NewForbiddenError(string(action), "general users can only modify their own posts")
+		}
+		if target.Status != post.StatusDraft {
+			return post.NewForbiddenError(string(action), "general users can only modify drafts")
+		}
+		return nil
+	case ActionPublishPost, ActionSchedulePost:
+		return post.NewForbiddenError(string(action), "general users can only save as draft")
+	}
+
+	return post.NewForbiddenError(string(action), "action is not allowed")
+}
+
+// authorizeStatus checks the extra permission needed to save a post in status.
+func authorizeStatus(policy Policy, userCtx UserContext, status post.PublicationStatus, target *post.Post) error {
+	switch status {
+	case post.StatusPublished:
+		return policy.Authorize(userCtx, ActionPublishPost, target)
+	case post.StatusScheduled:
+		return policy.Authorize(userCtx, ActionSchedulePost, target)
+	case post.StatusArchived:
+		return policy.Authorize(userCtx, ActionArchivePost, target)
+	}
+
+	if target != nil {
+		// 公開・予約の取り消しは、その操作自体と同じ権限を要求する
+		switch target.Status {
+		case post.StatusPublished:
+			return policy.Authorize(userCtx, ActionPublishPost, target)
+		case post.StatusScheduled:
+			return policy.Authorize(userCtx, ActionSchedulePost, target)
+		case post.StatusArchived:
+			return policy.Authorize(userCtx, ActionArchivePost, target)
+		}
+	}
+
+	return nil
+}
+
+// findReadablePost loads a post whose history userCtx wants to read. A post
+// in the trash is only visible to its author; to anyone else it does not
+// exist.
+func findReadablePost(ctx context.Context, repo repository.PostRepository, policy Policy, userCtx UserContext, postID post.PostID) (*post.Post, error) {
+	p, err := repo.FindByID(ctx, postID)
+	if err != nil {
+		if err.Error() != "post not found" {
+			return nil, err
+		}
+		if p, err = repo.FindTrashedByID(ctx, postID); err != nil {
+			return nil, err
+		}
+		if !p.IsAuthoredBy(userCtx.UserID) {
+			return nil, &post.ErrPostNotFound{}
+		}
+	}
+
+	if err := policy.Authorize(userCtx, ActionReadPost, p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/id"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+func TestRolePolicy_Authorize(t *testing.T) {
+	authorID := post.UserID(id.GenerateUUID())
+	draft := &post.Post{Status: post.StatusDraft, AuthorID: &authorID}
+	scheduled := &post.Post{Status: post.StatusScheduled, AuthorID: &authorID}
+	published := &post.Post{Status: post.StatusPublished, AuthorID: &authorID}
+	othersDraft := &post.Post{Status: post.StatusDraft}
+
+	general := UserContext{UserID: authorID, Role: post.RoleGeneral}
+	editor := UserContext{Role: post.RoleEditor}
+	admin := UserContext{Role: post.RoleAdmin}
+
+	tests := []struct {
+		name    string
+		userCtx UserContext
+		action  Action
+		target  *post.Post
+		allowed bool
+	}{
+		{name: "general can read own draft", userCtx: general, action: ActionReadPost, target: draft, allowed: true},
+		{name: "general cannot read others draft", userCtx: general, action: ActionReadPost, target: othersDraft, allowed: false},
+		{name: "general cannot read others posts", userCtx: general, action: ActionReadPost, allowed: false},
+		{name: "editor can read others draft", userCtx: editor, action: ActionReadPost, target: othersDraft, allowed: true},
+		{name: "general can create", userCtx: general, action: ActionCreatePost, allowed: true},
+		{name: "general can edit own draft", userCtx: general, action: ActionEditPost, target: draft, allowed: true},
+		{name: "general cannot edit others draft", userCtx: general, action: ActionEditPost, target: othersDraft, allowed: false},
+		{name: "general cannot delete others draft", userCtx: general, action: ActionDeletePost, target: othersDraft, allowed: false},
+		{name: "general cannot edit scheduled", userCtx: general, action: ActionEditPost, target: scheduled, allowed: false},
+		{name: "general can delete own draft", userCtx: general, action: ActionDeletePost, target: draft, allowed: true},
+		{name: "general cannot delete published", userCtx: general, action: ActionDeletePost, target: published, allowed: false},
+		{name: "general cannot schedule", userCtx: general, action: ActionSchedulePost, allowed: false},
+		{name: "general cannot publish", userCtx: general, action: ActionPublishPost, allowed: false},
+		{name: "general cannot analyze", userCtx: general, action: ActionAnalyzePost, allowed: false},
+		{name: "editor can edit published", userCtx: editor, action: ActionEditPost, target: published, allowed: true},
+		{name: "editor can schedule", userCtx: editor, action: ActionSchedulePost, allowed: true},
+		{name: "editor cannot publish", userCtx: editor, action: ActionPublishPost, allowed: false},
+		{name: "editor can delete scheduled", userCtx: editor, action: ActionDeletePost, target: scheduled, allowed: true},
+		{name: "editor cannot delete published", userCtx: editor, action: ActionDeletePost, target: published, allowed: false},
+		{name: "editor can analyze", userCtx: editor, action: ActionAnalyzePost, allowed: true},
+		{name: "general cannot manage webhooks", userCtx: general, action: ActionManageWebhooks, allowed: false},
+		{name: "editor cannot manage webhooks", userCtx: editor, action: ActionManageWebhooks, allowed: false},
+		{name: "admin can manage webhooks", userCtx: admin, action: ActionManageWebhooks, allowed: true},
+		{name: "editor cannot manage rules", userCtx: editor, action: ActionManageRules, allowed: false},
+		{name: "admin can manage rules", userCtx: admin, action: ActionManageRules, allowed: true},
+		{name: "general cannot archive", userCtx: general, action: ActionArchivePost, target: draft, allowed: false},
+		{name: "editor can archive", userCtx: editor, action: ActionArchivePost, target: published, allowed: true},
+		{name: "general cannot review", userCtx: general, action: ActionReviewPost, target: othersDraft, allowed: false},
+		{name: "editor can review others post", userCtx: editor, action: ActionReviewPost, target: draft, allowed: true},
+		{name: "editor cannot review own post", userCtx: UserContext{UserID: authorID, Role: post.RoleEditor}, action: ActionReviewPost, target: draft, allowed: false},
+		{name: "admin can review own post", userCtx: UserContext{UserID: authorID, Role: post.RoleAdmin}, action: ActionReviewPost, target: draft, allowed: true},
+		{name: "admin can publish", userCtx: admin, action: ActionPublishPost, allowed: true},
+		{name: "admin can delete published", userCtx: admin, action: ActionDeletePost, target: published, allowed: true},
+		{name: "unknown role is denied", userCtx: UserContext{Role: "owner"}, action: ActionCreatePost, allowed: false},
+	}
+
+	policy := NewRolePolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(tt.userCtx, tt.action, tt.target)
+			if tt.allowed && err != nil {
+				t.Errorf("Authorize() error = %v, want nil", err)
+			}
+			if !tt.allowed {
+				if _, ok := post.AsErrForbidden(err); !ok {
+					t.Errorf("Authorize() error = %v, want *post.ErrForbidden", err)
+				}
+			}
+		})
+	}
+}
+
+// findRepo serves FindByID and FindTrashedByID from memory.
+type findRepo struct {
+	repository.PostRepository
+	posts map[post.PostID]*post.Post
+}
+
+func (r *findRepo) FindByID(ctx context.Context, id post.PostID) (*post.Post, error) {
+	p, ok := r.posts[id]
+	if !ok || p.IsTrashed() {
+		return nil, errors.New("post not found")
+	}
+	return p, nil
+}
+
+func (r *findRepo) FindTrashedByID(ctx context.Context, id post.PostID) (*post.Post, error) {
+	p, ok := r.posts[id]
+	if !ok || !p.IsTrashed() {
+		return nil, errors.New("post not found")
+	}
+	return p, nil
+}
+
+func TestFindReadablePost(t *testing.T) {
+	authorID := post.UserID(id.GenerateUUID())
+	draft := &post.Post{ID: post.NewPostID(), Status: post.StatusDraft, AuthorID: &authorID}
+	trashed := &post.Post{ID: post.NewPostID(), Status: post.StatusDraft, AuthorID: &authorID}
+	trashed.Delete(authorID, time.Now())
+	repo := &findRepo{posts: map[post.PostID]*post.Post{draft.ID: draft, trashed.ID: trashed}}
+
+	author := UserContext{UserID: authorID, Role: post.RoleGeneral}
+	general := UserContext{UserID: post.UserID(id.GenerateUUID()), Role: post.RoleGeneral}
+	editor := UserContext{UserID: post.UserID(id.GenerateUUID()), Role: post.RoleEditor}
+
+	tests := []struct {
+		name    string
+		userCtx UserContext
+		postID  post.PostID
+		wantErr string
+	}{
+		{name: "author reads own draft", userCtx: author, postID: draft.ID},
+		{name: "editor reads others draft", userCtx: editor, postID: draft.ID},
+		{name: "general cannot read others draft", userCtx: general, postID: draft.ID, wantErr: "forbidden"},
+		{name: "author reads own trashed post", userCtx: author, postID: trashed.ID},
+		{name: "trashed post of another author is not found", userCtx: editor, postID: trashed.ID, wantErr: "not found"},
+		{name: "unknown post is not found", userCtx: editor, postID: post.NewPostID(), wantErr: "not found"},
+	}
+
+	policy := NewRolePolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := findReadablePost(context.Background(), repo, policy, tt.userCtx, tt.postID)
+			switch tt.wantErr {
+			case "":
+				if err != nil {
+					t.Errorf("findReadablePost() error = %v, want nil", err)
+				}
+			case "forbidden":
+				if _, ok := post.AsErrForbidden(err); !ok {
+					t.Errorf("findReadablePost() error = %v, want *post.ErrForbidden", err)
+				}
+			case "not found":
+				if err == nil || err.Error() != "post not found" {
+					t.Errorf("findReadablePost() error = %v, want post not found", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+	"github.com/ss49919201/myblog/api/internal/rule/entity/rule"
+	rulerepository "github.com/ss49919201/myblog/api/internal/rule/repository"
+	"github.com/ss49919201/myblog/api/internal/sanitize"
+)
+
+// postRuleTarget is the state of a post that the editorial rules are checked
+// against: the request for create, or the merged result for update.
+type postRuleTarget struct {
+	Title            string
+	Body             string
+	BodyFormat       post.BodyFormat
+	Status           post.PublicationStatus
+	ScheduledAt      *time.Time
+	Category         string
+	Tags             []string
+	FeaturedImageURL *string
+	MetaDescription  *string
+	EmergencyFlag    bool
+}
+
+func ruleTargetFromPost(p *post.Post) postRuleTarget {
+	return postRuleTarget{
+		Title:            p.Title,
+		Body:             p.Body,
+		BodyFormat:       p.BodyFormat,
+		Status:           p.Status,
+		ScheduledAt:      p.ScheduledAt,
+		Category:         p.Category,
+		Tags:             p.Tags,
+		FeaturedImageURL: p.FeaturedImageURL,
+		MetaDescription:  p.MetaDescription,
+		EmergencyFlag:    p.EmergencyFlag,
+	}
+}
+
+// normalized returns t with its text fields normalized the way post.Construct
+// and post.Post.Patched store them, so that lengths are checked on the same
+// text that is saved.
+func (t postRuleTarget) normalized() postRuleTarget {
+	t.Title = post.NormalizeLine(t.Title)
+	t.Body = post.NormalizeText(t.Body)
+	t.Category = post.NormalizeLine(t.Category)
+	if t.MetaDescription != nil {
+		metaDescription := post.NormalizeText(*t.MetaDescription)
+		t.MetaDescription = &metaDescription
+	}
+	return t
+}
+
+// validatePostContent runs the basic title and body checks and reports every
+// failure as post.ValidationErrors. Lengths are checked by the entity so that
+// the limits are the same everywhere. When previous is given, only the fields
+// that differ from it are checked, so that posts saved under older rules can
+// still be edited.
+func validatePostContent(target postRuleTarget, previous *post.Post) error {
+	var errs post.ValidationErrors
+	if previous == nil {
+		_ = errs.Add(post.ValidateForConstruct(target.Title, target.Body, target.Category, target.MetaDescription))
+	} else {
+		_ = errs.Add(post.ValidateForUpdate(previous, target.Title, target.Body, target.Category, target.MetaDescription))
+	}
+
+	// タイトル：プレーンテキストなのでタグは使えない
+	titleChanged := previous == nil || previous.Title != target.Title
+	if titleChanged && sanitize.ContainsMarkup(target.Title) {
+		_ = errs.Add(post.NewValidationError("title", post.ValidationCodeForbiddenCharacters, "title must not contain HTML tags"))
+	}
+
+	// 内容：本文の形式を確認し、許可リストにないマークアップを報告する
+	bodyChanged := previous == nil || previous.Body != target.Body || previous.BodyFormat != target.BodyFormat
+	if !bodyChanged {
+		return errs.Err()
+	}
+	if err := post.ValidateBodyFormat(target.BodyFormat); err != nil {
+		_ = errs.Add(err)
+	} else if err := errs.Add(post.ValidateBodyMarkup(target.BodyFormat, target.Body)); err != nil {
+		return err
+	}
+
+	return errs.Err()
+}
+
+// RuleEngine evaluates the editorial rules in effect for each post: the
+// global rules overlaid with those of the post's category.
+type RuleEngine struct {
+	repo  repository.PostRepository
+	rules rulerepository.RuleRepository
+}
+
+func NewRuleEngine(repo repository.PostRepository, rules rulerepository.RuleRepository) *RuleEngine {
+	return &RuleEngine{repo: repo, rules: rules}
+}
+
+func (e *RuleEngine) rulesFor(ctx context.Context, category string) (rule.Rules, error) {
+	set, err := e.rules.Load(ctx)
+	if err != nil {
+		return rule.Rules{}, fmt.Errorf("failed to load rules: %w", err)
+	}
+	return set.For(category), nil
+}
+
+// validate runs the category, timing and quota rules and reports every
+// failure as post.ValidationErrors.
+// previous is the stored post when updating and nil when creating; it is used
+// so that an unchanged schedule is not re-checked against the lead time and
+// the post is not counted against its own daily quota.
+func (e *RuleEngine) validate(ctx context.Context, target postRuleTarget, now time.Time, previous *post.Post) error {
+	rules, err := e.rulesFor(ctx, target.Category)
+	if err != nil {
+		return err
+	}
+
+	var errs post.ValidationErrors
+
+	// 3. カテゴリ依存バリデーション
+	for _, field := range rules.RequiredFields {
+		if !hasField(target, field) {
+			_ = errs.Add(post.NewValidationError(validationField(field), post.ValidationCodeRequiredByCategory, fmt.Sprintf("%s is required in category %q", field, target.Category)))
+		}
+	}
+	if rules.MinTags != nil && len(target.Tags) < *rules.MinTags {
+		_ = errs.Add(post.NewValidationError("tags", post.ValidationCodeMinTags, fmt.Sprintf("category %q requires at least %d tags", target.Category, *rules.MinTags)))
+	}
+	if rules.MaxExternalLinks != nil && externalLinkCount(target.Body) > *rules.MaxExternalLinks {
+		_ = errs.Add(post.NewValidationError("body", post.ValidationCodeMaxExternalLinks, fmt.Sprintf("body must not contain more than %d external links", *rules.MaxExternalLinks)))
+	}
+
+	// 4. 時間制約バリデーション
+	scheduleChanged := previous == nil ||
+		previous.Status != target.Status ||
+		!post.EqualPtr(previous.ScheduledAt, target.ScheduledAt)
+
+	if rules.LeadTimeMinutes != nil && target.ScheduledAt != nil && scheduleChanged {
+		leadTime := time.Duration(*rules.LeadTimeMinutes) * time.Minute
+		if target.ScheduledAt.Before(now.Add(leadTime)) {
+			_ = errs.Add(post.NewValidationError("scheduledAt", post.ValidationCodeLeadTime, fmt.Sprintf("scheduled time must be at least %d minutes from now", *rules.LeadTimeMinutes)))
+		}
+	}
+
+	publishing := target.Status == post.StatusPublished &&
+		(previous == nil || previous.Status != post.StatusPublished)
+
+	if rules.PublishWindow != nil && publishing && !target.EmergencyFlag {
+		if !rules.PublishWindow.Contains(now) {
+			_ = errs.Add(post.NewValidationError("publishTime", post.ValidationCodePublishWindow, fmt.Sprintf("category %q can only be published within %s", target.Category, rules.PublishWindow)))
+		}
+	}
+
+	// 5. 重複・関連性バリデーション
+	if rules.DailyQuota != nil && target.ScheduledAt != nil && scheduleChanged {
+		count, err := e.repo.CountScheduledSameDayByCategory(ctx, target.Category, *target.ScheduledAt)
+		if err != nil {
+			return fmt.Errorf("failed to check scheduled posts: %w", err)
+		}
+		if previous != nil && countedInQuota(previous, target) {
+			count--
+		}
+		if count >= *rules.DailyQuota {
+			_ = errs.Add(post.NewValidationError("category", post.ValidationCodeDailyQuota, "too many posts scheduled for same day in this category"))
+		}
+	}
+
+	return errs.Err()
+}
+
+// validatePost runs validatePostContent and validate together, so that every
+// violation is reported in one post.ValidationErrors.
+func (e *RuleEngine) validatePost(ctx context.Context, target postRuleTarget, now time.Time, previous *post.Post) error {
+	var errs post.ValidationErrors
+	_ = errs.Add(validatePostContent(target, previous))
+	if err := errs.Add(e.validate(ctx, target, now, previous)); err != nil {
+		return err
+	}
+
+	return errs.Err()
+}
+
+// reviewReasons lists the rules that require target to be approved by a
+// reviewer before it is scheduled or published.
+func (e *RuleEngine) reviewReasons(ctx context.Context, target postRuleTarget) ([]post.ReviewReason, error) {
+	rules, err := e.rulesFor(ctx, target.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	var reasons []post.ReviewReason
+	if rules.ReviewExternalLinks != nil && externalLinkCount(target.Body) >= *rules.ReviewExternalLinks {
+		reasons = append(reasons, post.ReviewReasonExternalLinks)
+	}
+
+	return reasons, nil
+}
+
+// requireApproval reports *post.ErrReviewRequired when p is scheduled or
+// published although its content needs an approval it does not have.
+func (e *RuleEngine) requireApproval(ctx context.Context, p *post.Post) error {
+	if p.Status != post.StatusScheduled && p.Status != post.StatusPublished {
+		return nil
+	}
+	if p.ReviewStatus == post.ReviewStatusApproved {
+		return nil
+	}
+
+	reasons, err := e.reviewReasons(ctx, ruleTargetFromPost(p))
+	if err != nil {
+		return err
+	}
+	if len(reasons) > 0 {
+		return &post.ErrReviewRequired{PostID: p.ID, ReviewStatus: p.ReviewStatus, Reasons: reasons}
+	}
+
+	return nil
+}
+
+func hasField(target postRuleTarget, field rule.Field) bool {
+	switch field {
+	case rule.FieldFeaturedImageURL:
+		return target.FeaturedImageURL != nil && *target.FeaturedImageURL != ""
+	case rule.FieldScheduledAt:
+		return target.ScheduledAt != nil
+	case rule.FieldMetaDescription:
+		return target.MetaDescription != nil && *target.MetaDescription != ""
+	}
+	return true
+}
+
+// validationField is the field name reported in validation errors.
+func validationField(field rule.Field) string {
+	if field == rule.FieldFeaturedImageURL {
+		return "featuredImageURL"
+	}
+	return string(field)
+}
+
+func externalLinkCount(body string) int {
+	return strings.Count(body, "http://") + strings.Count(body, "https://")
+}
+
+// countedInQuota reports whether previous is already included in the daily
+// count for target's category and day.
+func countedInQuota(previous *post.Post, target postRuleTarget) bool {
+	if previous.Status != post.StatusScheduled || previous.ScheduledAt == nil || target.ScheduledAt == nil {
+		return false
+	}
+	if previous.Category != target.Category {
+		return false
+	}
+
+	py, pm, pd := previous.ScheduledAt.Date()
+	ty, tm, td := target.ScheduledAt.In(previous.ScheduledAt.Location()).Date()
+	return py == ty && pm == tm && pd == td
+}
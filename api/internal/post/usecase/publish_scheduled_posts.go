@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/event"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type PublishScheduledPostsInput struct {
+	// Now is the time scheduled posts are compared against.
+	Now time.Time `json:"now"`
+	// Limit caps the number of posts handled in one call.
+	Limit int `json:"limit"`
+	// Skip lists posts that already failed in this run, so that they are not
+	// picked up again until the next run.
+	Skip []post.PostID `json:"skip"`
+}
+
+type PublishScheduledPostsOutput struct {
+	Posts    []*post.Post              `json:"posts"`
+	Failures []ScheduledPublishFailure `json:"failures"`
+}
+
+// ScheduledPublishFailure is a post that could not be published. It stays
+// scheduled and is retried on the next run.
+type ScheduledPublishFailure struct {
+	PostID post.PostID `json:"postId"`
+	Err    error       `json:"-"`
+}
+
+// PublishScheduledPostsUsecase publishes scheduled posts whose time has come.
+// It runs on behalf of the system, so no Policy is consulted.
+type PublishScheduledPostsUsecase struct {
+	repo       repository.PostRepository
+	tx         repository.Transactor
+	dispatcher event.EventDispatcher
+}
+
+func NewPublishScheduledPostsUsecase(repo repository.PostRepository, tx repository.Transactor, dispatcher event.EventDispatcher) *PublishScheduledPostsUsecase {
+	return &PublishScheduledPostsUsecase{repo: repo, tx: tx, dispatcher: dispatcher}
+}
+
+// Execute publishes each post in its own transaction. A post that fails is
+// reported in Failures and the rest are still published, so that one bad row
+// cannot hold back every scheduled post.
+func (u *PublishScheduledPostsUsecase) Execute(ctx context.Context, input PublishScheduledPostsInput) (*PublishScheduledPostsOutput, error) {
+	output := &PublishScheduledPostsOutput{}
+	skip := slices.Clone(input.Skip)
+
+	for len(output.Posts)+len(output.Failures) < input.Limit {
+		var p *post.Post
+
+		// 複数レプリカで同時に実行されても、行ロックにより同じ投稿は一度しか公開されない
+		err := u.tx.RunInTx(ctx, func(ctx context.Context) error {
+			p = nil
+
+			duePosts, err := u.repo.FindDueScheduledForUpdate(ctx, input.Now, 1, skip)
+			if err != nil {
+				return err
+			}
+			if len(duePosts) == 0 {
+				return nil
+			}
+			p = duePosts[0]
+
+			if err := p.PublishScheduled(input.Now); err != nil {
+				return err
+			}
+			if err := u.repo.Update(ctx, p); err != nil {
+				return err
+			}
+			return u.dispatcher.DispatchEvents(ctx, p.Events)
+		})
+		if err != nil {
+			// 投稿を取得できなかった場合は DB の障害なので中断する
+			if p == nil {
+				return nil, err
+			}
+			output.Failures = append(output.Failures, ScheduledPublishFailure{PostID: p.ID, Err: err})
+			skip = append(skip, p.ID)
+			continue
+		}
+		if p == nil {
+			break
+		}
+
+		output.Posts = append(output.Posts, p)
+	}
+
+	return output, nil
+}
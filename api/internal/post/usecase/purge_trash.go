@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/event"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type PurgeTrashInput struct {
+	// DeletedBefore is the latest deletion time of the posts to purge.
+	DeletedBefore time.Time `json:"deletedBefore"`
+	// Now is when the purge happens.
+	Now time.Time `json:"now"`
+	// Limit caps the number of posts handled in one call.
+	Limit int `json:"limit"`
+	// Skip lists posts that already failed in this run, so that they are not
+	// picked up again until the next run.
+	Skip []post.PostID `json:"skip"`
+}
+
+type PurgeTrashOutput struct {
+	Posts    []*post.Post   `json:"posts"`
+	Failures []PurgeFailure `json:"failures"`
+}
+
+// PurgeFailure is a post that could not be purged. It stays in the trash and
+// is retried on the next run.
+type PurgeFailure struct {
+	PostID post.PostID `json:"postId"`
+	Err    error       `json:"-"`
+}
+
+// PurgeTrashUsecase permanently removes posts that have stayed in the trash
+// longer than the retention period. It runs on behalf of the system, so no
+// Policy is consulted.
+type PurgeTrashUsecase struct {
+	repo       repository.PostRepository
+	tx         repository.Transactor
+	dispatcher event.EventDispatcher
+}
+
+func NewPurgeTrashUsecase(repo repository.PostRepository, tx repository.Transactor, dispatcher event.EventDispatcher) *PurgeTrashUsecase {
+	return &PurgeTrashUsecase{repo: repo, tx: tx, dispatcher: dispatcher}
+}
+
+// Execute purges each post in its own transaction. A post that fails is
+// reported in Failures and the rest are still purged, so that one bad row
+// cannot keep the whole trash from being emptied.
+func (u *PurgeTrashUsecase) Execute(ctx context.Context, input PurgeTrashInput) (*PurgeTrashOutput, error) {
+	output := &PurgeTrashOutput{}
+	skip := slices.Clone(input.Skip)
+
+	for len(output.Posts)+len(output.Failures) < input.Limit {
+		var p *post.Post
+
+		// 予約投稿の公開と同様に、行ロックで複数レプリカから同じ投稿を二重に削除しない
+		err := u.tx.RunInTx(ctx, func(ctx context.Context) error {
+			p = nil
+
+			trashed, err := u.repo.FindPurgeableForUpdate(ctx, input.DeletedBefore, 1, skip)
+			if err != nil {
+				return err
+			}
+			if len(trashed) == 0 {
+				return nil
+			}
+			p = trashed[0]
+
+			p.Purge(input.Now)
+			if err := u.repo.Purge(ctx, p.ID); err != nil {
+				return err
+			}
+			return u.dispatcher.DispatchEvents(ctx, p.Events)
+		})
+		if err != nil {
+			// 投稿を取得できなかった場合は DB の障害なので中断する
+			if p == nil {
+				return nil, err
+			}
+			output.Failures = append(output.Failures, PurgeFailure{PostID: p.ID, Err: err})
+			skip = append(skip, p.ID)
+			continue
+		}
+		if p == nil {
+			break
+		}
+
+		output.Posts = append(output.Posts, p)
+	}
+
+	return output, nil
+}
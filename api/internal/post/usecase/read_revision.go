@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type ReadRevisionInput struct {
+	ID       string `json:"id"`
+	Revision int    `json:"revision"`
+}
+
+type ReadRevisionOutput struct {
+	Revision *post.Revision `json:"revision"`
+}
+
+// ReadRevisionUsecase returns one revision of a post to those who may read
+// the post.
+type ReadRevisionUsecase struct {
+	repo      repository.PostRepository
+	revisions repository.RevisionRepository
+	policy    Policy
+}
+
+func NewReadRevisionUsecase(repo repository.PostRepository, revisions repository.RevisionRepository, policy Policy) *ReadRevisionUsecase {
+	return &ReadRevisionUsecase{repo: repo, revisions: revisions, policy: policy}
+}
+
+func (u *ReadRevisionUsecase) Execute(ctx context.Context, input ReadRevisionInput, userCtx UserContext) (*ReadRevisionOutput, error) {
+	postID, err := post.ParsePostID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := findReadablePost(ctx, u.repo, u.policy, userCtx, postID); err != nil {
+		return nil, err
+	}
+
+	rev, err := u.revisions.FindByNumber(ctx, postID, input.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReadRevisionOutput{Revision: rev}, nil
+}
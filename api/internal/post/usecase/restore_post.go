@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/event"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type RestorePostInput struct {
+	ID string `json:"id"`
+}
+
+type RestorePostOutput struct {
+	Post *post.Post `json:"post"`
+}
+
+// RestorePostUsecase takes a deleted post out of the trash. Whoever may delete
+// a post may also restore it.
+type RestorePostUsecase struct {
+	repo       repository.PostRepository
+	tx         repository.Transactor
+	dispatcher event.EventDispatcher
+	policy     Policy
+}
+
+func NewRestorePostUsecase(repo repository.PostRepository, tx repository.Transactor, dispatcher event.EventDispatcher, policy Policy) *RestorePostUsecase {
+	return &RestorePostUsecase{repo: repo, tx: tx, dispatcher: dispatcher, policy: policy}
+}
+
+func (u *RestorePostUsecase) Execute(ctx context.Context, input RestorePostInput, userCtx UserContext) (*RestorePostOutput, error) {
+	postID, err := post.ParsePostID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	trashedPost, err := u.repo.FindTrashedByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.policy.Authorize(userCtx, ActionDeletePost, trashedPost); err != nil {
+		return nil, err
+	}
+
+	trashedPost.Restore(userCtx.UserID, time.Now())
+
+	err = u.tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.Restore(ctx, trashedPost); err != nil {
+			return err
+		}
+		return u.dispatcher.DispatchEvents(ctx, trashedPost.Events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RestorePostOutput{Post: trashedPost}, nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/event"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+type ReviewPostInput struct {
+	ID      string            `json:"id"`
+	Action  post.ReviewAction `json:"action"`
+	Comment *string           `json:"comment"`
+}
+
+type ReviewPostOutput struct {
+	Post   *post.Post   `json:"post"`
+	Review *post.Review `json:"review"`
+}
+
+// ReviewPostUsecase moves a post through the approval workflow: whoever may
+// edit a draft may submit it, and reviewers approve or reject it.
+type ReviewPostUsecase struct {
+	repo       repository.PostRepository
+	reviews    repository.ReviewRepository
+	tx         repository.Transactor
+	dispatcher event.EventDispatcher
+	policy     Policy
+	rules      *RuleEngine
+}
+
+func NewReviewPostUsecase(repo repository.PostRepository, reviews repository.ReviewRepository, tx repository.Transactor, dispatcher event.EventDispatcher, policy Policy, rules *RuleEngine) *ReviewPostUsecase {
+	return &ReviewPostUsecase{repo: repo, reviews: reviews, tx: tx, dispatcher: dispatcher, policy: policy, rules: rules}
+}
+
+func (u *ReviewPostUsecase) Execute(ctx context.Context, input ReviewPostInput, userCtx UserContext) (*ReviewPostOutput, error) {
+	postID, err := post.ParsePostID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingPost, err := u.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.policy.Authorize(userCtx, reviewAction(input.Action), existingPost); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var review *post.Review
+	switch input.Action {
+	case post.ReviewActionSubmit:
+		var reasons []post.ReviewReason
+		if reasons, err = u.rules.reviewReasons(ctx, ruleTargetFromPost(existingPost)); err != nil {
+			return nil, err
+		}
+		review, err = existingPost.SubmitForReview(userCtx.UserID, reasons, input.Comment, now)
+	case post.ReviewActionApprove:
+		review, err = existingPost.Approve(userCtx.UserID, input.Comment, now)
+	case post.ReviewActionReject:
+		review, err = existingPost.Reject(userCtx.UserID, input.Comment, now)
+	default:
+		err = errors.New("unknown review action")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 投稿・レビュー記録・イベントは同一トランザクションで書き込む
+	err = u.tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.Update(ctx, existingPost); err != nil {
+			return err
+		}
+		if err := u.reviews.Append(ctx, review); err != nil {
+			return err
+		}
+		return u.dispatcher.DispatchEvents(ctx, existingPost.Events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReviewPostOutput{Post: existingPost, Review: review}, nil
+}
+
+func reviewAction(a post.ReviewAction) Action {
+	if a == post.ReviewActionSubmit {
+		return ActionEditPost
+	}
+	return ActionReviewPost
+}
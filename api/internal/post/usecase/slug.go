@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+const (
+	// maxSlugSuggestions is the number of free slugs offered on a conflict.
+	maxSlugSuggestions = 3
+
+	// maxSlugSuffix bounds the numbered slugs tried for one conflict.
+	maxSlugSuffix = 20
+)
+
+// ensureSlugAvailable checks that no other post uses the slug of p.
+// A slug generated from the title is made unique with a numbered suffix,
+// while a slug chosen by the user is reported as *post.ErrSlugConflict.
+func ensureSlugAvailable(ctx context.Context, repo repository.PostRepository, p *post.Post, generated bool) error {
+	if p.Slug == nil {
+		return nil
+	}
+
+	taken, err := repo.SlugTaken(ctx, *p.Slug, p.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check slug: %w", err)
+	}
+	if !taken {
+		return nil
+	}
+
+	conflict, err := slugConflict(ctx, repo, *p.Slug, p.ID)
+	if err != nil {
+		return err
+	}
+	if generated && len(conflict.Suggestions) > 0 {
+		p.ReplaceGeneratedSlug(conflict.Suggestions[0])
+		return nil
+	}
+
+	return conflict
+}
+
+// slugConflict builds the conflict error for slug, suggesting numbered
+// variants that are still free.
+func slugConflict(ctx context.Context, repo repository.PostRepository, slug string, exclude post.PostID) (*post.ErrSlugConflict, error) {
+	conflict := &post.ErrSlugConflict{Slug: slug, Suggestions: []string{}}
+
+	for n := 2; n <= maxSlugSuffix && len(conflict.Suggestions) < maxSlugSuggestions; n++ {
+		candidate := post.SlugWithSuffix(slug, n)
+		taken, err := repo.SlugTaken(ctx, candidate, exclude)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check slug: %w", err)
+		}
+		if !taken {
+			conflict.Suggestions = append(conflict.Suggestions, candidate)
+		}
+	}
+
+	return conflict, nil
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/event"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+)
+
+// Transition is a publication state change requested through a dedicated
+// HTTP action such as POST /api/posts/{id}/publish.
+type Transition string
+
+const (
+	TransitionPublish    Transition = "publish"
+	TransitionSchedule   Transition = "schedule"
+	TransitionUnschedule Transition = "unschedule"
+	TransitionUnpublish  Transition = "unpublish"
+	TransitionArchive    Transition = "archive"
+	TransitionUnarchive  Transition = "unarchive"
+)
+
+type TransitionPostInput struct {
+	ID          string     `json:"id"`
+	Transition  Transition `json:"transition"`
+	ScheduledAt *time.Time `json:"scheduledAt"`
+}
+
+type TransitionPostOutput struct {
+	Post *post.Post `json:"post"`
+}
+
+// TransitionPostUsecase changes the publication status of a post. Revisions
+// only version the content of a post, so a transition stores none; the status
+// history is kept in the post's events.
+type TransitionPostUsecase struct {
+	repo       repository.PostRepository
+	tx         repository.Transactor
+	dispatcher event.EventDispatcher
+	policy     Policy
+	rules      *RuleEngine
+}
+
+func NewTransitionPostUsecase(repo repository.PostRepository, tx repository.Transactor, dispatcher event.EventDispatcher, policy Policy, rules *RuleEngine) *TransitionPostUsecase {
+	return &TransitionPostUsecase{repo: repo, tx: tx, dispatcher: dispatcher, policy: policy, rules: rules}
+}
+
+func (u *TransitionPostUsecase) Execute(ctx context.Context, input TransitionPostInput, userCtx UserContext) (*TransitionPostOutput, error) {
+	postID, err := post.ParsePostID(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingPost, err := u.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.policy.Authorize(userCtx, transitionAction(input.Transition), existingPost); err != nil {
+		return nil, err
+	}
+
+	// 遷移前の状態をルール検証用に保持する
+	previous := *existingPost
+
+	now := time.Now()
+	if err := applyTransition(existingPost, input, &userCtx.UserID, now); err != nil {
+		return nil, err
+	}
+
+	if err := u.rules.validate(ctx, ruleTargetFromPost(existingPost), now, &previous); err != nil {
+		return nil, err
+	}
+	if err := u.rules.requireApproval(ctx, existingPost); err != nil {
+		return nil, err
+	}
+
+	// 投稿とイベントは同一トランザクションで書き込む
+	err = u.tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := u.repo.Update(ctx, existingPost); err != nil {
+			return err
+		}
+		return u.dispatcher.DispatchEvents(ctx, existingPost.Events)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransitionPostOutput{Post: existingPost}, nil
+}
+
+func transitionAction(t Transition) Action {
+	switch t {
+	case TransitionPublish, TransitionUnpublish:
+		return ActionPublishPost
+	case TransitionSchedule, TransitionUnschedule:
+		return ActionSchedulePost
+	default:
+		return ActionArchivePost
+	}
+}
+
+func applyTransition(p *post.Post, input TransitionPostInput, actorID *post.UserID, now time.Time) error {
+	switch input.Transition {
+	case TransitionPublish:
+		return p.Publish(actorID, now)
+	case TransitionSchedule:
+		if input.ScheduledAt == nil {
+			return post.NewValidationError("scheduledAt", post.ValidationCodeRequired, "scheduled time is required")
+		}
+		return p.Schedule(*input.ScheduledAt, actorID, now)
+	case TransitionUnschedule:
+		return p.Unschedule(actorID, now)
+	case TransitionUnpublish:
+		return p.Unpublish(actorID, now)
+	case TransitionArchive:
+		return p.Archive(actorID, now)
+	case TransitionUnarchive:
+		return p.Unarchive(actorID, now)
+	}
+
+	return errors.New("unknown transition")
+}
@@ -2,16 +2,21 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/ss49919201/myblog/api/internal/post/entity/post"
 	"github.com/ss49919201/myblog/api/internal/post/event"
 	"github.com/ss49919201/myblog/api/internal/post/repository"
 )
 
+// UpdatePostInput carries a JSON Merge Patch (RFC 7396) for a post.
+// Members that were absent from the request are left unset in Patch.
 type UpdatePostInput struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
-	Body  string `json:"body"`
+	ID    string     `json:"id"`
+	Patch post.Patch `json:"-"`
+	// ExpectedVersions are the versions the caller accepts, taken from
+	// If-Match. nil accepts any version.
+	ExpectedVersions []int `json:"-"`
 }
 
 type UpdatePostOutput struct {
@@ -20,14 +25,18 @@ type UpdatePostOutput struct {
 
 type UpdatePostUsecase struct {
 	repo       repository.PostRepository
+	revisions  repository.RevisionRepository
+	tx         repository.Transactor
 	dispatcher event.EventDispatcher
+	policy     Policy
+	rules      *RuleEngine
 }
 
-func NewUpdatePostUsecase(repo repository.PostRepository, dispatcher event.EventDispatcher) *UpdatePostUsecase {
-	return &UpdatePostUsecase{repo: repo, dispatcher: dispatcher}
+func NewUpdatePostUsecase(repo repository.PostRepository, revisions repository.RevisionRepository, tx repository.Transactor, dispatcher event.EventDispatcher, policy Policy, rules *RuleEngine) *UpdatePostUsecase {
+	return &UpdatePostUsecase{repo: repo, revisions: revisions, tx: tx, dispatcher: dispatcher, policy: policy, rules: rules}
 }
 
-func (u *UpdatePostUsecase) Execute(ctx context.Context, input UpdatePostInput) (*UpdatePostOutput, error) {
+func (u *UpdatePostUsecase) Execute(ctx context.Context, input UpdatePostInput, userCtx UserContext) (*UpdatePostOutput, error) {
 	postID, err := post.ParsePostID(input.ID)
 	if err != nil {
 		return nil, err
@@ -38,18 +47,66 @@ func (u *UpdatePostUsecase) Execute(ctx context.Context, input UpdatePostInput)
 		return nil, err
 	}
 
-	if err := existingPost.Update(input.Title, input.Body); err != nil {
+	if err := u.policy.Authorize(userCtx, ActionEditPost, existingPost); err != nil {
 		return nil, err
 	}
 
-	if err := u.repo.Update(ctx, existingPost); err != nil {
+	if err := checkVersion(existingPost, input.ExpectedVersions); err != nil {
 		return nil, err
 	}
 
-	if err := u.dispatcher.DispatchEvents(ctx, existingPost.Events); err != nil {
-		// イベント配信失敗はログに記録するが、処理は続行
-		// TODO: ログ出力を追加
+	now := time.Now()
+	merged, err := existingPost.Patched(input.Patch, userCtx.UserID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if merged.Status != existingPost.Status {
+		if err := authorizeStatus(u.policy, userCtx, merged.Status, existingPost); err != nil {
+			return nil, err
+		}
+	}
+
+	// 作成時と同じルールをマージ後の状態に対して再検証する
+	if err := u.rules.validatePost(ctx, ruleTargetFromPost(merged), now, existingPost); err != nil {
+		return nil, err
+	}
+	if err := u.rules.requireApproval(ctx, merged); err != nil {
+		return nil, err
+	}
+
+	if !post.EqualPtr(merged.Slug, existingPost.Slug) {
+		if err := ensureSlugAvailable(ctx, u.repo, merged, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := saveRevision(ctx, u.repo, u.revisions, u.tx, u.dispatcher, merged, merged.NewRevision(&userCtx.UserID, now)); err != nil {
+		return nil, err
+	}
+
+	return &UpdatePostOutput{Post: merged}, nil
+}
+
+// saveRevision writes p, rev and the events of p in one transaction. A slug
+// taken concurrently is reported with suggestions like in ensureSlugAvailable.
+func saveRevision(ctx context.Context, repo repository.PostRepository, revisions repository.RevisionRepository, tx repository.Transactor, dispatcher event.EventDispatcher, p *post.Post, rev *post.Revision) error {
+	// 投稿・リビジョン・イベントは同一トランザクションで書き込む
+	err := tx.RunInTx(ctx, func(ctx context.Context) error {
+		if err := repo.Update(ctx, p); err != nil {
+			return err
+		}
+		if err := revisions.Append(ctx, rev); err != nil {
+			return err
+		}
+		return dispatcher.DispatchEvents(ctx, p.Events)
+	})
+	if conflict, ok := post.AsErrSlugConflict(err); ok {
+		if conflict, err = slugConflict(ctx, repo, conflict.Slug, p.ID); err != nil {
+			return err
+		}
+		return conflict
 	}
 
-	return &UpdatePostOutput{Post: existingPost}, nil
+	return err
 }
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"slices"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// checkVersion reports *post.ErrVersionConflict unless p is at one of the
+// expected versions. A nil expected accepts any version, which is what an
+// If-Match: * precondition asks for.
+func checkVersion(p *post.Post, expected []int) error {
+	if expected == nil || slices.Contains(expected, p.Version) {
+		return nil
+	}
+
+	return &post.ErrVersionConflict{PostID: p.ID, Current: p.Version}
+}
@@ -0,0 +1,24 @@
+package rule
+
+import "errors"
+
+type ErrCategoryRulesNotFound struct {
+	Category string
+}
+
+func (e *ErrCategoryRulesNotFound) Error() string {
+	return "no rules are stored for category " + e.Category
+}
+
+func AsErrCategoryRulesNotFound(err error) (*ErrCategoryRulesNotFound, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrCategoryRulesNotFound
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,260 @@
+package rule
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// Field is a post field that a rule can require.
+type Field string
+
+const (
+	FieldFeaturedImageURL Field = "featuredImageUrl"
+	FieldScheduledAt      Field = "scheduledAt"
+	FieldMetaDescription  Field = "metaDescription"
+)
+
+func (f Field) Valid() bool {
+	switch f {
+	case FieldFeaturedImageURL, FieldScheduledAt, FieldMetaDescription:
+		return true
+	}
+	return false
+}
+
+// Weekday is a day of the week written as "mon" to "sun".
+type Weekday string
+
+const (
+	Monday    Weekday = "mon"
+	Tuesday   Weekday = "tue"
+	Wednesday Weekday = "wed"
+	Thursday  Weekday = "thu"
+	Friday    Weekday = "fri"
+	Saturday  Weekday = "sat"
+	Sunday    Weekday = "sun"
+)
+
+var weekdays = map[Weekday]time.Weekday{
+	Monday:    time.Monday,
+	Tuesday:   time.Tuesday,
+	Wednesday: time.Wednesday,
+	Thursday:  time.Thursday,
+	Friday:    time.Friday,
+	Saturday:  time.Saturday,
+	Sunday:    time.Sunday,
+}
+
+func (d Weekday) Valid() bool {
+	_, ok := weekdays[d]
+	return ok
+}
+
+// PublishWindow limits immediate publishing to some hours of some days, in
+// the server's local time. Emergency posts are not limited.
+type PublishWindow struct {
+	Weekdays []Weekday `json:"weekdays"`
+	// StartHour is inclusive and EndHour exclusive, so 9 and 18 allow
+	// publishing from 9:00 to 17:59.
+	StartHour int `json:"startHour"`
+	EndHour   int `json:"endHour"`
+}
+
+// Contains reports whether t falls inside the window.
+func (w PublishWindow) Contains(t time.Time) bool {
+	if !slices.ContainsFunc(w.Weekdays, func(d Weekday) bool { return weekdays[d] == t.Weekday() }) {
+		return false
+	}
+	return t.Hour() >= w.StartHour && t.Hour() < w.EndHour
+}
+
+func (w PublishWindow) String() string {
+	days := make([]string, 0, len(w.Weekdays))
+	for _, d := range w.Weekdays {
+		days = append(days, string(d))
+	}
+	return fmt.Sprintf("%d-%d, %v", w.StartHour, w.EndHour, days)
+}
+
+// Rules are the editorial rules for posts. A nil field means the rule is not
+// set at this scope.
+type Rules struct {
+	// RequiredFields must be present for a post to be saved.
+	RequiredFields []Field `json:"requiredFields,omitempty"`
+	MinTags        *int    `json:"minTags,omitempty"`
+	// LeadTimeMinutes is how far in the future a post must be scheduled.
+	LeadTimeMinutes *int `json:"leadTimeMinutes,omitempty"`
+	// DailyQuota is the number of posts that can be scheduled for the same
+	// day in a category.
+	DailyQuota    *int           `json:"dailyQuota,omitempty"`
+	PublishWindow *PublishWindow `json:"publishWindow,omitempty"`
+	// MaxExternalLinks rejects bodies with more external links.
+	MaxExternalLinks *int `json:"maxExternalLinks,omitempty"`
+	// ReviewExternalLinks is the number of external links from which a post
+	// must be approved before it is scheduled or published.
+	ReviewExternalLinks *int `json:"reviewExternalLinks,omitempty"`
+}
+
+// Validate checks that the rules can be evaluated and reports every problem
+// as post.ValidationErrors.
+func (r Rules) Validate() error {
+	var errs post.ValidationErrors
+
+	for _, f := range r.RequiredFields {
+		if !f.Valid() {
+			_ = errs.Add(post.NewValidationError("requiredFields", post.ValidationCodeInvalidValue, fmt.Sprintf("unknown field %q", f)))
+		}
+	}
+
+	counts := []struct {
+		field string
+		value *int
+		min   int
+	}{
+		{"minTags", r.MinTags, 0},
+		{"leadTimeMinutes", r.LeadTimeMinutes, 0},
+		{"dailyQuota", r.DailyQuota, 1},
+		{"maxExternalLinks", r.MaxExternalLinks, 0},
+		{"reviewExternalLinks", r.ReviewExternalLinks, 1},
+	}
+	for _, c := range counts {
+		if c.value != nil && *c.value < c.min {
+			_ = errs.Add(post.NewValidationError(c.field, post.ValidationCodeOutOfRange, fmt.Sprintf("%s must be at least %d", c.field, c.min)))
+		}
+	}
+
+	if w := r.PublishWindow; w != nil {
+		if len(w.Weekdays) == 0 {
+			_ = errs.Add(post.NewValidationError("publishWindow", post.ValidationCodeRequired, "publish window requires at least one weekday"))
+		}
+		for _, d := range w.Weekdays {
+			if !d.Valid() {
+				_ = errs.Add(post.NewValidationError("publishWindow", post.ValidationCodeInvalidValue, fmt.Sprintf("unknown weekday %q", d)))
+			}
+		}
+		if w.StartHour < 0 || w.EndHour > 24 || w.StartHour >= w.EndHour {
+			_ = errs.Add(post.NewValidationError("publishWindow", post.ValidationCodeOutOfRange, "publish window hours must satisfy 0 <= startHour < endHour <= 24"))
+		}
+	}
+
+	return errs.Err()
+}
+
+// Overlay returns r with the rules set in o taking precedence. Required
+// fields are combined.
+func (r Rules) Overlay(o Rules) Rules {
+	merged := r
+	merged.RequiredFields = slices.Clone(r.RequiredFields)
+	for _, f := range o.RequiredFields {
+		if !slices.Contains(merged.RequiredFields, f) {
+			merged.RequiredFields = append(merged.RequiredFields, f)
+		}
+	}
+
+	if o.MinTags != nil {
+		merged.MinTags = o.MinTags
+	}
+	if o.LeadTimeMinutes != nil {
+		merged.LeadTimeMinutes = o.LeadTimeMinutes
+	}
+	if o.DailyQuota != nil {
+		merged.DailyQuota = o.DailyQuota
+	}
+	if o.PublishWindow != nil {
+		merged.PublishWindow = o.PublishWindow
+	}
+	if o.MaxExternalLinks != nil {
+		merged.MaxExternalLinks = o.MaxExternalLinks
+	}
+	if o.ReviewExternalLinks != nil {
+		merged.ReviewExternalLinks = o.ReviewExternalLinks
+	}
+
+	return merged
+}
+
+// RuleSet holds the global rules and the rules of each category.
+type RuleSet struct {
+	Global     Rules            `json:"global"`
+	Categories map[string]Rules `json:"categories"`
+}
+
+// For returns the rules in effect for posts in category: the global rules
+// overlaid with the category's own rules.
+func (s *RuleSet) For(category string) Rules {
+	return s.Global.Overlay(s.Categories[category])
+}
+
+func (s *RuleSet) Clone() *RuleSet {
+	return &RuleSet{Global: s.Global, Categories: maps.Clone(s.Categories)}
+}
+
+// Validate checks every scope of the rule set.
+func (s *RuleSet) Validate() error {
+	var errs post.ValidationErrors
+	_ = errs.Add(s.Global.Validate())
+	for _, category := range slices.Sorted(maps.Keys(s.Categories)) {
+		if category == "" {
+			_ = errs.Add(post.NewValidationError("category", post.ValidationCodeRequired, "category must not be empty"))
+		}
+		_ = errs.Add(s.Categories[category].Validate())
+	}
+	return errs.Err()
+}
+
+// Parse reads a rule set from its JSON form, as used by the rules file
+// given in POST_RULES_FILE.
+func Parse(data []byte) (*RuleSet, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var s RuleSet
+	if err := decoder.Decode(&s); err != nil {
+		return nil, fmt.Errorf("invalid rule set: %w", err)
+	}
+	if s.Categories == nil {
+		s.Categories = map[string]Rules{}
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rule set: %w", err)
+	}
+
+	return &s, nil
+}
+
+// Default returns the rules used when no rules file is configured.
+func Default() *RuleSet {
+	return &RuleSet{
+		Global: Rules{
+			LeadTimeMinutes:     intPtr(30),
+			DailyQuota:          intPtr(5),
+			ReviewExternalLinks: intPtr(10),
+		},
+		Categories: map[string]Rules{
+			"ニュース": {
+				RequiredFields: []Field{FieldFeaturedImageURL},
+				PublishWindow: &PublishWindow{
+					Weekdays:  []Weekday{Monday, Tuesday, Wednesday, Thursday, Friday},
+					StartHour: 9,
+					EndHour:   18,
+				},
+			},
+			"技術": {
+				MinTags: intPtr(2),
+			},
+			"お知らせ": {
+				RequiredFields: []Field{FieldScheduledAt},
+			},
+		},
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
@@ -0,0 +1,130 @@
+package rule
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+func TestRuleSet_For(t *testing.T) {
+	set := Default()
+
+	news := set.For("ニュース")
+	if !slices.Contains(news.RequiredFields, FieldFeaturedImageURL) {
+		t.Errorf("ニュース RequiredFields = %v, want featuredImageUrl", news.RequiredFields)
+	}
+	if news.PublishWindow == nil {
+		t.Error("ニュース PublishWindow = nil, want business hours")
+	}
+	if news.LeadTimeMinutes == nil || *news.LeadTimeMinutes != 30 {
+		t.Errorf("ニュース LeadTimeMinutes = %v, want the global 30", news.LeadTimeMinutes)
+	}
+
+	other := set.For("その他")
+	if other.MinTags != nil || other.PublishWindow != nil || len(other.RequiredFields) != 0 {
+		t.Errorf("For(その他) = %+v, want only the global rules", other)
+	}
+}
+
+func TestRules_Overlay(t *testing.T) {
+	global := Rules{
+		RequiredFields: []Field{FieldMetaDescription},
+		DailyQuota:     intPtr(5),
+		MinTags:        intPtr(1),
+	}
+	category := Rules{
+		RequiredFields: []Field{FieldMetaDescription, FieldScheduledAt},
+		DailyQuota:     intPtr(2),
+	}
+
+	merged := global.Overlay(category)
+
+	if want := []Field{FieldMetaDescription, FieldScheduledAt}; !slices.Equal(merged.RequiredFields, want) {
+		t.Errorf("RequiredFields = %v, want %v", merged.RequiredFields, want)
+	}
+	if *merged.DailyQuota != 2 {
+		t.Errorf("DailyQuota = %d, want 2", *merged.DailyQuota)
+	}
+	if *merged.MinTags != 1 {
+		t.Errorf("MinTags = %d, want 1", *merged.MinTags)
+	}
+	if len(global.RequiredFields) != 1 {
+		t.Errorf("global RequiredFields = %v, want it unchanged", global.RequiredFields)
+	}
+}
+
+func TestRules_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     Rules
+		wantField string
+	}{
+		{name: "defaults", rules: Default().Global},
+		{name: "unknown field", rules: Rules{RequiredFields: []Field{"author"}}, wantField: "requiredFields"},
+		{name: "negative lead time", rules: Rules{LeadTimeMinutes: intPtr(-1)}, wantField: "leadTimeMinutes"},
+		{name: "zero quota", rules: Rules{DailyQuota: intPtr(0)}, wantField: "dailyQuota"},
+		{name: "window without days", rules: Rules{PublishWindow: &PublishWindow{StartHour: 9, EndHour: 18}}, wantField: "publishWindow"},
+		{name: "unknown weekday", rules: Rules{PublishWindow: &PublishWindow{Weekdays: []Weekday{"monday"}, StartHour: 9, EndHour: 18}}, wantField: "publishWindow"},
+		{name: "inverted hours", rules: Rules{PublishWindow: &PublishWindow{Weekdays: []Weekday{Monday}, StartHour: 18, EndHour: 9}}, wantField: "publishWindow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := post.AsErrValidation(err)
+			if !ok {
+				t.Fatalf("Validate() error = %v, want validation error", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestPublishWindow_Contains(t *testing.T) {
+	w := PublishWindow{Weekdays: []Weekday{Monday, Friday}, StartHour: 9, EndHour: 18}
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{at: time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC), want: true},   // 月曜 9:00
+		{at: time.Date(2025, 1, 6, 17, 59, 0, 0, time.UTC), want: true}, // 月曜 17:59
+		{at: time.Date(2025, 1, 6, 18, 0, 0, 0, time.UTC), want: false}, // 月曜 18:00
+		{at: time.Date(2025, 1, 7, 12, 0, 0, 0, time.UTC), want: false}, // 火曜
+		{at: time.Date(2025, 1, 10, 8, 59, 0, 0, time.UTC), want: false},
+	}
+
+	for _, tt := range tests {
+		if got := w.Contains(tt.at); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	set, err := Parse([]byte(`{"global": {"leadTimeMinutes": 60}, "categories": {"技術": {"minTags": 3}}}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := set.For("技術"); *got.MinTags != 3 || *got.LeadTimeMinutes != 60 {
+		t.Errorf("For(技術) = %+v, want minTags 3 and leadTimeMinutes 60", got)
+	}
+
+	if _, err := Parse([]byte(`{"global": {"leadTime": 60}}`)); err == nil {
+		t.Error("Parse() with unknown rule error = nil, want error")
+	}
+	if _, err := Parse([]byte(`{"global": {"dailyQuota": 0}}`)); err == nil {
+		t.Error("Parse() with invalid rule error = nil, want error")
+	}
+}
@@ -0,0 +1,101 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	postrdb "github.com/ss49919201/myblog/api/internal/post/rdb"
+	"github.com/ss49919201/myblog/api/internal/rule/entity/rule"
+	"github.com/ss49919201/myblog/api/internal/rule/repository"
+)
+
+// editorial_rules.scope は "global" か "category:<カテゴリ名>"
+const (
+	globalScope         = "global"
+	categoryScopePrefix = "category:"
+)
+
+type RuleRepositoryImpl struct {
+	db *sql.DB
+	// base is the rule set from the configuration, used for every scope
+	// that has no stored rules.
+	base *rule.RuleSet
+}
+
+func NewRuleRepository(db *sql.DB, base *rule.RuleSet) repository.RuleRepository {
+	return &RuleRepositoryImpl{db: db, base: base}
+}
+
+func (r *RuleRepositoryImpl) Load(ctx context.Context) (*rule.RuleSet, error) {
+	query := `SELECT scope, rules FROM editorial_rules`
+
+	rows, err := postrdb.Conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	set := r.base.Clone()
+	for rows.Next() {
+		var scope string
+		var raw []byte
+		if err := rows.Scan(&scope, &raw); err != nil {
+			return nil, err
+		}
+
+		var rules rule.Rules
+		if err := json.Unmarshal(raw, &rules); err != nil {
+			return nil, fmt.Errorf("invalid rules stored for %s: %w", scope, err)
+		}
+
+		if scope == globalScope {
+			set.Global = rules
+		} else if category, ok := strings.CutPrefix(scope, categoryScopePrefix); ok {
+			set.Categories[category] = rules
+		}
+	}
+
+	return set, rows.Err()
+}
+
+func (r *RuleRepositoryImpl) SaveGlobal(ctx context.Context, rules rule.Rules) error {
+	return r.save(ctx, globalScope, rules)
+}
+
+func (r *RuleRepositoryImpl) SaveCategory(ctx context.Context, category string, rules rule.Rules) error {
+	return r.save(ctx, categoryScopePrefix+category, rules)
+}
+
+func (r *RuleRepositoryImpl) DeleteCategory(ctx context.Context, category string) error {
+	query := `DELETE FROM editorial_rules WHERE scope = ?`
+
+	result, err := postrdb.Conn(ctx, r.db).ExecContext(ctx, query, categoryScopePrefix+category)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return &rule.ErrCategoryRulesNotFound{Category: category}
+	}
+
+	return nil
+}
+
+func (r *RuleRepositoryImpl) save(ctx context.Context, scope string, rules rule.Rules) error {
+	query := `INSERT INTO editorial_rules (scope, rules) VALUES (?, ?) ON DUPLICATE KEY UPDATE rules = VALUES(rules), updated_at = CURRENT_TIMESTAMP`
+
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+
+	_, err = postrdb.Conn(ctx, r.db).ExecContext(ctx, query, scope, raw)
+	return err
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/rule/entity/rule"
+)
+
+type RuleRepository interface {
+	// Load returns the rules in effect: the configured rule set with the
+	// scopes stored through the admin API taking its place.
+	Load(ctx context.Context) (*rule.RuleSet, error)
+	// SaveGlobal replaces the global rules.
+	SaveGlobal(ctx context.Context, rules rule.Rules) error
+	// SaveCategory replaces the rules of category.
+	SaveCategory(ctx context.Context, category string, rules rule.Rules) error
+	// DeleteCategory drops the stored rules of category so that the
+	// configured ones apply again. It returns *rule.ErrCategoryRulesNotFound
+	// when nothing is stored.
+	DeleteCategory(ctx context.Context, category string) error
+}
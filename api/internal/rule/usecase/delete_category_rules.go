@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	postusecase "github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/rule/repository"
+)
+
+type DeleteCategoryRulesInput struct {
+	Category string `json:"category"`
+}
+
+type DeleteCategoryRulesUsecase struct {
+	repo   repository.RuleRepository
+	policy postusecase.Policy
+}
+
+func NewDeleteCategoryRulesUsecase(repo repository.RuleRepository, policy postusecase.Policy) *DeleteCategoryRulesUsecase {
+	return &DeleteCategoryRulesUsecase{repo: repo, policy: policy}
+}
+
+func (u *DeleteCategoryRulesUsecase) Execute(ctx context.Context, input DeleteCategoryRulesInput, userCtx postusecase.UserContext) error {
+	if err := u.policy.Authorize(userCtx, postusecase.ActionManageRules, nil); err != nil {
+		return err
+	}
+
+	return u.repo.DeleteCategory(ctx, input.Category)
+}
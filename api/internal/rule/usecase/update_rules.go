@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	postusecase "github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/rule/entity/rule"
+	"github.com/ss49919201/myblog/api/internal/rule/repository"
+)
+
+type UpdateRulesInput struct {
+	// Category is the category whose rules are replaced, or nil for the
+	// global rules.
+	Category *string    `json:"category"`
+	Rules    rule.Rules `json:"rules"`
+}
+
+type UpdateRulesOutput struct {
+	RuleSet *rule.RuleSet `json:"ruleSet"`
+}
+
+type UpdateRulesUsecase struct {
+	repo   repository.RuleRepository
+	policy postusecase.Policy
+}
+
+func NewUpdateRulesUsecase(repo repository.RuleRepository, policy postusecase.Policy) *UpdateRulesUsecase {
+	return &UpdateRulesUsecase{repo: repo, policy: policy}
+}
+
+func (u *UpdateRulesUsecase) Execute(ctx context.Context, input UpdateRulesInput, userCtx postusecase.UserContext) (*UpdateRulesOutput, error) {
+	if err := u.policy.Authorize(userCtx, postusecase.ActionManageRules, nil); err != nil {
+		return nil, err
+	}
+
+	var errs post.ValidationErrors
+	_ = errs.Add(input.Rules.Validate())
+
+	var category string
+	if input.Category != nil {
+		category = strings.TrimSpace(*input.Category)
+		if category == "" || len(category) > 50 {
+			_ = errs.Add(post.NewValidationError("category", post.ValidationCodeLength, "category must be between 1 and 50 characters"))
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	if input.Category == nil {
+		if err := u.repo.SaveGlobal(ctx, input.Rules); err != nil {
+			return nil, err
+		}
+	} else if err := u.repo.SaveCategory(ctx, category, input.Rules); err != nil {
+		return nil, err
+	}
+
+	set, err := u.repo.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateRulesOutput{RuleSet: set}, nil
+}
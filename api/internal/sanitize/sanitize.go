@@ -0,0 +1,286 @@
+// Package sanitize cleans post bodies written in HTML.
+//
+// Bodies are tokenized with golang.org/x/net/html and only the elements and
+// attributes in the allowlist are kept. Scripts, styles and embedded content
+// are dropped together with their content, event handler attributes are
+// always removed and links may only use the schemes in allowedSchemes.
+// Check reports what Sanitize would remove, so that editors can be told
+// exactly which markup is not allowed before the post is saved.
+package sanitize
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Reason is why a piece of markup is not allowed.
+type Reason string
+
+const (
+	ReasonElement      Reason = "element"
+	ReasonAttribute    Reason = "attribute"
+	ReasonEventHandler Reason = "event_handler"
+	ReasonURL          Reason = "url"
+	ReasonComment      Reason = "comment"
+)
+
+// Issue is markup that Sanitize removes.
+type Issue struct {
+	// Line is the 1-based line of the body the markup starts on.
+	Line      int
+	Element   string
+	Attribute string
+	Reason    Reason
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message())
+}
+
+// Message describes the issue without its line, for bodies whose lines do
+// not match the HTML that was checked.
+func (i Issue) Message() string {
+	switch i.Reason {
+	case ReasonElement:
+		return fmt.Sprintf("element <%s> is not allowed", i.Element)
+	case ReasonEventHandler:
+		return fmt.Sprintf("event handler %s on <%s> is not allowed", i.Attribute, i.Element)
+	case ReasonURL:
+		return fmt.Sprintf("URL in %s of <%s> uses a scheme that is not allowed", i.Attribute, i.Element)
+	case ReasonComment:
+		return "HTML comments are not allowed"
+	}
+	return fmt.Sprintf("attribute %s on <%s> is not allowed", i.Attribute, i.Element)
+}
+
+// globalAttributes are allowed on every allowed element.
+var globalAttributes = []string{"title", "class", "id", "lang", "role"}
+
+// allowedElements maps each allowed element to its own allowed attributes.
+var allowedElements = map[string][]string{
+	"p": nil, "br": nil, "hr": nil, "div": nil, "span": nil, "section": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"blockquote": {"cite"}, "pre": nil, "code": nil, "kbd": nil, "samp": nil,
+	"em": nil, "strong": nil, "b": nil, "i": nil, "u": nil, "s": nil,
+	"del": nil, "ins": nil, "sub": nil, "sup": nil, "mark": nil, "small": nil,
+	"abbr": nil, "q": {"cite"}, "cite": nil, "time": {"datetime"},
+	"ul": nil, "ol": {"start", "reversed"}, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+	"a":          {"href", "rel"},
+	"img":        {"src", "alt", "width", "height"},
+	"figure":     nil,
+	"figcaption": nil,
+	"table":      nil, "caption": nil, "thead": nil, "tbody": nil, "tfoot": nil, "tr": nil,
+	"th": {"align", "colspan", "rowspan", "scope"}, "td": {"align", "colspan", "rowspan"},
+	// タスクリストのチェックボックス
+	"input": {"type", "checked", "disabled"},
+}
+
+// voidElements have no content and no end tag.
+var voidElements = map[string]bool{"br": true, "hr": true, "img": true, "input": true}
+
+// droppedElements are removed together with everything inside them.
+var droppedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true,
+	"iframe": true, "frame": true, "frameset": true, "object": true, "embed": true,
+	"applet": true, "svg": true, "math": true, "textarea": true, "select": true,
+	"title": true, "xmp": true, "plaintext": true, "noembed": true, "noframes": true,
+}
+
+// urlAttributes hold URLs whose scheme is checked.
+var urlAttributes = map[string]bool{"href": true, "src": true, "cite": true}
+
+// allowedSchemes are the schemes absolute URLs may use. Relative URLs and
+// fragments are always allowed.
+var allowedSchemes = []string{"http", "https", "mailto"}
+
+// tokenValue limits class, id and role to names that cannot break out of
+// the attribute or smuggle CSS. Footnote ids such as "fn:1" use a colon.
+var tokenValue = regexp.MustCompile(`^[A-Za-z0-9_\-:. ]*$`)
+
+// Sanitize returns body with every markup that is not allowed removed. The
+// text of removed elements is kept, except for scripts, styles and embedded
+// content. The result is always well formed: end tags without a start tag are
+// dropped and open elements are closed at the end.
+func Sanitize(body string) string {
+	out, _ := run(body)
+	return out
+}
+
+// Check lists the markup in body that Sanitize would remove, in document
+// order. It returns nil for bodies that are already clean.
+func Check(body string) []Issue {
+	_, issues := run(body)
+	return issues
+}
+
+// ContainsMarkup reports whether s holds any HTML tag or comment, for plain
+// text fields such as titles. Characters like "&" and "<" that do not form a
+// tag are not markup.
+func ContainsMarkup(s string) bool {
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return false
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken, html.CommentToken, html.DoctypeToken:
+			return true
+		}
+	}
+}
+
+func run(body string) (string, []Issue) {
+	var (
+		buf    bytes.Buffer
+		issues []Issue
+		open   []string
+		// dropping は中身ごと削除している要素の名前
+		dropping string
+		depth    int
+		line     = 1
+	)
+
+	z := html.NewTokenizer(strings.NewReader(body))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		tokenLine := line
+		line += bytes.Count(z.Raw(), []byte("\n"))
+
+		token := z.Token()
+
+		if dropping != "" {
+			switch {
+			case tt == html.StartTagToken && token.Data == dropping:
+				depth++
+			case tt == html.EndTagToken && token.Data == dropping:
+				depth--
+				if depth == 0 {
+					dropping = ""
+				}
+			}
+			continue
+		}
+
+		switch tt {
+		case html.TextToken:
+			buf.WriteString(html.EscapeString(token.Data))
+
+		case html.CommentToken:
+			issues = append(issues, Issue{Line: tokenLine, Reason: ReasonComment})
+
+		case html.DoctypeToken:
+			issues = append(issues, Issue{Line: tokenLine, Element: "!doctype", Reason: ReasonElement})
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedElements[token.Data] {
+				issues = append(issues, Issue{Line: tokenLine, Element: token.Data, Reason: ReasonElement})
+				if tt == html.StartTagToken {
+					dropping, depth = token.Data, 1
+				}
+				continue
+			}
+			allowed, ok := allowedElements[token.Data]
+			if !ok {
+				issues = append(issues, Issue{Line: tokenLine, Element: token.Data, Reason: ReasonElement})
+				continue
+			}
+
+			attrs, attrIssues := filterAttributes(token.Data, token.Attr, allowed, tokenLine)
+			issues = append(issues, attrIssues...)
+			token.Attr = attrs
+			token.Type = html.StartTagToken
+			buf.WriteString(token.String())
+
+			if !voidElements[token.Data] {
+				open = append(open, token.Data)
+			}
+
+		case html.EndTagToken:
+			if _, ok := allowedElements[token.Data]; !ok || voidElements[token.Data] {
+				continue
+			}
+			// 対応する開始タグがなければ捨て、間の要素は閉じる
+			i := lastIndex(open, token.Data)
+			if i < 0 {
+				continue
+			}
+			for len(open) > i {
+				buf.WriteString("</" + open[len(open)-1] + ">")
+				open = open[:len(open)-1]
+			}
+		}
+	}
+
+	for len(open) > 0 {
+		buf.WriteString("</" + open[len(open)-1] + ">")
+		open = open[:len(open)-1]
+	}
+
+	return buf.String(), issues
+}
+
+func filterAttributes(element string, attrs []html.Attribute, allowed []string, line int) ([]html.Attribute, []Issue) {
+	var (
+		kept   []html.Attribute
+		issues []Issue
+	)
+
+	for _, attr := range attrs {
+		name := attr.Key
+		switch {
+		case strings.HasPrefix(name, "on"):
+			issues = append(issues, Issue{Line: line, Element: element, Attribute: name, Reason: ReasonEventHandler})
+		case !slices.Contains(globalAttributes, name) && !slices.Contains(allowed, name):
+			issues = append(issues, Issue{Line: line, Element: element, Attribute: name, Reason: ReasonAttribute})
+		case urlAttributes[name] && !safeURL(attr.Val):
+			issues = append(issues, Issue{Line: line, Element: element, Attribute: name, Reason: ReasonURL})
+		case (name == "class" || name == "id" || name == "role") && !tokenValue.MatchString(attr.Val):
+			issues = append(issues, Issue{Line: line, Element: element, Attribute: name, Reason: ReasonAttribute})
+		case element == "input" && name == "type" && attr.Val != "checkbox":
+			issues = append(issues, Issue{Line: line, Element: element, Attribute: name, Reason: ReasonAttribute})
+		default:
+			kept = append(kept, html.Attribute{Key: name, Val: attr.Val})
+		}
+	}
+
+	return kept, issues
+}
+
+// safeURL reports whether s is relative or uses an allowed scheme. Browsers
+// ignore control characters and white space inside a scheme, so they are
+// removed before parsing: "java\tscript:" is a javascript: URL.
+func safeURL(s string) bool {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+
+	u, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		// "//host" はスキームを引き継ぐので許可する
+		return true
+	}
+	return slices.Contains(allowedSchemes, strings.ToLower(u.Scheme))
+}
+
+func lastIndex(s []string, v string) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == v {
+			return i
+		}
+	}
+	return -1
+}
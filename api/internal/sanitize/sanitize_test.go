@@ -0,0 +1,84 @@
+package sanitize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "allowed markup", in: `<p>本文 <strong>強調</strong> <a href="https://example.com/a?b=1&amp;c=2">link</a></p>`, want: `<p>本文 <strong>強調</strong> <a href="https://example.com/a?b=1&amp;c=2">link</a></p>`},
+		{name: "script with content", in: `<p>a</p><script>alert("x")</script><p>b</p>`, want: `<p>a</p><p>b</p>`},
+		{name: "style with content", in: `<style>p{color:red}</style>text`, want: `text`},
+		{name: "unknown element keeps text", in: `<marquee>moving</marquee>`, want: `moving`},
+		{name: "event handler", in: `<img src="/a.png" onerror="alert(1)" alt="a">`, want: `<img src="/a.png" alt="a">`},
+		{name: "javascript url", in: `<a href="javascript:alert(1)">x</a>`, want: `<a>x</a>`},
+		{name: "obfuscated javascript url", in: `<a href="java&#09;script:alert(1)">x</a>`, want: `<a>x</a>`},
+		{name: "data url", in: `<img src="data:image/svg+xml;base64,AAAA">`, want: `<img>`},
+		{name: "relative url", in: `<a href="/posts/hello#top">x</a>`, want: `<a href="/posts/hello#top">x</a>`},
+		{name: "style attribute", in: `<p style="position:fixed">x</p>`, want: `<p>x</p>`},
+		{name: "class for code", in: `<pre><code class="language-go">x := 1</code></pre>`, want: `<pre><code class="language-go">x := 1</code></pre>`},
+		{name: "bad class", in: `<span class="a&quot;b">x</span>`, want: `<span>x</span>`},
+		{name: "task list checkbox", in: `<input type="checkbox" checked disabled>`, want: `<input type="checkbox" checked="" disabled="">`},
+		{name: "text input", in: `<input type="text">`, want: `<input>`},
+		{name: "comment", in: `a<!-- hidden -->b`, want: `ab`},
+		{name: "text is escaped", in: `a < b & c`, want: `a &lt; b &amp; c`},
+		{name: "unclosed elements", in: `<ul><li><em>x`, want: `<ul><li><em>x</em></li></ul>`},
+		{name: "stray end tag", in: `x</div></p>`, want: `x`},
+		{name: "misnested", in: `<p><em>x</p>y`, want: `<p><em>x</em></p>y`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.in); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	body := "<p>ok</p>\n<p onclick=\"x()\">a</p>\n\n<script>\nalert(1)\n</script>\n<a href=\"vbscript:x\">b</a>"
+	want := []Issue{
+		{Line: 2, Element: "p", Attribute: "onclick", Reason: ReasonEventHandler},
+		{Line: 4, Element: "script", Reason: ReasonElement},
+		{Line: 7, Element: "a", Attribute: "href", Reason: ReasonURL},
+	}
+
+	if got := Check(body); !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() = %+v, want %+v", got, want)
+	}
+	if got := Check("<p>clean <em>body</em></p>\nplain text"); got != nil {
+		t.Errorf("Check() = %+v, want nil", got)
+	}
+}
+
+func TestIssue_String(t *testing.T) {
+	issue := Issue{Line: 3, Element: "img", Attribute: "onerror", Reason: ReasonEventHandler}
+	if got, want := issue.String(), "line 3: event handler onerror on <img> is not allowed"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestContainsMarkup(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{in: `Tom & Jerry の "名作"`, want: false},
+		{in: `1 < 2 > 0`, want: false},
+		{in: `<b>bold</b>`, want: true},
+		{in: `title<script>`, want: true},
+		{in: `a <!-- b -->`, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := ContainsMarkup(tt.in); got != tt.want {
+			t.Errorf("ContainsMarkup(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// postETag is the strong entity tag of the post's current version.
+func postETag(p *post.Post) string {
+	return `"` + strconv.Itoa(p.Version) + `"`
+}
+
+// etagList splits an If-Match or If-None-Match header into its entity tags.
+func etagList(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// notModified reports whether an If-None-Match header lists etag. Tags are
+// compared weakly as RFC 9110 requires for If-None-Match, so W/"3" matches
+// "3".
+func notModified(ifNoneMatch *string, etag string) bool {
+	if ifNoneMatch == nil {
+		return false
+	}
+
+	for _, tag := range etagList(*ifNoneMatch) {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// versionsFromIfMatch parses an If-Match header into the post versions it
+// accepts. "*" accepts any version and is returned as nil. Weak and foreign
+// tags can never match a strong comparison and are skipped, so ok is false
+// when nothing in the header can match.
+func versionsFromIfMatch(ifMatch string) (versions []int, ok bool) {
+	for _, tag := range etagList(ifMatch) {
+		if tag == "*" {
+			return nil, true
+		}
+
+		unquoted, found := strings.CutPrefix(tag, `"`)
+		if !found {
+			continue
+		}
+		unquoted, found = strings.CutSuffix(unquoted, `"`)
+		if !found {
+			continue
+		}
+		version, err := strconv.Atoi(unquoted)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, len(versions) > 0
+}
+
+// requireIfMatch returns the versions a write may change. Writes without
+// If-Match are rejected with 428 so that no client overwrites a post it has
+// not read, and a header that matches no version is rejected with 412.
+func requireIfMatch(c *gin.Context, ifMatch *string) ([]int, bool) {
+	if ifMatch == nil {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header is required"})
+		return nil, false
+	}
+
+	versions, ok := versionsFromIfMatch(*ifMatch)
+	if !ok {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match does not match the post"})
+		return nil, false
+	}
+
+	return versions, true
+}
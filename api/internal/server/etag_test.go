@@ -0,0 +1,61 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNotModified(t *testing.T) {
+	header := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		ifNoneMatch *string
+		want        bool
+	}{
+		{"no header", nil, false},
+		{"same version", header(`"3"`), true},
+		{"weak tag of same version", header(`W/"3"`), true},
+		{"one of several", header(`"1", "3"`), true},
+		{"any", header(`*`), true},
+		{"older version", header(`"2"`), false},
+		{"unquoted", header(`3`), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notModified(tt.ifNoneMatch, `"3"`); got != tt.want {
+				t.Errorf("notModified() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionsFromIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    []int
+		wantOk  bool
+	}{
+		{"single", `"3"`, []int{3}, true},
+		{"several", `"3", "4"`, []int{3, 4}, true},
+		{"any", `*`, nil, true},
+		{"weak tags never match", `W/"3"`, nil, false},
+		{"foreign tags are skipped", `"abc", "5"`, []int{5}, true},
+		{"unquoted", `3`, nil, false},
+		{"empty", ``, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := versionsFromIfMatch(tt.ifMatch)
+			if ok != tt.wantOk {
+				t.Errorf("versionsFromIfMatch() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("versionsFromIfMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
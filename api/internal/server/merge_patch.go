@@ -0,0 +1,111 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// decodePostMergePatch decodes an application/merge-patch+json document
+// (RFC 7396) for a Post.
+//
+// Absent members are left unset. An explicit null clears nullable members
+// (scheduledAt, featuredImageURL, metaDescription, slug) and resets members
+// with a natural default (category, tags and the flags) to that default.
+// Title, body, body format and status cannot be removed.
+func decodePostMergePatch(raw []byte) (post.Patch, error) {
+	var patch post.Patch
+
+	// RFC 7396 ではオブジェクト以外のパッチは対象全体の置換を意味するため受け付けない
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		return patch, post.NewValidationError("body", post.ValidationCodeInvalidType, "merge patch must be a JSON object")
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &members); err != nil {
+		return patch, post.NewValidationError("body", post.ValidationCodeMalformed, "merge patch is not valid JSON")
+	}
+
+	for name, value := range members {
+		var err error
+		switch name {
+		case "title":
+			patch.Title, err = decodeRequired[string](name, value)
+		case "body":
+			patch.Body, err = decodeRequired[string](name, value)
+		case "bodyFormat":
+			patch.BodyFormat, err = decodeRequired[post.BodyFormat](name, value)
+		case "status":
+			patch.Status, err = decodeRequired[post.PublicationStatus](name, value)
+		case "scheduledAt":
+			patch.ScheduledAt, err = decodeNullable[time.Time](name, value)
+		case "category":
+			patch.Category, err = decodeDefaulted[string](name, value)
+		case "tags":
+			patch.Tags, err = decodeDefaulted[[]string](name, value)
+		case "featuredImageURL":
+			patch.FeaturedImageURL, err = decodeNullable[string](name, value)
+		case "metaDescription":
+			patch.MetaDescription, err = decodeNullable[string](name, value)
+		case "slug":
+			patch.Slug, err = decodeNullable[string](name, value)
+		case "snsAutoPost":
+			patch.SNSAutoPost, err = decodeDefaulted[bool](name, value)
+		case "externalNotification":
+			patch.ExternalNotification, err = decodeDefaulted[bool](name, value)
+		case "emergencyFlag":
+			patch.EmergencyFlag, err = decodeDefaulted[bool](name, value)
+		case "id", "renderedBody", "createdAt", "publishedAt", "authorId", "lastEditorId":
+			err = post.NewValidationError(name, post.ValidationCodeReadOnly, name+" is read-only")
+		default:
+			err = post.NewValidationError(name, post.ValidationCodeUnknownField, "unknown field")
+		}
+		if err != nil {
+			return post.Patch{}, err
+		}
+	}
+
+	return patch, nil
+}
+
+func isNull(value json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+}
+
+func decodeRequired[T any](name string, value json.RawMessage) (post.Field[T], error) {
+	if isNull(value) {
+		return post.Field[T]{}, post.NewValidationError(name, post.ValidationCodeNotRemovable, name+" cannot be removed")
+	}
+
+	var v T
+	if err := json.Unmarshal(value, &v); err != nil {
+		return post.Field[T]{}, post.NewValidationError(name, post.ValidationCodeInvalidType, name+" has an invalid type")
+	}
+	return post.SetField(v), nil
+}
+
+func decodeDefaulted[T any](name string, value json.RawMessage) (post.Field[T], error) {
+	var v T
+	if isNull(value) {
+		return post.SetField(v), nil
+	}
+
+	if err := json.Unmarshal(value, &v); err != nil {
+		return post.Field[T]{}, post.NewValidationError(name, post.ValidationCodeInvalidType, name+" has an invalid type")
+	}
+	return post.SetField(v), nil
+}
+
+func decodeNullable[T any](name string, value json.RawMessage) (post.Field[*T], error) {
+	if isNull(value) {
+		return post.SetField[*T](nil), nil
+	}
+
+	var v T
+	if err := json.Unmarshal(value, &v); err != nil {
+		return post.Field[*T]{}, post.NewValidationError(name, post.ValidationCodeInvalidType, name+" has an invalid type")
+	}
+	return post.SetField(&v), nil
+}
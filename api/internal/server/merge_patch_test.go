@@ -0,0 +1,91 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+func TestDecodePostMergePatch(t *testing.T) {
+	t.Run("absent members stay unset", func(t *testing.T) {
+		patch, err := decodePostMergePatch([]byte(`{"title":"New Title"}`))
+		if err != nil {
+			t.Fatalf("decodePostMergePatch() error = %v", err)
+		}
+		if !patch.Title.Set || patch.Title.Value != "New Title" {
+			t.Errorf("Title = %+v, want set to %q", patch.Title, "New Title")
+		}
+		if patch.Body.Set || patch.Slug.Set || patch.Tags.Set {
+			t.Errorf("decodePostMergePatch() set members that were absent: %+v", patch)
+		}
+	})
+
+	t.Run("null clears nullable members and resets defaults", func(t *testing.T) {
+		patch, err := decodePostMergePatch([]byte(`{"slug":null,"scheduledAt":null,"tags":null,"snsAutoPost":null}`))
+		if err != nil {
+			t.Fatalf("decodePostMergePatch() error = %v", err)
+		}
+		if !patch.Slug.Set || patch.Slug.Value != nil {
+			t.Errorf("Slug = %+v, want set to nil", patch.Slug)
+		}
+		if !patch.ScheduledAt.Set || patch.ScheduledAt.Value != nil {
+			t.Errorf("ScheduledAt = %+v, want set to nil", patch.ScheduledAt)
+		}
+		if !patch.Tags.Set || patch.Tags.Value != nil {
+			t.Errorf("Tags = %+v, want set to nil", patch.Tags)
+		}
+		if !patch.SNSAutoPost.Set || patch.SNSAutoPost.Value {
+			t.Errorf("SNSAutoPost = %+v, want set to false", patch.SNSAutoPost)
+		}
+	})
+
+	t.Run("values are decoded", func(t *testing.T) {
+		patch, err := decodePostMergePatch([]byte(`{"status":"scheduled","scheduledAt":"2024-01-01T10:00:00Z","metaDescription":"desc","emergencyFlag":true,"bodyFormat":"plain"}`))
+		if err != nil {
+			t.Fatalf("decodePostMergePatch() error = %v", err)
+		}
+		if patch.Status.Value != post.StatusScheduled {
+			t.Errorf("Status = %v, want %v", patch.Status.Value, post.StatusScheduled)
+		}
+		if patch.ScheduledAt.Value == nil || patch.ScheduledAt.Value.Hour() != 10 {
+			t.Errorf("ScheduledAt = %v, want 10:00", patch.ScheduledAt.Value)
+		}
+		if patch.MetaDescription.Value == nil || *patch.MetaDescription.Value != "desc" {
+			t.Errorf("MetaDescription = %v, want %q", patch.MetaDescription.Value, "desc")
+		}
+		if !patch.EmergencyFlag.Value {
+			t.Error("EmergencyFlag = false, want true")
+		}
+		if patch.BodyFormat.Value != post.BodyFormatPlain {
+			t.Errorf("BodyFormat = %v, want %v", patch.BodyFormat.Value, post.BodyFormatPlain)
+		}
+	})
+
+	errorCases := []struct {
+		name  string
+		raw   string
+		field string
+	}{
+		{name: "non-object patch", raw: `["title"]`, field: "body"},
+		{name: "invalid json", raw: `{"title":`, field: "body"},
+		{name: "removing title", raw: `{"title":null}`, field: "title"},
+		{name: "wrong type", raw: `{"tags":"go"}`, field: "tags"},
+		{name: "removing body format", raw: `{"bodyFormat":null}`, field: "bodyFormat"},
+		{name: "read-only member", raw: `{"createdAt":"2024-01-01T10:00:00Z"}`, field: "createdAt"},
+		{name: "rendered body is read-only", raw: `{"renderedBody":"<p>x</p>"}`, field: "renderedBody"},
+		{name: "unknown member", raw: `{"name":"x"}`, field: "name"},
+	}
+
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodePostMergePatch([]byte(tt.raw))
+			validationErr, ok := post.AsErrValidation(err)
+			if !ok {
+				t.Fatalf("decodePostMergePatch() error = %v, want validation error", err)
+			}
+			if validationErr.Field != tt.field {
+				t.Errorf("Field = %q, want %q", validationErr.Field, tt.field)
+			}
+		})
+	}
+}
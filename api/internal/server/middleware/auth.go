@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/user/token"
+)
+
+const userContextKey = "middleware.userContext"
+
+// TokenVerifier verifies a raw bearer token and returns its claims.
+type TokenVerifier interface {
+	Verify(raw string) (*token.Claims, error)
+}
+
+// Authenticate resolves the bearer token into a usecase.UserContext.
+// It is registered as an openapi operation middleware so that it runs after
+// the generated wrapper has marked operations secured with BearerAuth;
+// those operations are rejected with 401 when no valid token is presented.
+func Authenticate(verifier TokenVerifier) openapi.MiddlewareFunc {
+	return func(c *gin.Context) {
+		_, required := c.Get(openapi.BearerAuthScopes)
+
+		raw, found := bearerToken(c.GetHeader("Authorization"))
+		if !found {
+			if required {
+				abortUnauthorized(c)
+			}
+			return
+		}
+
+		claims, err := verifier.Verify(raw)
+		if err != nil {
+			abortUnauthorized(c)
+			return
+		}
+
+		userID, err := post.ParseUserID(claims.Subject)
+		if err != nil {
+			abortUnauthorized(c)
+			return
+		}
+
+		c.Set(userContextKey, usecase.UserContext{
+			UserID: userID,
+			Role:   post.UserRole(claims.Role),
+		})
+	}
+}
+
+// UserContextFrom returns the identity resolved by Authenticate.
+func UserContextFrom(c *gin.Context) (usecase.UserContext, bool) {
+	v, ok := c.Get(userContextKey)
+	if !ok {
+		return usecase.UserContext{}, false
+	}
+
+	userCtx, ok := v.(usecase.UserContext)
+	return userCtx, ok
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, raw, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || raw == "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(raw), true
+}
+
+func abortUnauthorized(c *gin.Context) {
+	c.Header("WWW-Authenticate", `Bearer realm="myblog"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, openapi.Error{
+		Code:    http.StatusUnauthorized,
+		Message: "authentication required",
+	})
+}
@@ -6,7 +6,11 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/openapi"
 	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/rule/entity/rule"
+	"github.com/ss49919201/myblog/api/internal/user/entity/user"
+	"github.com/ss49919201/myblog/api/internal/webhook/entity/webhook"
 )
 
 // ErrorHandler processes errors registered with c.Error() and panic recovery
@@ -44,7 +48,7 @@ func Recovery() gin.HandlerFunc {
 func handleError(c *gin.Context, err error) {
 	// Check for validation errors
 	if post.IsErrValidation(err) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, ValidationErrorsResponse(err))
 		c.Abort()
 		slog.Warn("validation error", slog.String("err", err.Error()))
 		return
@@ -57,8 +61,121 @@ func handleError(c *gin.Context, err error) {
 		return
 	}
 
+	if _, ok := post.AsErrRevisionNotFound(err); ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "revision not found"})
+		c.Abort()
+		slog.Warn("revision not found", slog.String("err", err.Error()))
+		return
+	}
+
+	if _, ok := rule.AsErrCategoryRulesNotFound(err); ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Abort()
+		slog.Warn("category rules not found", slog.String("err", err.Error()))
+		return
+	}
+
+	if _, ok := webhook.AsErrEndpointNotFound(err); ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "webhook endpoint not found"})
+		c.Abort()
+		slog.Warn("webhook endpoint not found", slog.String("err", err.Error()))
+		return
+	}
+
+	if _, ok := post.AsErrForbidden(err); ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		c.Abort()
+		slog.Warn("forbidden", slog.String("err", err.Error()))
+		return
+	}
+
+	if _, ok := post.AsErrInvalidTransition(err); ok {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		c.Abort()
+		slog.Warn("invalid transition", slog.String("err", err.Error()))
+		return
+	}
+
+	if conflict, ok := post.AsErrSlugConflict(err); ok {
+		c.JSON(http.StatusConflict, openapi.SlugConflictError{
+			Code:        http.StatusConflict,
+			Message:     err.Error(),
+			Slug:        conflict.Slug,
+			Suggestions: conflict.Suggestions,
+		})
+		c.Abort()
+		slog.Warn("slug conflict", slog.String("err", err.Error()))
+		return
+	}
+
+	if _, ok := post.AsErrInvalidReviewTransition(err); ok {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		c.Abort()
+		slog.Warn("invalid review transition", slog.String("err", err.Error()))
+		return
+	}
+
+	if required, ok := post.AsErrReviewRequired(err); ok {
+		reasons := make([]string, 0, len(required.Reasons))
+		for _, r := range required.Reasons {
+			reasons = append(reasons, string(r))
+		}
+		c.JSON(http.StatusConflict, openapi.ReviewRequiredError{
+			Code:         http.StatusConflict,
+			Message:      err.Error(),
+			ReviewStatus: openapi.ReviewStatus(required.ReviewStatus),
+			Reasons:      reasons,
+		})
+		c.Abort()
+		slog.Warn("review required", slog.String("err", err.Error()))
+		return
+	}
+
+	if _, ok := post.AsErrVersionConflict(err); ok {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		c.Abort()
+		slog.Warn("version conflict", slog.String("err", err.Error()))
+		return
+	}
+
+	if _, ok := user.AsErrUnauthenticated(err); ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		c.Abort()
+		slog.Warn("unauthenticated", slog.String("err", err.Error()))
+		return
+	}
+
+	if _, ok := user.AsErrInvalidCredentials(err); ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
+		c.Abort()
+		slog.Warn("invalid credentials", slog.String("err", err.Error()))
+		return
+	}
+
 	// Default to internal server error for unhandled errors
 	c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 	c.Abort()
 	slog.Error("internal error", slog.String("err", err.Error()))
 }
+
+// ValidationErrorsResponse lists every validation error in err, so that
+// clients can point out all bad fields at once.
+func ValidationErrorsResponse(err error) openapi.ValidationErrors {
+	all := post.ValidationErrorsOf(err)
+
+	errs := make([]openapi.ValidationError, 0, len(all))
+	for _, e := range all {
+		errs = append(errs, openapi.ValidationError{
+			Code:      http.StatusBadRequest,
+			ErrorCode: string(e.Code),
+			Field:     e.Field,
+			Message:   e.Message,
+		})
+	}
+
+	return openapi.ValidationErrors{
+		Code:    http.StatusBadRequest,
+		Message: "Validation failed",
+		Errors:  errs,
+	}
+}
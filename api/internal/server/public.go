@@ -0,0 +1,167 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/rdb"
+	"github.com/ss49919201/myblog/api/internal/sanitize"
+)
+
+// PublicPostsList lists the posts readers can see. It needs no
+// authentication and never returns drafts, scheduled or archived posts.
+func (s *Server) PublicPostsList(c *gin.Context, params openapi.PublicPostsListParams) {
+	query, err := publicPostListQuery(params, s.clock.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, openapi.Error{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	db, err := s.container.DB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database connection failed"})
+		return
+	}
+
+	page, err := rdb.FindPostPage(c.Request.Context(), db, query)
+	if err != nil {
+		if errors.Is(err, rdb.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, openapi.Error{
+				Code:    http.StatusBadRequest,
+				Message: "invalid cursor",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	items := make([]openapi.PublicPost, 0, len(page.Items))
+	for _, p := range page.Items {
+		items = append(items, toOpenAPIPublicPost(p))
+	}
+
+	c.JSON(http.StatusOK, openapi.PublicPostList{
+		Items:      items,
+		NextCursor: page.NextCursor,
+	})
+}
+
+// PublicPostsRead returns a published post by its slug, or by its id when
+// the post has no slug. A slug the post used before is redirected to the
+// current one.
+func (s *Server) PublicPostsRead(c *gin.Context, slug string) {
+	db, err := s.container.DB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database connection failed"})
+		return
+	}
+
+	now := s.clock.Now()
+	found, err := rdb.FindPublicPost(c.Request.Context(), db, slug, now)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || err.Error() == "post not found" {
+			find := func(id post.PostID) (*post.Post, error) {
+				return rdb.FindPublicPostByID(c.Request.Context(), db, id, now)
+			}
+			if redirectOldSlug(c, db, slug, find, publicPostPath) {
+				return
+			}
+			c.JSON(http.StatusNotFound, openapi.Error{
+				Code:    http.StatusNotFound,
+				Message: "post not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIPublicPost(found))
+}
+
+// publicPostListQuery validates the public list parameters and converts them
+// into a query limited to the posts published at now.
+func publicPostListQuery(params openapi.PublicPostsListParams, now time.Time) (rdb.PostListQuery, error) {
+	query := rdb.PostListQuery{
+		Sort:  rdb.PostSortPublishedAtDesc,
+		Limit: rdb.DefaultPostListLimit,
+		Filter: rdb.PublicPostFilter(rdb.PostListFilter{
+			Category: params.Category,
+			Tag:      params.Tag,
+		}, now),
+	}
+
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > rdb.MaxPostListLimit {
+			return rdb.PostListQuery{}, fmt.Errorf("limit must be between 1 and %d", rdb.MaxPostListLimit)
+		}
+		query.Limit = int(*params.Limit)
+	}
+	if params.Cursor != nil {
+		query.Cursor = *params.Cursor
+	}
+	if params.Sort != nil {
+		switch *params.Sort {
+		case openapi.PublicPostSortMinusPublishedAt:
+			query.Sort = rdb.PostSortPublishedAtDesc
+		case openapi.PublicPostSortPublishedAt:
+			query.Sort = rdb.PostSortPublishedAtAsc
+		default:
+			return rdb.PostListQuery{}, errors.New("sort must be one of -publishedAt, publishedAt")
+		}
+	}
+
+	return query, nil
+}
+
+// toOpenAPIPublicPost leaves out the editorial fields of p, such as its
+// flags, schedule and authors. The body is served as its rendered HTML.
+func toOpenAPIPublicPost(p *post.Post) openapi.PublicPost {
+	tags := p.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var publishedAt time.Time
+	if p.PublishedAt != nil {
+		publishedAt = *p.PublishedAt
+	}
+
+	return openapi.PublicPost{
+		Id:               p.ID.String(),
+		Title:            p.Title,
+		Body:             publicBody(p),
+		Category:         p.Category,
+		Tags:             tags,
+		FeaturedImageURL: p.FeaturedImageURL,
+		MetaDescription:  p.MetaDescription,
+		Slug:             p.Slug,
+		PublishedAt:      publishedAt,
+	}
+}
+
+// publicBody returns the HTML readers see for the body of p. Posts read
+// without their rendered body are rendered here, and a body whose format is
+// unknown is sanitized as HTML, so that unchecked markup never reaches
+// readers.
+func publicBody(p *post.Post) string {
+	if p.RenderedBody != "" {
+		return p.RenderedBody
+	}
+
+	rendered, err := post.RenderBody(p.BodyFormat, p.Body)
+	if err != nil {
+		return sanitize.Sanitize(p.Body)
+	}
+	return rendered
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/rdb"
+)
+
+func TestPublicPostListQuery(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ptr := func(v int32) *int32 { return &v }
+	asc := openapi.PublicPostSortPublishedAt
+	invalidSort := openapi.PublicPostSort("-createdAt")
+
+	tests := []struct {
+		name    string
+		params  openapi.PublicPostsListParams
+		want    func(t *testing.T, q rdb.PostListQuery)
+		wantErr bool
+	}{
+		{
+			name:   "defaults to published posts, newest first",
+			params: openapi.PublicPostsListParams{},
+			want: func(t *testing.T, q rdb.PostListQuery) {
+				if q.Limit != rdb.DefaultPostListLimit || q.Sort != rdb.PostSortPublishedAtDesc {
+					t.Errorf("query = %+v, want defaults", q)
+				}
+				if q.Filter.Status == nil || *q.Filter.Status != post.StatusPublished {
+					t.Errorf("status = %v, want published", q.Filter.Status)
+				}
+				if q.Filter.PublishedUntil == nil || !q.Filter.PublishedUntil.Equal(now) {
+					t.Errorf("publishedUntil = %v, want %v", q.Filter.PublishedUntil, now)
+				}
+			},
+		},
+		{
+			name:   "ascending",
+			params: openapi.PublicPostsListParams{Sort: &asc, Limit: ptr(5)},
+			want: func(t *testing.T, q rdb.PostListQuery) {
+				if q.Sort != rdb.PostSortPublishedAtAsc || q.Limit != 5 {
+					t.Errorf("query = %+v", q)
+				}
+			},
+		},
+		{name: "limit too large", params: openapi.PublicPostsListParams{Limit: ptr(101)}, wantErr: true},
+		{name: "invalid sort", params: openapi.PublicPostsListParams{Sort: &invalidSort}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := publicPostListQuery(tt.params, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("publicPostListQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil {
+				tt.want(t, q)
+			}
+		})
+	}
+}
+
+func TestToOpenAPIPublicPost_OmitsEditorialFields(t *testing.T) {
+	publishedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	authorID, _ := post.ParseUserID("0f000000-0000-4000-8000-000000000002")
+	p := &post.Post{
+		ID:                   post.NewPostID(),
+		Title:                "title",
+		Body:                 "body",
+		Status:               post.StatusPublished,
+		Category:             "tech",
+		SNSAutoPost:          true,
+		ExternalNotification: true,
+		EmergencyFlag:        true,
+		PublishedAt:          &publishedAt,
+		AuthorID:             &authorID,
+	}
+
+	b, err := json.Marshal(toOpenAPIPublicPost(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, field := range []string{"status", "scheduledAt", "snsAutoPost", "externalNotification", "emergencyFlag", "authorId", "lastEditorId", "createdAt"} {
+		if strings.Contains(string(b), `"`+field+`"`) {
+			t.Errorf("public post %s contains %q", b, field)
+		}
+	}
+	if !strings.Contains(string(b), `"tags":[]`) {
+		t.Errorf("public post %s, want empty tags array", b)
+	}
+}
+
+func TestToOpenAPIPublicPost_SanitizesBody(t *testing.T) {
+	p := &post.Post{
+		ID:    post.NewPostID(),
+		Title: "title",
+		Body:  `<p onclick="steal()">本文</p><script>alert(1)</script>`,
+	}
+
+	if got, want := toOpenAPIPublicPost(p).Body, "<p>本文</p>"; got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+}
+
+func TestToOpenAPIPublicPost_ServesRenderedBody(t *testing.T) {
+	p := &post.Post{
+		ID:           post.NewPostID(),
+		Title:        "title",
+		Body:         "**本文**",
+		BodyFormat:   post.BodyFormatMarkdown,
+		RenderedBody: "<p><strong>本文</strong></p>\n",
+	}
+
+	if got, want := toOpenAPIPublicPost(p).Body, p.RenderedBody; got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+
+	// キャッシュがない場合は形式に従ってその場で描画する
+	p.RenderedBody = ""
+	if got, want := toOpenAPIPublicPost(p).Body, "<p><strong>本文</strong></p>\n"; got != want {
+		t.Errorf("Body = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/server/middleware"
+)
+
+func (s *Server) PostsSubmitReview(c *gin.Context, id string) {
+	s.reviewPost(c, id, post.ReviewActionSubmit)
+}
+
+func (s *Server) PostsApproveReview(c *gin.Context, id string) {
+	s.reviewPost(c, id, post.ReviewActionApprove)
+}
+
+func (s *Server) PostsRejectReview(c *gin.Context, id string) {
+	s.reviewPost(c, id, post.ReviewActionReject)
+}
+
+// reviewPost runs a step of the approval workflow shared by the
+// submit/approve/reject actions.
+func (s *Server) reviewPost(c *gin.Context, id string, action post.ReviewAction) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var req openapi.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	uc, err := s.container.ReviewPostUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), usecase.ReviewPostInput{
+		ID:      id,
+		Action:  action,
+		Comment: req.Comment,
+	}, userCtx)
+	if err != nil {
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+			return
+		}
+		if _, ok := post.AsErrValidation(err); ok {
+			c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIPost(output.Post))
+}
+
+func (s *Server) PostsListReviews(c *gin.Context, id string) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	if _, err := post.ParsePostID(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+
+	uc, err := s.container.ListReviewsUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), usecase.ListReviewsInput{ID: id}, userCtx)
+	if err != nil {
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]openapi.PostReview, 0, len(output.Reviews))
+	for _, r := range output.Reviews {
+		items = append(items, openapi.PostReview{
+			Action:    openapi.ReviewAction(r.Action),
+			ActorId:   userIDString(r.ActorID),
+			Comment:   r.Comment,
+			Reasons:   reviewReasonStrings(r.Reasons),
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, openapi.PostReviewList{Items: items})
+}
+
+func reviewReasonStrings(reasons []post.ReviewReason) []string {
+	s := make([]string, 0, len(reasons))
+	for _, r := range reasons {
+		s = append(s, string(r))
+	}
+	return s
+}
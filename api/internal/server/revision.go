@@ -0,0 +1,228 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/server/middleware"
+	"github.com/ss49919201/myblog/api/internal/textdiff"
+)
+
+func (s *Server) PostsListRevisions(c *gin.Context, id string) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	if _, err := post.ParsePostID(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+
+	uc, err := s.container.ListRevisionsUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), usecase.ListRevisionsInput{ID: id}, userCtx)
+	if err != nil {
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]openapi.PostRevisionSummary, 0, len(output.Revisions))
+	for _, rev := range output.Revisions {
+		items = append(items, openapi.PostRevisionSummary{
+			Number:       int32(rev.Number),
+			Title:        rev.Title,
+			EditorId:     userIDString(rev.EditorID),
+			CreatedAt:    rev.CreatedAt,
+			RestoredFrom: revisionNumberPtr(rev.RestoredFrom),
+		})
+	}
+
+	c.JSON(http.StatusOK, openapi.PostRevisionList{Items: items})
+}
+
+func (s *Server) PostsReadRevision(c *gin.Context, id string, revision int32) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	if _, err := post.ParsePostID(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+
+	uc, err := s.container.ReadRevisionUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), usecase.ReadRevisionInput{
+		ID:       id,
+		Revision: int(revision),
+	}, userCtx)
+	if err != nil {
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIPostRevision(output.Revision))
+}
+
+// PostsDiffRevision compares revision with params.From, which defaults to
+// the revision before it, so the diff shows what revision changed.
+func (s *Server) PostsDiffRevision(c *gin.Context, id string, revision int32, params openapi.PostsDiffRevisionParams) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	if _, err := post.ParsePostID(id); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
+		return
+	}
+
+	from := revision - 1
+	if params.From != nil {
+		from = *params.From
+	}
+	mode := textdiff.ModeLine
+	if params.Mode != nil {
+		mode = textdiff.Mode(*params.Mode)
+	}
+
+	uc, err := s.container.DiffRevisionsUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), usecase.DiffRevisionsInput{
+		ID:   id,
+		From: int(from),
+		To:   int(revision),
+		Mode: mode,
+	}, userCtx)
+	if err != nil {
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+			return
+		}
+		if _, ok := post.AsErrValidation(err); ok {
+			c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+	diff := output.Diff
+
+	changes := make([]openapi.RevisionFieldChange, 0, len(diff.Changes))
+	for _, change := range diff.Changes {
+		changes = append(changes, openapi.RevisionFieldChange{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+
+	c.JSON(http.StatusOK, openapi.PostRevisionDiff{
+		From:    int32(diff.From),
+		To:      int32(diff.To),
+		Mode:    openapi.DiffMode(diff.Mode),
+		Title:   toOpenAPIDiffEdits(diff.Title),
+		Body:    toOpenAPIDiffEdits(diff.Body),
+		Changes: changes,
+	})
+}
+
+func (s *Server) PostsRestoreRevision(c *gin.Context, id string, revision int32) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	uc, err := s.container.RestoreRevisionUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), usecase.RestoreRevisionInput{
+		ID:       id,
+		Revision: int(revision),
+	}, userCtx)
+	if err != nil {
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+			return
+		}
+		if _, ok := post.AsErrValidation(err); ok {
+			c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIPost(output.Post))
+}
+
+func toOpenAPIPostRevision(rev *post.Revision) openapi.PostRevision {
+	tags := rev.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return openapi.PostRevision{
+		Number:           int32(rev.Number),
+		Title:            rev.Title,
+		Body:             rev.Body,
+		BodyFormat:       openapi.BodyFormat(rev.BodyFormat),
+		Category:         rev.Category,
+		Tags:             tags,
+		FeaturedImageURL: rev.FeaturedImageURL,
+		MetaDescription:  rev.MetaDescription,
+		Slug:             rev.Slug,
+		EditorId:         userIDString(rev.EditorID),
+		CreatedAt:        rev.CreatedAt,
+		RestoredFrom:     revisionNumberPtr(rev.RestoredFrom),
+	}
+}
+
+func toOpenAPIDiffEdits(edits []textdiff.Edit) []openapi.DiffEdit {
+	converted := make([]openapi.DiffEdit, 0, len(edits))
+	for _, e := range edits {
+		converted = append(converted, openapi.DiffEdit{Op: openapi.DiffOp(e.Op), Text: e.Text})
+	}
+	return converted
+}
+
+func revisionNumberPtr(number *int) *int32 {
+	if number == nil {
+		return nil
+	}
+
+	n := int32(*number)
+	return &n
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/rule/entity/rule"
+	ruleusecase "github.com/ss49919201/myblog/api/internal/rule/usecase"
+	"github.com/ss49919201/myblog/api/internal/server/middleware"
+)
+
+func (s *Server) EditorialRuleSetsRead(c *gin.Context) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	policy, err := s.container.Policy()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get policy"})
+		return
+	}
+	if err := policy.Authorize(userCtx, usecase.ActionManageRules, nil); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	repo, err := s.container.RuleRepository()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get repository"})
+		return
+	}
+
+	set, err := repo.Load(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIRuleSet(set))
+}
+
+func (s *Server) EditorialRuleSetsUpdateGlobal(c *gin.Context) {
+	s.updateRules(c, nil)
+}
+
+func (s *Server) EditorialRuleSetsUpdateCategory(c *gin.Context, category string) {
+	s.updateRules(c, &category)
+}
+
+// updateRules replaces the rules of category, or the global rules when
+// category is nil.
+func (s *Server) updateRules(c *gin.Context, category *string) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	var request openapi.EditorialRules
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, openapi.Error{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	uc, err := s.container.UpdateRulesUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), ruleusecase.UpdateRulesInput{
+		Category: category,
+		Rules:    toRules(request),
+	}, userCtx)
+	if err != nil {
+		if _, ok := post.AsErrValidation(err); ok {
+			c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+			return
+		}
+		if _, ok := post.AsErrForbidden(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update rules"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIRuleSet(output.RuleSet))
+}
+
+func (s *Server) EditorialRuleSetsDeleteCategory(c *gin.Context, category string) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	uc, err := s.container.DeleteCategoryRulesUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	if err := uc.Execute(c.Request.Context(), ruleusecase.DeleteCategoryRulesInput{Category: category}, userCtx); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func toRules(r openapi.EditorialRules) rule.Rules {
+	rules := rule.Rules{
+		MinTags:             intPtr(r.MinTags),
+		LeadTimeMinutes:     intPtr(r.LeadTimeMinutes),
+		DailyQuota:          intPtr(r.DailyQuota),
+		MaxExternalLinks:    intPtr(r.MaxExternalLinks),
+		ReviewExternalLinks: intPtr(r.ReviewExternalLinks),
+	}
+	if r.RequiredFields != nil {
+		for _, f := range *r.RequiredFields {
+			rules.RequiredFields = append(rules.RequiredFields, rule.Field(f))
+		}
+	}
+	if w := r.PublishWindow; w != nil {
+		window := &rule.PublishWindow{StartHour: int(w.StartHour), EndHour: int(w.EndHour)}
+		for _, d := range w.Weekdays {
+			window.Weekdays = append(window.Weekdays, rule.Weekday(d))
+		}
+		rules.PublishWindow = window
+	}
+	return rules
+}
+
+func toOpenAPIRules(r rule.Rules) openapi.EditorialRules {
+	rules := openapi.EditorialRules{
+		MinTags:             int32Ptr(r.MinTags),
+		LeadTimeMinutes:     int32Ptr(r.LeadTimeMinutes),
+		DailyQuota:          int32Ptr(r.DailyQuota),
+		MaxExternalLinks:    int32Ptr(r.MaxExternalLinks),
+		ReviewExternalLinks: int32Ptr(r.ReviewExternalLinks),
+	}
+	if len(r.RequiredFields) > 0 {
+		fields := make([]openapi.RuleField, 0, len(r.RequiredFields))
+		for _, f := range r.RequiredFields {
+			fields = append(fields, openapi.RuleField(f))
+		}
+		rules.RequiredFields = &fields
+	}
+	if w := r.PublishWindow; w != nil {
+		window := &openapi.PublishWindow{StartHour: int32(w.StartHour), EndHour: int32(w.EndHour)}
+		for _, d := range w.Weekdays {
+			window.Weekdays = append(window.Weekdays, openapi.Weekday(d))
+		}
+		rules.PublishWindow = window
+	}
+	return rules
+}
+
+func toOpenAPIRuleSet(set *rule.RuleSet) openapi.EditorialRuleSet {
+	categories := make(map[string]openapi.EditorialRules, len(set.Categories))
+	for category, rules := range set.Categories {
+		categories[category] = toOpenAPIRules(rules)
+	}
+
+	return openapi.EditorialRuleSet{
+		Global:     toOpenAPIRules(set.Global),
+		Categories: categories,
+	}
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+
+	n := int(*v)
+	return &n
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+
+	n := int32(*v)
+	return &n
+}
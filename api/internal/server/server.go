@@ -3,27 +3,34 @@ package server
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/clock"
 	"github.com/ss49919201/myblog/api/internal/openapi"
 	"github.com/ss49919201/myblog/api/internal/post/di"
 	"github.com/ss49919201/myblog/api/internal/post/entity/post"
 	"github.com/ss49919201/myblog/api/internal/post/rdb"
 	"github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/server/middleware"
+	"github.com/ss49919201/myblog/api/internal/user/entity/user"
+	userusecase "github.com/ss49919201/myblog/api/internal/user/usecase"
 )
 
 type Server struct {
 	container *di.Container
+	clock     clock.Clock
 }
 
 func NewServer() *Server {
 	return &Server{
 		container: di.NewContainer(),
+		clock:     clock.System{},
 	}
 }
 
-func (s *Server) PostsRead(c *gin.Context, id string) {
+func (s *Server) PostsRead(c *gin.Context, id string, params openapi.PostsReadParams) {
 	repo, err := s.container.PostRepository()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get repository"})
@@ -46,30 +53,212 @@ func (s *Server) PostsRead(c *gin.Context, id string) {
 		return
 	}
 
-	c.JSON(http.StatusOK, foundPost)
+	etag := postETag(foundPost)
+	c.Header("ETag", etag)
+	if notModified(params.IfNoneMatch, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIPost(foundPost))
 }
 
-func (s *Server) PostsList(c *gin.Context) {
+func (s *Server) PostsReadBySlug(c *gin.Context, slug string, params openapi.PostsReadBySlugParams) {
 	db, err := s.container.DB()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "database connection failed"})
 		return
 	}
 
-	posts, err := rdb.FindAllPosts(c.Request.Context(), db)
+	foundPost, err := rdb.FindPostBySlug(c.Request.Context(), db, slug)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || err.Error() == "post not found" {
+			find := func(id post.PostID) (*post.Post, error) {
+				repo, err := s.container.PostRepository()
+				if err != nil {
+					return nil, err
+				}
+				return repo.FindByID(c.Request.Context(), id)
+			}
+			if redirectOldSlug(c, db, slug, find, postPath) {
+				return
+			}
+			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 		return
 	}
 
-	response := gin.H{
-		"items": posts,
+	etag := postETag(foundPost)
+	c.Header("ETag", etag)
+	if notModified(params.IfNoneMatch, etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIPost(foundPost))
+}
+
+func (s *Server) PostsList(c *gin.Context, params openapi.PostsListParams) {
+	query, err := postListQuery(params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, openapi.Error{
+			Code:    http.StatusBadRequest,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	db, err := s.container.DB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database connection failed"})
+		return
+	}
+
+	page, err := rdb.FindPostPage(c.Request.Context(), db, query)
+	if err != nil {
+		if errors.Is(err, rdb.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, openapi.Error{
+				Code:    http.StatusBadRequest,
+				Message: "invalid cursor",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	items := make([]openapi.Post, 0, len(page.Items))
+	for _, p := range page.Items {
+		items = append(items, toOpenAPIPost(p))
+	}
+
+	response := openapi.PostList{
+		Items:      items,
+		NextCursor: page.NextCursor,
+	}
+	if page.Total != nil {
+		total := int32(*page.Total)
+		response.Total = &total
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
-func (s *Server) PostsCreate(c *gin.Context, params openapi.PostsCreateParams) {
+// postListQuery validates the list parameters and converts them into a query.
+func postListQuery(params openapi.PostsListParams) (rdb.PostListQuery, error) {
+	query := rdb.PostListQuery{
+		Sort:  rdb.PostSortCreatedAtDesc,
+		Limit: rdb.DefaultPostListLimit,
+		Filter: rdb.PostListFilter{
+			Category:      params.Category,
+			Tag:           params.Tag,
+			CreatedFrom:   params.CreatedFrom,
+			CreatedTo:     params.CreatedTo,
+			PublishedFrom: params.PublishedFrom,
+			PublishedTo:   params.PublishedTo,
+		},
+	}
+
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > rdb.MaxPostListLimit {
+			return rdb.PostListQuery{}, fmt.Errorf("limit must be between 1 and %d", rdb.MaxPostListLimit)
+		}
+		query.Limit = int(*params.Limit)
+	}
+	if params.Cursor != nil {
+		query.Cursor = *params.Cursor
+	}
+	if params.Sort != nil {
+		sort, err := rdb.ParsePostSort(string(*params.Sort))
+		if err != nil {
+			return rdb.PostListQuery{}, err
+		}
+		query.Sort = sort
+	}
+	if params.Status != nil {
+		status := post.PublicationStatus(*params.Status)
+		if !status.Valid() {
+			return rdb.PostListQuery{}, errors.New("invalid status")
+		}
+		query.Filter.Status = &status
+	}
+	if params.ReviewStatus != nil {
+		reviewStatus := post.ReviewStatus(*params.ReviewStatus)
+		if !reviewStatus.Valid() {
+			return rdb.PostListQuery{}, errors.New("invalid reviewStatus")
+		}
+		query.Filter.ReviewStatus = &reviewStatus
+	}
+	if params.AuthorId != nil {
+		authorID, err := post.ParseUserID(*params.AuthorId)
+		if err != nil {
+			return rdb.PostListQuery{}, errors.New("invalid authorId")
+		}
+		query.Filter.AuthorID = &authorID
+	}
+	if params.IncludeTotal != nil {
+		query.IncludeTotal = *params.IncludeTotal
+	}
+
+	return query, nil
+}
+
+func (s *Server) AuthLogin(c *gin.Context) {
+	uc, err := s.container.LoginUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, openapi.Error{
+			Code:    http.StatusInternalServerError,
+			Message: "failed to get usecase",
+		})
+		return
+	}
+
+	var request openapi.LoginRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, openapi.Error{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), userusecase.LoginInput{
+		Email:    request.Email,
+		Password: request.Password,
+	})
+	if err != nil {
+		if _, ok := user.AsErrInvalidCredentials(err); ok {
+			c.JSON(http.StatusUnauthorized, openapi.Error{
+				Code:    http.StatusUnauthorized,
+				Message: "invalid email or password",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, openapi.Error{
+			Code:    http.StatusInternalServerError,
+			Message: "failed to login",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, openapi.LoginResponse{
+		Token:     output.Token,
+		ExpiresAt: output.ExpiresAt,
+	})
+}
+
+func (s *Server) PostsCreate(c *gin.Context) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, openapi.Error{
+			Code:    http.StatusUnauthorized,
+			Message: "authentication required",
+		})
+		return
+	}
+
 	uc, err := s.container.CreatePostUsecase()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, openapi.Error{
@@ -94,9 +283,15 @@ func (s *Server) PostsCreate(c *gin.Context, params openapi.PostsCreateParams) {
 		tags = request.Tags
 	}
 
+	var bodyFormat post.BodyFormat
+	if request.BodyFormat != nil {
+		bodyFormat = post.BodyFormat(*request.BodyFormat)
+	}
+
 	input := usecase.CreatePostInput{
 		Title:                request.Title,
 		Body:                 request.Body,
+		BodyFormat:           bodyFormat,
 		Status:               post.PublicationStatus(request.Status),
 		ScheduledAt:          request.ScheduledAt,
 		Category:             request.Category,
@@ -109,25 +304,21 @@ func (s *Server) PostsCreate(c *gin.Context, params openapi.PostsCreateParams) {
 		EmergencyFlag:        request.EmergencyFlag,
 	}
 
-	userCtx := usecase.UserContext{
-		Role: post.UserRole(params.XUserRole),
-	}
-
 	output, err := uc.Execute(c.Request.Context(), input, userCtx)
 	if err != nil {
 		// バリデーションエラーの場合
-		if validationErr, ok := post.AsErrValidation(err); ok {
-			c.JSON(http.StatusBadRequest, openapi.ValidationErrors{
-				Code:    http.StatusBadRequest,
-				Message: "Validation failed",
-				Errors: []openapi.ValidationError{
-					{
-						Code:    http.StatusBadRequest,
-						Field:   validationErr.Field,
-						Message: validationErr.Message,
-					},
-				},
-			})
+		if _, ok := post.AsErrValidation(err); ok {
+			c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+			return
+		}
+
+		if _, ok := post.AsErrForbidden(err); ok {
+			_ = c.Error(err)
+			return
+		}
+
+		if _, ok := post.AsErrSlugConflict(err); ok {
+			_ = c.Error(err)
 			return
 		}
 
@@ -138,29 +329,69 @@ func (s *Server) PostsCreate(c *gin.Context, params openapi.PostsCreateParams) {
 		return
 	}
 
-	// Post エンティティからOpenAPI型への変換
-	response := openapi.Post{
-		Id:                   output.Post.ID.String(),
-		Title:                output.Post.Title,
-		Body:                 output.Post.Body,
-		Status:               openapi.PublicationStatus(output.Post.Status),
-		ScheduledAt:          output.Post.ScheduledAt,
-		Category:             output.Post.Category,
-		Tags:                 output.Post.Tags,
-		FeaturedImageURL:     output.Post.FeaturedImageURL,
-		MetaDescription:      output.Post.MetaDescription,
-		Slug:                 output.Post.Slug,
-		SnsAutoPost:          output.Post.SNSAutoPost,
-		ExternalNotification: output.Post.ExternalNotification,
-		EmergencyFlag:        output.Post.EmergencyFlag,
-		CreatedAt:            output.Post.CreatedAt,
-		PublishedAt:          output.Post.PublishedAt,
+	c.JSON(http.StatusOK, toOpenAPIPost(output.Post))
+}
+
+// PostsPreview renders a draft body the way it would be shown to readers,
+// without saving it. Markup that saving would reject is returned as issues
+// next to the HTML, so that the editor can show both while the author types.
+func (s *Server) PostsPreview(c *gin.Context) {
+	if _, ok := middleware.UserContextFrom(c); !ok {
+		c.JSON(http.StatusUnauthorized, openapi.Error{
+			Code:    http.StatusUnauthorized,
+			Message: "authentication required",
+		})
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	var request openapi.PreviewPostRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, openapi.Error{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	format := post.BodyFormatMarkdown
+	if request.BodyFormat != nil {
+		format = post.BodyFormat(*request.BodyFormat)
+	}
+	if err := post.ValidateBodyFormat(format); err != nil {
+		c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+		return
+	}
+
+	// 保存時と同じ正規化をしてから描画する
+	body := post.NormalizeText(request.Body)
+
+	rendered, err := post.RenderBody(format, body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, openapi.Error{
+			Code:    http.StatusInternalServerError,
+			Message: "failed to render body",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, openapi.PostPreview{
+		Html:   rendered,
+		Issues: middleware.ValidationErrorsResponse(post.ValidateBodyMarkup(format, body)).Errors,
+	})
 }
 
-func (s *Server) PostsDelete(c *gin.Context, id string) {
+func (s *Server) PostsDelete(c *gin.Context, id string, params openapi.PostsDeleteParams) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	versions, ok := requireIfMatch(c, params.IfMatch)
+	if !ok {
+		return
+	}
+
 	uc, err := s.container.DeletePostUsecase()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
@@ -168,9 +399,18 @@ func (s *Server) PostsDelete(c *gin.Context, id string) {
 	}
 
 	err = uc.Execute(c.Request.Context(), usecase.DeletePostInput{
-		ID: id,
-	})
+		ID:               id,
+		ExpectedVersions: versions,
+	}, userCtx)
 	if err != nil {
+		if _, ok := post.AsErrForbidden(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		if _, ok := post.AsErrVersionConflict(err); ok {
+			_ = c.Error(err)
+			return
+		}
 		if err.Error() == "post not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
 			return
@@ -182,41 +422,89 @@ func (s *Server) PostsDelete(c *gin.Context, id string) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
-func (s *Server) PostsUpdate(c *gin.Context, id string) {
+func (s *Server) PostsUpdate(c *gin.Context, id string, params openapi.PostsUpdateParams) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	versions, ok := requireIfMatch(c, params.IfMatch)
+	if !ok {
+		return
+	}
+
 	uc, err := s.container.UpdatePostUsecase()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
 		return
 	}
 
-	var input struct {
-		Title string `json:"title"`
-		Body  string `json:"body"`
+	raw, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
 	}
 
-	if err := c.ShouldBindJSON(&input); err != nil {
+	patch, err := decodePostMergePatch(raw)
+	if err != nil {
+		if _, ok := post.AsErrValidation(err); ok {
+			c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 		return
 	}
 
 	output, err := uc.Execute(c.Request.Context(), usecase.UpdatePostInput{
-		ID:    id,
-		Title: input.Title,
-		Body:  input.Body,
-	})
+		ID:               id,
+		Patch:            patch,
+		ExpectedVersions: versions,
+	}, userCtx)
 	if err != nil {
 		if err.Error() == "post not found" {
 			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
 			return
 		}
+		if _, ok := post.AsErrValidation(err); ok {
+			c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+			return
+		}
+		if _, ok := post.AsErrForbidden(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		if _, ok := post.AsErrInvalidTransition(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		if _, ok := post.AsErrSlugConflict(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		if _, ok := post.AsErrVersionConflict(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		if _, ok := post.AsErrReviewRequired(err); ok {
+			_ = c.Error(err)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update post"})
 		return
 	}
 
-	c.JSON(http.StatusOK, output.Post)
+	c.Header("ETag", postETag(output.Post))
+	c.JSON(http.StatusOK, toOpenAPIPost(output.Post))
 }
 
 func (s *Server) PostsAnalyze(c *gin.Context, id string) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
 	uc, err := s.container.AnalyzePostUsecase()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
@@ -225,11 +513,128 @@ func (s *Server) PostsAnalyze(c *gin.Context, id string) {
 
 	output, err := uc.Execute(c.Request.Context(), usecase.AnalyzePostInput{
 		ID: id,
-	})
+	}, userCtx)
 	if err != nil {
+		if _, ok := post.AsErrForbidden(err); ok {
+			_ = c.Error(err)
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid post id"})
 		return
 	}
 
 	c.JSON(http.StatusOK, output)
 }
+
+func (s *Server) PostsPublish(c *gin.Context, id string) {
+	s.transitionPost(c, usecase.TransitionPostInput{ID: id, Transition: usecase.TransitionPublish})
+}
+
+func (s *Server) PostsSchedule(c *gin.Context, id string) {
+	var req openapi.SchedulePostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	s.transitionPost(c, usecase.TransitionPostInput{ID: id, Transition: usecase.TransitionSchedule, ScheduledAt: &req.ScheduledAt})
+}
+
+func (s *Server) PostsUnschedule(c *gin.Context, id string) {
+	s.transitionPost(c, usecase.TransitionPostInput{ID: id, Transition: usecase.TransitionUnschedule})
+}
+
+func (s *Server) PostsUnpublish(c *gin.Context, id string) {
+	s.transitionPost(c, usecase.TransitionPostInput{ID: id, Transition: usecase.TransitionUnpublish})
+}
+
+func (s *Server) PostsArchive(c *gin.Context, id string) {
+	s.transitionPost(c, usecase.TransitionPostInput{ID: id, Transition: usecase.TransitionArchive})
+}
+
+func (s *Server) PostsUnarchive(c *gin.Context, id string) {
+	s.transitionPost(c, usecase.TransitionPostInput{ID: id, Transition: usecase.TransitionUnarchive})
+}
+
+// transitionPost runs a publication state transition shared by the
+// publish/schedule/unschedule/unpublish/archive/unarchive actions.
+func (s *Server) transitionPost(c *gin.Context, input usecase.TransitionPostInput) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	uc, err := s.container.TransitionPostUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), input, userCtx)
+	if err != nil {
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+			return
+		}
+		if _, ok := post.AsErrValidation(err); ok {
+			c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+			return
+		}
+		if _, ok := post.AsErrForbidden(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		if _, ok := post.AsErrInvalidTransition(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		if _, ok := post.AsErrReviewRequired(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to change post status"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIPost(output.Post))
+}
+
+// toOpenAPIPost converts a Post entity into the OpenAPI representation.
+func toOpenAPIPost(p *post.Post) openapi.Post {
+	version := int32(p.Version)
+	reviewStatus := openapi.ReviewStatus(p.ReviewStatus)
+
+	return openapi.Post{
+		Id:                   p.ID.String(),
+		Title:                p.Title,
+		Body:                 p.Body,
+		BodyFormat:           openapi.BodyFormat(p.BodyFormat),
+		RenderedBody:         &p.RenderedBody,
+		Status:               openapi.PublicationStatus(p.Status),
+		ScheduledAt:          p.ScheduledAt,
+		Category:             p.Category,
+		Tags:                 p.Tags,
+		FeaturedImageURL:     p.FeaturedImageURL,
+		MetaDescription:      p.MetaDescription,
+		Slug:                 p.Slug,
+		SnsAutoPost:          p.SNSAutoPost,
+		ExternalNotification: p.ExternalNotification,
+		EmergencyFlag:        p.EmergencyFlag,
+		CreatedAt:            p.CreatedAt,
+		PublishedAt:          p.PublishedAt,
+		AuthorId:             userIDString(p.AuthorID),
+		LastEditorId:         userIDString(p.LastEditorID),
+		Version:              &version,
+		ReviewStatus:         &reviewStatus,
+	}
+}
+
+func userIDString(userID *post.UserID) *string {
+	if userID == nil {
+		return nil
+	}
+
+	s := userID.String()
+	return &s
+}
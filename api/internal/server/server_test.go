@@ -0,0 +1,81 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/rdb"
+)
+
+func TestPostListQuery(t *testing.T) {
+	ptr := func(v int32) *int32 { return &v }
+	str := func(v string) *string { return &v }
+	sort := openapi.PostSortMinusPublishedAt
+	status := openapi.PublicationStatus("published")
+	invalidStatus := openapi.PublicationStatus("deleted")
+	invalidSort := openapi.PostSort("title")
+
+	tests := []struct {
+		name    string
+		params  openapi.PostsListParams
+		want    func(t *testing.T, q rdb.PostListQuery)
+		wantErr bool
+	}{
+		{
+			name:   "defaults",
+			params: openapi.PostsListParams{},
+			want: func(t *testing.T, q rdb.PostListQuery) {
+				if q.Limit != rdb.DefaultPostListLimit || q.Sort != rdb.PostSortCreatedAtDesc || q.IncludeTotal {
+					t.Errorf("query = %+v, want defaults", q)
+				}
+			},
+		},
+		{
+			name: "filters",
+			params: openapi.PostsListParams{
+				Limit:    ptr(100),
+				Sort:     &sort,
+				Status:   &status,
+				AuthorId: str("0f000000-0000-4000-8000-000000000002"),
+				Tag:      str("go"),
+				Cursor:   str("abc"),
+			},
+			want: func(t *testing.T, q rdb.PostListQuery) {
+				if q.Limit != 100 || q.Sort != rdb.PostSortPublishedAtDesc || q.Cursor != "abc" {
+					t.Errorf("query = %+v", q)
+				}
+				if q.Filter.Status == nil || *q.Filter.Status != post.StatusPublished {
+					t.Errorf("status = %v, want published", q.Filter.Status)
+				}
+				if q.Filter.AuthorID == nil || q.Filter.AuthorID.String() != "0f000000-0000-4000-8000-000000000002" {
+					t.Errorf("authorID = %v", q.Filter.AuthorID)
+				}
+				if q.Filter.Tag == nil || *q.Filter.Tag != "go" {
+					t.Errorf("tag = %v, want go", q.Filter.Tag)
+				}
+			},
+		},
+		{name: "limit too small", params: openapi.PostsListParams{Limit: ptr(0)}, wantErr: true},
+		{name: "limit too large", params: openapi.PostsListParams{Limit: ptr(101)}, wantErr: true},
+		{name: "unknown status", params: openapi.PostsListParams{Status: &invalidStatus}, wantErr: true},
+		{name: "unknown sort", params: openapi.PostsListParams{Sort: &invalidSort}, wantErr: true},
+		{name: "invalid author", params: openapi.PostsListParams{AuthorId: str("someone")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := postListQuery(tt.params)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.want(t, q)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/rdb"
+)
+
+// redirectOldSlug answers a lookup of slug that matched no current slug.
+// An old slug is redirected permanently to the post's current location,
+// which path builds, and a tombstoned one is answered with 410 Gone.
+// find loads the post the old slug belonged to; when it reports the post as
+// not found, or slug was never used, redirectOldSlug returns false and the
+// caller responds with 404.
+func redirectOldSlug(c *gin.Context, db *sql.DB, slug string, find func(post.PostID) (*post.Post, error), path func(*post.Post) string) bool {
+	history, err := rdb.FindSlugHistory(c.Request.Context(), db, slug)
+	if err != nil {
+		if err.Error() == "slug history not found" {
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return true
+	}
+
+	if history.TombstonedAt != nil {
+		c.JSON(http.StatusGone, openapi.Error{
+			Code:    http.StatusGone,
+			Message: "post was deleted",
+		})
+		return true
+	}
+
+	current, err := find(history.PostID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || err.Error() == "post not found" {
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return true
+	}
+
+	location := path(current)
+	c.Header("Location", location)
+	c.JSON(http.StatusMovedPermanently, openapi.SlugRedirect{
+		Id:   current.ID.String(),
+		Slug: current.Slug,
+	})
+	return true
+}
+
+// publicPostPath is the public read path of p: its slug, or its id when it
+// has no slug.
+func publicPostPath(p *post.Post) string {
+	key := p.ID.String()
+	if p.Slug != nil {
+		key = *p.Slug
+	}
+	return "/api/public/posts/" + url.PathEscape(key)
+}
+
+// postPath is the editor read path of p.
+func postPath(p *post.Post) string {
+	if p.Slug != nil {
+		return "/api/posts/by-slug/" + url.PathEscape(*p.Slug)
+	}
+	return "/api/posts/" + p.ID.String()
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+func TestPostPaths(t *testing.T) {
+	id, _ := post.ParsePostID("0f000000-0000-4000-8000-000000000001")
+	slug := "hello-world"
+
+	withSlug := &post.Post{ID: id, Slug: &slug}
+	withoutSlug := &post.Post{ID: id}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"public with slug", publicPostPath(withSlug), "/api/public/posts/hello-world"},
+		{"public without slug", publicPostPath(withoutSlug), "/api/public/posts/" + id.String()},
+		{"editor with slug", postPath(withSlug), "/api/posts/by-slug/hello-world"},
+		{"editor without slug", postPath(withoutSlug), "/api/posts/" + id.String()},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: path = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
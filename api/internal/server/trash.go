@@ -0,0 +1,127 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/rdb"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/server/middleware"
+)
+
+func (s *Server) PostsListTrash(c *gin.Context, params openapi.PostsListTrashParams) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	input := usecase.ListTrashInput{}
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > usecase.MaxTrashListLimit {
+			c.JSON(http.StatusBadRequest, openapi.Error{
+				Code:    http.StatusBadRequest,
+				Message: fmt.Sprintf("limit must be between 1 and %d", usecase.MaxTrashListLimit),
+			})
+			return
+		}
+		input.Limit = int(*params.Limit)
+	}
+	if params.Cursor != nil {
+		input.Cursor = *params.Cursor
+	}
+
+	uc, err := s.container.ListTrashUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), input, userCtx)
+	if err != nil {
+		if errors.Is(err, rdb.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, openapi.Error{
+				Code:    http.StatusBadRequest,
+				Message: "invalid cursor",
+			})
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	items := make([]openapi.TrashedPost, 0, len(output.Posts))
+	for _, p := range output.Posts {
+		items = append(items, toOpenAPITrashedPost(p))
+	}
+
+	c.JSON(http.StatusOK, openapi.TrashedPostList{Items: items, NextCursor: output.NextCursor})
+}
+
+func (s *Server) PostsRestore(c *gin.Context, id string) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	uc, err := s.container.RestorePostUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), usecase.RestorePostInput{ID: id}, userCtx)
+	if err != nil {
+		if err.Error() == "post not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+			return
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("ETag", postETag(output.Post))
+	c.JSON(http.StatusOK, toOpenAPIPost(output.Post))
+}
+
+func toOpenAPITrashedPost(p *post.Post) openapi.TrashedPost {
+	version := int32(p.Version)
+	reviewStatus := openapi.ReviewStatus(p.ReviewStatus)
+
+	var deletedAt time.Time
+	if p.DeletedAt != nil {
+		deletedAt = *p.DeletedAt
+	}
+
+	return openapi.TrashedPost{
+		Id:                   p.ID.String(),
+		Title:                p.Title,
+		Body:                 p.Body,
+		BodyFormat:           openapi.BodyFormat(p.BodyFormat),
+		RenderedBody:         &p.RenderedBody,
+		Status:               openapi.PublicationStatus(p.Status),
+		ScheduledAt:          p.ScheduledAt,
+		Category:             p.Category,
+		Tags:                 p.Tags,
+		FeaturedImageURL:     p.FeaturedImageURL,
+		MetaDescription:      p.MetaDescription,
+		Slug:                 p.Slug,
+		SnsAutoPost:          p.SNSAutoPost,
+		ExternalNotification: p.ExternalNotification,
+		EmergencyFlag:        p.EmergencyFlag,
+		CreatedAt:            p.CreatedAt,
+		PublishedAt:          p.PublishedAt,
+		AuthorId:             userIDString(p.AuthorID),
+		LastEditorId:         userIDString(p.LastEditorID),
+		Version:              &version,
+		ReviewStatus:         &reviewStatus,
+		DeletedAt:            deletedAt,
+		DeletedBy:            userIDString(p.DeletedBy),
+	}
+}
@@ -0,0 +1,217 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ss49919201/myblog/api/internal/openapi"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/server/middleware"
+	"github.com/ss49919201/myblog/api/internal/webhook/entity/webhook"
+	webhookrdb "github.com/ss49919201/myblog/api/internal/webhook/rdb"
+	webhookusecase "github.com/ss49919201/myblog/api/internal/webhook/usecase"
+)
+
+const (
+	defaultWebhookDeliveryLimit = 50
+	maxWebhookDeliveryLimit     = 200
+)
+
+func (s *Server) WebhooksList(c *gin.Context) {
+	if !s.authorizeWebhooks(c) {
+		return
+	}
+
+	db, err := s.container.DB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database connection failed"})
+		return
+	}
+
+	endpoints, err := webhookrdb.FindWebhookEndpoints(c.Request.Context(), db)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	items := make([]openapi.Webhook, 0, len(endpoints))
+	for _, e := range endpoints {
+		items = append(items, toOpenAPIWebhook(e))
+	}
+
+	c.JSON(http.StatusOK, openapi.WebhookList{Items: items})
+}
+
+func (s *Server) WebhooksCreate(c *gin.Context) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	uc, err := s.container.RegisterWebhookUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	var request openapi.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, openapi.Error{
+			Code:    http.StatusBadRequest,
+			Message: "invalid request body",
+		})
+		return
+	}
+
+	output, err := uc.Execute(c.Request.Context(), webhookusecase.RegisterEndpointInput{
+		URL:        request.Url,
+		Secret:     request.Secret,
+		EventTypes: request.EventTypes,
+	}, userCtx)
+	if err != nil {
+		if _, ok := post.AsErrValidation(err); ok {
+			c.JSON(http.StatusBadRequest, middleware.ValidationErrorsResponse(err))
+			return
+		}
+		if _, ok := post.AsErrForbidden(err); ok {
+			_ = c.Error(err)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register webhook"})
+		return
+	}
+
+	c.JSON(http.StatusOK, toOpenAPIWebhook(output.Endpoint))
+}
+
+func (s *Server) WebhooksDelete(c *gin.Context, id string) {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return
+	}
+
+	uc, err := s.container.DeactivateWebhookUsecase()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get usecase"})
+		return
+	}
+
+	if err := uc.Execute(c.Request.Context(), webhookusecase.DeactivateEndpointInput{ID: id}, userCtx); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+func (s *Server) WebhooksListDeliveries(c *gin.Context, id string, params openapi.WebhooksListDeliveriesParams) {
+	if !s.authorizeWebhooks(c) {
+		return
+	}
+
+	endpointID, err := webhook.ParseEndpointID(id)
+	if err != nil {
+		_ = c.Error(&webhook.ErrEndpointNotFound{})
+		return
+	}
+
+	limit := defaultWebhookDeliveryLimit
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > maxWebhookDeliveryLimit {
+			c.JSON(http.StatusBadRequest, openapi.Error{
+				Code:    http.StatusBadRequest,
+				Message: "limit must be between 1 and 200",
+			})
+			return
+		}
+		limit = int(*params.Limit)
+	}
+
+	repo, err := s.container.WebhookEndpointRepository()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get repository"})
+		return
+	}
+	if _, err := repo.FindByID(c.Request.Context(), endpointID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	db, err := s.container.DB()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "database connection failed"})
+		return
+	}
+
+	deliveries, err := webhookrdb.FindWebhookDeliveries(c.Request.Context(), db, endpointID, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	items := make([]openapi.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		items = append(items, toOpenAPIWebhookDelivery(d))
+	}
+
+	c.JSON(http.StatusOK, openapi.WebhookDeliveryList{Items: items})
+}
+
+// authorizeWebhooks checks that the caller may manage webhooks and writes the
+// error response if not.
+func (s *Server) authorizeWebhooks(c *gin.Context) bool {
+	userCtx, ok := middleware.UserContextFrom(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+		return false
+	}
+
+	policy, err := s.container.Policy()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get policy"})
+		return false
+	}
+
+	if err := policy.Authorize(userCtx, usecase.ActionManageWebhooks, nil); err != nil {
+		_ = c.Error(err)
+		return false
+	}
+
+	return true
+}
+
+func toOpenAPIWebhook(e *webhook.Endpoint) openapi.Webhook {
+	return openapi.Webhook{
+		Id:         e.ID.String(),
+		Url:        e.URL,
+		EventTypes: e.EventTypes,
+		Active:     e.Active,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func toOpenAPIWebhookDelivery(d *webhook.Delivery) openapi.WebhookDelivery {
+	var lastStatusCode *int32
+	if d.LastStatusCode != nil {
+		code := int32(*d.LastStatusCode)
+		lastStatusCode = &code
+	}
+
+	return openapi.WebhookDelivery{
+		Id:             d.ID.String(),
+		EndpointId:     d.EndpointID.String(),
+		EventId:        d.EventID,
+		EventType:      d.EventType,
+		PostId:         d.PostID,
+		Status:         openapi.WebhookDeliveryStatus(d.Status),
+		Attempts:       int32(d.Attempts),
+		LastStatusCode: lastStatusCode,
+		LastError:      d.LastError,
+		NextAttemptAt:  d.NextAttemptAt,
+		CreatedAt:      d.CreatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+}
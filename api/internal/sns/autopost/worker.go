@@ -0,0 +1,204 @@
+// Package autopost announces published posts on social networks.
+package autopost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/outbox"
+	postrepository "github.com/ss49919201/myblog/api/internal/post/repository"
+	"github.com/ss49919201/myblog/api/internal/sns/connector"
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+	"github.com/ss49919201/myblog/api/internal/sns/repository"
+)
+
+type Config struct {
+	// BaseURL is the public site the announced links point to.
+	BaseURL string
+	// Interval is how long the worker sleeps when nothing is due.
+	Interval  time.Duration
+	BatchSize int
+	// MaxAttempts is the number of failed attempts after which a share is
+	// marked dead.
+	MaxAttempts int
+	// Backoff returns the delay before the next attempt after attempts failures.
+	Backoff func(attempts int) time.Duration
+	// Lease is how long claimed shares stay hidden from other workers. It
+	// must cover posting a whole batch, since shares are posted one by one
+	// after they are claimed.
+	Lease time.Duration
+}
+
+func DefaultConfig(baseURL string) Config {
+	return Config{
+		BaseURL:     baseURL,
+		Interval:    10 * time.Second,
+		BatchSize:   10,
+		MaxAttempts: 6,
+		Backoff:     outbox.ExponentialBackoff(30*time.Second, time.Hour),
+		Lease:       5 * time.Minute,
+	}
+}
+
+// Worker posts due shares through the publisher of their network.
+// Several replicas can run a Worker at once; shares are claimed with
+// SELECT ... FOR UPDATE SKIP LOCKED and a lease, so that no transaction is
+// held open while the networks are called.
+type Worker struct {
+	shares     repository.ShareRepository
+	posts      postrepository.PostRepository
+	tx         postrepository.Transactor
+	publishers map[string]connector.SocialPublisher
+	clock      clock.Clock
+	config     Config
+}
+
+func NewWorker(shares repository.ShareRepository, posts postrepository.PostRepository, tx postrepository.Transactor, publishers []connector.SocialPublisher, clk clock.Clock, config Config) *Worker {
+	byNetwork := make(map[string]connector.SocialPublisher, len(publishers))
+	for _, p := range publishers {
+		byNetwork[p.Network()] = p
+	}
+
+	return &Worker{shares: shares, posts: posts, tx: tx, publishers: byNetwork, clock: clk, config: config}
+}
+
+// Run posts shares until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	slog.Info("sns autopost worker started", slog.Int("networks", len(w.publishers)))
+
+	for {
+		processed, err := w.Tick(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to post to sns", slog.String("err", err.Error()))
+		}
+
+		if err == nil && processed == w.config.BatchSize {
+			if ctx.Err() != nil {
+				slog.Info("sns autopost worker stopped")
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("sns autopost worker stopped")
+			return
+		case <-w.clock.After(w.config.Interval):
+		}
+	}
+}
+
+// Tick posts one batch of due shares and returns how many were claimed.
+func (w *Worker) Tick(ctx context.Context) (int, error) {
+	var shares []*sns.Share
+
+	// 短いトランザクションでリースを取り、送信中は行ロックも接続も持たない
+	err := w.tx.RunInTx(ctx, func(ctx context.Context) error {
+		claimed, err := w.shares.ClaimDue(ctx, w.clock.Now(), w.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		now := w.clock.Now()
+		for _, s := range claimed {
+			s.Claim(now, w.config.Lease)
+			if err := w.shares.Update(ctx, s); err != nil {
+				return err
+			}
+		}
+
+		shares = claimed
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, s := range shares {
+		if err := w.post(ctx, s); err != nil {
+			errs = append(errs, fmt.Errorf("share %s: %w", s.ID, err))
+		}
+	}
+
+	return len(shares), errors.Join(errs...)
+}
+
+// post sends s and records the outcome on it in its own transaction. The
+// returned error is a storage failure; failures of the network are recorded
+// on the share.
+func (w *Worker) post(ctx context.Context, s *sns.Share) error {
+	if err := w.send(ctx, s); err != nil {
+		return err
+	}
+	return w.record(ctx, s)
+}
+
+// record stores the outcome of s. A posted share is recorded as soon as the
+// network accepts it, so that its remote ID is kept before anything else can
+// fail; otherwise the share would be posted again once its lease expires.
+func (w *Worker) record(ctx context.Context, s *sns.Share) error {
+	err := w.tx.RunInTx(ctx, func(ctx context.Context) error {
+		return w.shares.Update(ctx, s)
+	})
+	if err != nil && s.Status == sns.ShareStatusPosted {
+		slog.Error("failed to record posted sns share; it may be posted again",
+			slog.String("share_id", s.ID.String()),
+			slog.String("network", s.Network),
+			slog.String("remote_id", *s.RemoteID),
+			slog.String("err", err.Error()),
+		)
+	}
+	return err
+}
+
+// send posts s to its network and sets the outcome on it. The returned error
+// is a storage failure.
+func (w *Worker) send(ctx context.Context, s *sns.Share) error {
+	publisher, ok := w.publishers[s.Network]
+	if !ok {
+		s.Skip(fmt.Sprintf("no connector configured for %s", s.Network))
+		return nil
+	}
+
+	p, err := w.posts.FindByID(ctx, s.PostID)
+	if err != nil {
+		if err.Error() == "post not found" {
+			s.Skip("post was deleted")
+			return nil
+		}
+		return err
+	}
+	// 予約から送信までの間に非公開になった投稿は告知しない
+	if p.Status != post.StatusPublished {
+		s.Skip(fmt.Sprintf("post is %s", p.Status))
+		return nil
+	}
+
+	message := sns.FormatMessage(p, sns.PostURL(w.config.BaseURL, p), publisher.Limits())
+	remoteID, err := publisher.Publish(ctx, s.ID.String(), message)
+	now := w.clock.Now()
+	if err != nil {
+		slog.Warn("sns post failed",
+			slog.String("share_id", s.ID.String()),
+			slog.String("network", s.Network),
+			slog.Int("attempt", s.Attempts),
+			slog.String("err", err.Error()),
+		)
+		if _, ok := connector.AsPermanentError(err); ok {
+			s.RecordPermanentFailure(err.Error())
+		} else {
+			s.RecordFailure(err.Error(), now, w.config.MaxAttempts, w.config.Backoff)
+		}
+		return nil
+	}
+
+	s.RecordSuccess(remoteID, now)
+	return nil
+}
@@ -0,0 +1,249 @@
+package autopost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock/clocktest"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/repository"
+	"github.com/ss49919201/myblog/api/internal/sns/connector"
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+)
+
+// memoryPosts implements the FindByID part of PostRepository.
+type memoryPosts struct {
+	repository.PostRepository
+
+	posts map[post.PostID]*post.Post
+}
+
+func (r *memoryPosts) FindByID(ctx context.Context, id post.PostID) (*post.Post, error) {
+	p, ok := r.posts[id]
+	if !ok {
+		return nil, errors.New("post not found")
+	}
+	return p, nil
+}
+
+// memoryStore is an in-memory ShareRepository and Transactor.
+type memoryStore struct {
+	*memoryPosts
+
+	shares []*sns.Share
+	inTx   bool
+}
+
+func (s *memoryStore) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.inTx = true
+	defer func() { s.inTx = false }()
+	return fn(ctx)
+}
+
+func (s *memoryStore) CreateIfAbsent(ctx context.Context, share *sns.Share) error {
+	s.shares = append(s.shares, share)
+	return nil
+}
+
+func (s *memoryStore) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*sns.Share, error) {
+	var due []*sns.Share
+	for _, share := range s.shares {
+		leased := share.LockedUntil != nil && share.LockedUntil.After(now)
+		if share.Status == sns.ShareStatusPending && !share.NextAttemptAt.After(now) && !leased && len(due) < limit {
+			due = append(due, share)
+		}
+	}
+	return due, nil
+}
+
+func (s *memoryStore) Update(ctx context.Context, share *sns.Share) error {
+	return nil
+}
+
+func newNetwork(t *testing.T, statusCodes ...int) (*httptest.Server, func() []string) {
+	t.Helper()
+
+	var (
+		mu    sync.Mutex
+		texts []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		mu.Lock()
+		texts = append(texts, body.Text)
+		code := statusCodes[min(len(texts), len(statusCodes))-1]
+		mu.Unlock()
+
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(`{"id":"remote-1"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), texts...)
+	}
+}
+
+func newTestStore(status post.PublicationStatus, now time.Time) (*memoryStore, *post.Post) {
+	slug := "hello"
+	p := &post.Post{ID: post.NewPostID(), Title: "Hello", Status: status, Tags: []string{"go"}, Slug: &slug, SNSAutoPost: true}
+	return &memoryStore{
+		memoryPosts: &memoryPosts{posts: map[post.PostID]*post.Post{p.ID: p}},
+		shares:      []*sns.Share{sns.NewShare(p.ID, "test", now)},
+	}, p
+}
+
+func TestWorker_PostsAndRecordsRemoteID(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv, texts := newNetwork(t, http.StatusOK)
+	store, _ := newTestStore(post.StatusPublished, now)
+	publisher := connector.NewHTTP("test", srv.URL, "", sns.Limits{MaxLength: 280}, srv.Client())
+	w := NewWorker(store, store.memoryPosts, store, []connector.SocialPublisher{publisher}, clocktest.NewFake(now), DefaultConfig("https://blog.example.com"))
+
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+
+	share := store.shares[0]
+	if share.Status != sns.ShareStatusPosted || share.RemoteID == nil || *share.RemoteID != "remote-1" {
+		t.Errorf("share = %+v, want posted as remote-1", share)
+	}
+	if got, want := texts(), []string{"Hello\nhttps://blog.example.com/posts/hello\n\n#go"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("posted %q, want %q", got, want)
+	}
+}
+
+func TestWorker_RetriesTransientFailures(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv, texts := newNetwork(t, http.StatusServiceUnavailable, http.StatusOK)
+	store, _ := newTestStore(post.StatusPublished, now)
+	clk := clocktest.NewFake(now)
+	config := DefaultConfig("https://blog.example.com")
+	config.Backoff = func(int) time.Duration { return time.Minute }
+	publisher := connector.NewHTTP("test", srv.URL, "", sns.Limits{MaxLength: 280}, srv.Client())
+	w := NewWorker(store, store.memoryPosts, store, []connector.SocialPublisher{publisher}, clk, config)
+	share := store.shares[0]
+
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	if share.Status != sns.ShareStatusPending || share.Attempts != 1 || !share.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("share = %+v, want pending retry in a minute", share)
+	}
+
+	// バックオフ中は再送しない
+	if processed, _ := w.Tick(context.Background()); processed != 0 {
+		t.Fatalf("processed %d shares during backoff, want 0", processed)
+	}
+
+	clk.Advance(time.Minute)
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	if share.Status != sns.ShareStatusPosted || share.Attempts != 2 {
+		t.Errorf("share = %+v, want posted after 2 attempts", share)
+	}
+	if n := len(texts()); n != 2 {
+		t.Errorf("network received %d requests, want 2", n)
+	}
+}
+
+func TestWorker_GivesUpOnPermanentFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv, texts := newNetwork(t, http.StatusUnprocessableEntity)
+	store, _ := newTestStore(post.StatusPublished, now)
+	clk := clocktest.NewFake(now)
+	publisher := connector.NewHTTP("test", srv.URL, "", sns.Limits{MaxLength: 280}, srv.Client())
+	w := NewWorker(store, store.memoryPosts, store, []connector.SocialPublisher{publisher}, clk, DefaultConfig("https://blog.example.com"))
+
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	clk.Advance(24 * time.Hour)
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+
+	if share := store.shares[0]; share.Status != sns.ShareStatusDead || share.Attempts != 1 {
+		t.Errorf("share = %+v, want dead after 1 attempt", share)
+	}
+	if n := len(texts()); n != 1 {
+		t.Errorf("network received %d requests, want 1", n)
+	}
+}
+
+func TestWorker_SkipsUnpublishedPosts(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv, texts := newNetwork(t, http.StatusOK)
+	store, _ := newTestStore(post.StatusDraft, now)
+	publisher := connector.NewHTTP("test", srv.URL, "", sns.Limits{MaxLength: 280}, srv.Client())
+	w := NewWorker(store, store.memoryPosts, store, []connector.SocialPublisher{publisher}, clocktest.NewFake(now), DefaultConfig("https://blog.example.com"))
+
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+
+	if share := store.shares[0]; share.Status != sns.ShareStatusSkipped {
+		t.Errorf("status = %s, want skipped", share.Status)
+	}
+	if n := len(texts()); n != 0 {
+		t.Errorf("network received %d requests, want 0", n)
+	}
+}
+
+// txCheckingPublisher fails the test when it is called inside a transaction.
+type txCheckingPublisher struct {
+	t     *testing.T
+	store *memoryStore
+	calls int
+}
+
+func (p *txCheckingPublisher) Network() string    { return "test" }
+func (p *txCheckingPublisher) Limits() sns.Limits { return sns.Limits{MaxLength: 280} }
+
+func (p *txCheckingPublisher) Publish(ctx context.Context, key string, m sns.Message) (string, error) {
+	if p.store.inTx {
+		p.t.Error("Publish called inside the claim transaction")
+	}
+	p.calls++
+	return "remote-1", nil
+}
+
+func TestWorker_PublishesOutsideTransactionUnderLease(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store, _ := newTestStore(post.StatusPublished, now)
+	clk := clocktest.NewFake(now)
+	config := DefaultConfig("https://blog.example.com")
+	publisher := &txCheckingPublisher{t: t, store: store}
+	w := NewWorker(store, store.memoryPosts, store, []connector.SocialPublisher{publisher}, clk, config)
+	share := store.shares[0]
+
+	// 別のワーカーが送信中のまま止まった共有
+	share.Claim(now, config.Lease)
+	if processed, err := w.Tick(context.Background()); err != nil || processed != 0 {
+		t.Fatalf("Tick() = %d, %v, want 0, nil while leased", processed, err)
+	}
+
+	clk.Advance(config.Lease)
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	if publisher.calls != 1 {
+		t.Errorf("Publish called %d times, want 1", publisher.calls)
+	}
+	if share.Status != sns.ShareStatusPosted || share.Attempts != 2 || share.LockedUntil != nil {
+		t.Errorf("share = %+v, want posted after 2 attempts without a lease", share)
+	}
+}
@@ -0,0 +1,97 @@
+// Package connector posts statuses to social networks.
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+)
+
+// SocialPublisher posts a status to one network.
+type SocialPublisher interface {
+	// Network is the stable name the remote IDs are recorded under.
+	Network() string
+	Limits() sns.Limits
+	// Publish posts m and returns the ID the network assigned to it.
+	// key is the same on every retry of a share so that networks supporting
+	// idempotency keys do not post it twice.
+	Publish(ctx context.Context, key string, m sns.Message) (remoteID string, err error)
+}
+
+// PermanentError is returned when the network rejected the status in a way
+// that retrying cannot fix, e.g. a 422 response.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+func AsPermanentError(err error) (*PermanentError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *PermanentError
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
+
+// postJSON sends body to url and decodes the response into out.
+// Client errors other than 408 and 429 are reported as PermanentError.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		err := fmt.Errorf("%s responded with status %d: %s", url, resp.StatusCode, bytes.TrimSpace(respBody))
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+			resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			return &PermanentError{Err: err}
+		}
+		return err
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", url, err)
+	}
+
+	return nil
+}
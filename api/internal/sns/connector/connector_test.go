@@ -0,0 +1,113 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+)
+
+func TestMastodon_Publish(t *testing.T) {
+	var (
+		gotAuth, gotKey string
+		gotBody         map[string]string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/statuses" {
+			t.Errorf("request = %s %s, want POST /api/v1/statuses", r.Method, r.URL.Path)
+		}
+		gotAuth = r.Header.Get("Authorization")
+		gotKey = r.Header.Get("Idempotency-Key")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{"id":"109372843234","content":"<p>Hello</p>"}`))
+	}))
+	defer srv.Close()
+
+	m := NewMastodon(srv.URL+"/", "token", MastodonLimits, srv.Client())
+	remoteID, err := m.Publish(context.Background(), "share-1", sns.Message{Text: "Hello"})
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	if remoteID != "109372843234" {
+		t.Errorf("remoteID = %q, want 109372843234", remoteID)
+	}
+	if gotAuth != "Bearer token" {
+		t.Errorf("Authorization = %q, want Bearer token", gotAuth)
+	}
+	if gotKey != "share-1" {
+		t.Errorf("Idempotency-Key = %q, want share-1", gotKey)
+	}
+	if gotBody["status"] != "Hello" || gotBody["visibility"] != "public" {
+		t.Errorf("body = %v, want public status Hello", gotBody)
+	}
+}
+
+func TestHTTP_Publish(t *testing.T) {
+	image := "https://blog.example.com/image.png"
+	var got struct {
+		Key string `json:"key"`
+		sns.Message
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Authorization = %q, want none", auth)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"id":"remote-1"}`))
+	}))
+	defer srv.Close()
+
+	h := NewHTTP("bluesky-bridge", srv.URL, "", sns.Limits{MaxLength: 300}, srv.Client())
+	msg := sns.Message{Text: "Hello", URL: "https://blog.example.com/posts/hello", ImageURL: &image, Hashtags: []string{"#go"}}
+	remoteID, err := h.Publish(context.Background(), "share-1", msg)
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	if remoteID != "remote-1" {
+		t.Errorf("remoteID = %q, want remote-1", remoteID)
+	}
+	if h.Network() != "bluesky-bridge" {
+		t.Errorf("Network() = %q, want bluesky-bridge", h.Network())
+	}
+	if got.Key != "share-1" || got.Text != msg.Text || got.URL != msg.URL || got.ImageURL == nil || *got.ImageURL != image || len(got.Hashtags) != 1 {
+		t.Errorf("body = %+v, want %+v with key share-1", got, msg)
+	}
+}
+
+func TestPublish_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		wantPermanent bool
+	}{
+		{name: "unprocessable", status: http.StatusUnprocessableEntity, body: `{"error":"Validation failed"}`, wantPermanent: true},
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{"error":"invalid token"}`, wantPermanent: true},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: `{"error":"Too many requests"}`},
+		{name: "server error", status: http.StatusBadGateway},
+		{name: "missing id", status: http.StatusOK, body: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			_, err := NewMastodon(srv.URL, "token", MastodonLimits, srv.Client()).Publish(context.Background(), "share-1", sns.Message{Text: "Hello"})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if _, ok := AsPermanentError(err); ok != tt.wantPermanent {
+				t.Errorf("permanent = %v, want %v (err: %v)", ok, tt.wantPermanent, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+)
+
+// HTTP posts statuses to an arbitrary endpoint, e.g. a bridge to a network
+// without a dedicated connector. The request body is
+//
+//	{"key": "...", "text": "...", "url": "...", "imageUrl": "...", "hashtags": ["#go"]}
+//
+// and the endpoint must answer 2xx with {"id": "<remote id>"}.
+type HTTP struct {
+	network string
+	url     string
+	token   string
+	limits  sns.Limits
+	client  *http.Client
+}
+
+// NewHTTP creates a connector recording remote IDs under network.
+// token is sent as a bearer token when it is not empty.
+func NewHTTP(network, url, token string, limits sns.Limits, client *http.Client) *HTTP {
+	return &HTTP{network: network, url: url, token: token, limits: limits, client: client}
+}
+
+func (h *HTTP) Network() string {
+	return h.network
+}
+
+func (h *HTTP) Limits() sns.Limits {
+	return h.limits
+}
+
+func (h *HTTP) Publish(ctx context.Context, key string, msg sns.Message) (string, error) {
+	header := http.Header{}
+	if h.token != "" {
+		header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	var response struct {
+		ID string `json:"id"`
+	}
+	if err := postJSON(ctx, h.client, h.url, header, struct {
+		Key string `json:"key"`
+		sns.Message
+	}{Key: key, Message: msg}, &response); err != nil {
+		return "", err
+	}
+
+	if response.ID == "" {
+		return "", errors.New("response has no id")
+	}
+	return response.ID, nil
+}
@@ -0,0 +1,64 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+)
+
+// MastodonLimits are the defaults of a stock Mastodon instance.
+var MastodonLimits = sns.Limits{MaxLength: 500, URLLength: 23}
+
+// Mastodon posts public statuses through the Mastodon REST API. Any server
+// implementing POST /api/v1/statuses (Pleroma, Misskey's compatibility
+// layer, ...) works as well.
+//
+// The featured image is not uploaded; instances show it in the link preview
+// card built from the post's OpenGraph tags.
+type Mastodon struct {
+	baseURL     string
+	accessToken string
+	limits      sns.Limits
+	client      *http.Client
+}
+
+func NewMastodon(baseURL, accessToken string, limits sns.Limits, client *http.Client) *Mastodon {
+	return &Mastodon{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		accessToken: accessToken,
+		limits:      limits,
+		client:      client,
+	}
+}
+
+func (m *Mastodon) Network() string {
+	return "mastodon"
+}
+
+func (m *Mastodon) Limits() sns.Limits {
+	return m.limits
+}
+
+func (m *Mastodon) Publish(ctx context.Context, key string, msg sns.Message) (string, error) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+m.accessToken)
+	header.Set("Idempotency-Key", key)
+
+	var status struct {
+		ID string `json:"id"`
+	}
+	if err := postJSON(ctx, m.client, m.baseURL+"/api/v1/statuses", header, map[string]string{
+		"status":     msg.Text,
+		"visibility": "public",
+	}, &status); err != nil {
+		return "", err
+	}
+
+	if status.ID == "" {
+		return "", errors.New("mastodon response has no status id")
+	}
+	return status.ID, nil
+}
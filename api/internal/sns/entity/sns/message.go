@@ -0,0 +1,129 @@
+package sns
+
+import (
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+// Limits describes how a network measures a status.
+type Limits struct {
+	// MaxLength is the maximum number of characters in a status.
+	MaxLength int
+	// URLLength is the number of characters every link counts as, regardless
+	// of its real length (23 on Mastodon). Zero counts links as written.
+	URLLength int
+}
+
+// Message is a status announcing a post.
+type Message struct {
+	// Text is the full status: title, link and hashtags.
+	Text     string   `json:"text"`
+	URL      string   `json:"url"`
+	ImageURL *string  `json:"imageUrl"`
+	Hashtags []string `json:"hashtags"`
+}
+
+const ellipsis = "…"
+
+// PostURL returns the public URL of p under baseURL, preferring the slug.
+func PostURL(baseURL string, p *post.Post) string {
+	path := p.ID.String()
+	if p.Slug != nil && *p.Slug != "" {
+		path = *p.Slug
+	}
+
+	return strings.TrimRight(baseURL, "/") + "/posts/" + url.PathEscape(path)
+}
+
+// FormatMessage builds the status for p:
+//
+//	<title>
+//	<postURL>
+//
+//	#tag1 #tag2
+//
+// The title is shortened with an ellipsis when the link would not fit, and
+// hashtags that do not fit in the remaining space are dropped.
+func FormatMessage(p *post.Post, postURL string, limits Limits) Message {
+	urlLength := utf8.RuneCountInString(postURL)
+	if limits.URLLength > 0 {
+		urlLength = limits.URLLength
+	}
+
+	// タイトルと URL の間の改行 1 文字分を除いた長さまでタイトルを詰める
+	title := truncate(p.Title, limits.MaxLength-urlLength-1)
+	text := postURL
+	if title != "" {
+		text = title + "\n" + postURL
+	}
+	length := utf8.RuneCountInString(title) + 1 + urlLength
+	if title == "" {
+		length = urlLength
+	}
+
+	var hashtags []string
+	seen := map[string]bool{}
+	for _, tag := range p.Tags {
+		hashtag := Hashtag(tag)
+		if hashtag == "" || seen[strings.ToLower(hashtag)] {
+			continue
+		}
+
+		separator := " "
+		if len(hashtags) == 0 {
+			separator = "\n\n"
+		}
+		added := utf8.RuneCountInString(separator) + utf8.RuneCountInString(hashtag)
+		if length+added > limits.MaxLength {
+			continue
+		}
+
+		seen[strings.ToLower(hashtag)] = true
+		hashtags = append(hashtags, hashtag)
+		text += separator + hashtag
+		length += added
+	}
+
+	return Message{
+		Text:     text,
+		URL:      postURL,
+		ImageURL: p.FeaturedImageURL,
+		Hashtags: hashtags,
+	}
+}
+
+// Hashtag turns a tag into a hashtag by dropping every character that
+// networks do not accept in one, e.g. "Go 1.24" becomes "#Go124".
+// It returns "" when nothing is left.
+func Hashtag(tag string) string {
+	var b strings.Builder
+	for _, r := range tag {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+
+	if b.Len() == 0 {
+		return ""
+	}
+	return "#" + b.String()
+}
+
+// truncate shortens s to at most max characters, ending with an ellipsis
+// when anything was cut.
+func truncate(s string, max int) string {
+	s = strings.TrimSpace(s)
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	if max <= 0 {
+		return ""
+	}
+
+	runes := []rune(s)
+	return strings.TrimSpace(string(runes[:max-1])) + ellipsis
+}
@@ -0,0 +1,105 @@
+package sns
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+func TestHashtag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "go", want: "#go"},
+		{tag: "Go 1.24", want: "#Go124"},
+		{tag: "snake_case", want: "#snake_case"},
+		{tag: "日本語 タグ", want: "#日本語タグ"},
+		{tag: "C++", want: "#C"},
+		{tag: "!!!", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := Hashtag(tt.tag); got != tt.want {
+				t.Errorf("Hashtag(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostURL(t *testing.T) {
+	slug := "hello world"
+	p := &post.Post{ID: post.NewPostID()}
+
+	if got, want := PostURL("https://blog.example.com/", p), "https://blog.example.com/posts/"+p.ID.String(); got != want {
+		t.Errorf("PostURL without slug = %q, want %q", got, want)
+	}
+
+	p.Slug = &slug
+	if got, want := PostURL("https://blog.example.com", p), "https://blog.example.com/posts/hello%20world"; got != want {
+		t.Errorf("PostURL with slug = %q, want %q", got, want)
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	const url = "https://blog.example.com/posts/hello"
+	image := "https://blog.example.com/image.png"
+
+	tests := []struct {
+		name   string
+		title  string
+		tags   []string
+		limits Limits
+		want   string
+	}{
+		{
+			name:   "fits",
+			title:  "Hello",
+			tags:   []string{"go", "Go", "blog"},
+			limits: Limits{MaxLength: 500, URLLength: 23},
+			want:   "Hello\n" + url + "\n\n#go #blog",
+		},
+		{
+			name:   "drops hashtags that do not fit",
+			title:  "Hello",
+			tags:   []string{"long_hashtag", "go"},
+			limits: Limits{MaxLength: 5 + 1 + 23 + 2 + 3, URLLength: 23},
+			want:   "Hello\n" + url + "\n\n#go",
+		},
+		{
+			name:   "truncates title",
+			title:  "こんにちは世界",
+			tags:   []string{"go"},
+			limits: Limits{MaxLength: 5 + 1 + 23, URLLength: 23},
+			want:   "こんにち…\n" + url,
+		},
+		{
+			name:   "counts links as written without URLLength",
+			title:  "Hello",
+			limits: Limits{MaxLength: 4 + 1 + utf8.RuneCountInString(url)},
+			want:   "Hel…\n" + url,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &post.Post{ID: post.NewPostID(), Title: tt.title, Tags: tt.tags, FeaturedImageURL: &image}
+
+			m := FormatMessage(p, url, tt.limits)
+			if m.Text != tt.want {
+				t.Errorf("Text = %q, want %q", m.Text, tt.want)
+			}
+			if m.URL != url || m.ImageURL == nil || *m.ImageURL != image {
+				t.Errorf("message = %+v, want url and image of the post", m)
+			}
+			for _, h := range m.Hashtags {
+				if !strings.Contains(m.Text, h) {
+					t.Errorf("hashtag %q is not in the text", h)
+				}
+			}
+		})
+	}
+}
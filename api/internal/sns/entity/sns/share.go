@@ -0,0 +1,116 @@
+package sns
+
+import (
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/id"
+)
+
+type ShareID id.UUID
+
+func (s ShareID) String() string {
+	return id.UUID(s).String()
+}
+
+func (s ShareID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s.String() + `"`), nil
+}
+
+func ParseShareID(v string) (ShareID, error) {
+	parsed, err := id.ParseUUID(v)
+	if err != nil {
+		return ShareID{}, err
+	}
+
+	return ShareID(parsed), nil
+}
+
+type ShareStatus string
+
+const (
+	ShareStatusPending ShareStatus = "pending"
+	ShareStatusPosted  ShareStatus = "posted"
+	// ShareStatusSkipped marks a share that was not sent, e.g. because the
+	// post was unpublished before its turn came.
+	ShareStatusSkipped ShareStatus = "skipped"
+	// ShareStatusDead marks a share that failed permanently or too many times.
+	ShareStatusDead ShareStatus = "dead"
+)
+
+// Share is the announcement of one post on one network.
+// A post is announced at most once per network.
+type Share struct {
+	ID      ShareID     `json:"id"`
+	PostID  post.PostID `json:"postId"`
+	Network string      `json:"network"`
+	Status  ShareStatus `json:"status"`
+	// RemoteID is the ID the network assigned to the status.
+	RemoteID      *string    `json:"remoteId"`
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"lastError"`
+	NextAttemptAt time.Time  `json:"nextAttemptAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	PostedAt      *time.Time `json:"postedAt"`
+	// LockedUntil is the end of the lease held by the worker posting the
+	// share. No other worker claims it before then.
+	LockedUntil *time.Time `json:"-"`
+}
+
+func NewShare(postID post.PostID, network string, now time.Time) *Share {
+	return &Share{
+		ID:            ShareID(id.GenerateUUID()),
+		PostID:        postID,
+		Network:       network,
+		Status:        ShareStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+// Claim takes a lease on the share for the attempt about to be made. The
+// attempt is counted here rather than when it is recorded, so that an
+// attempt cut short by a crash still counts once the lease expires.
+func (s *Share) Claim(now time.Time, lease time.Duration) {
+	s.Attempts++
+	lockedUntil := now.Add(lease)
+	s.LockedUntil = &lockedUntil
+}
+
+// RecordSuccess marks the share as posted under remoteID.
+func (s *Share) RecordSuccess(remoteID string, now time.Time) {
+	s.LockedUntil = nil
+	s.Status = ShareStatusPosted
+	s.RemoteID = &remoteID
+	s.LastError = nil
+	s.PostedAt = &now
+}
+
+// RecordFailure records a failed attempt. The share is retried after
+// backoff(attempts) until maxAttempts is reached, then it is marked dead.
+func (s *Share) RecordFailure(message string, now time.Time, maxAttempts int, backoff func(attempts int) time.Duration) {
+	s.LockedUntil = nil
+	s.LastError = &message
+
+	if s.Attempts >= maxAttempts {
+		s.Status = ShareStatusDead
+		return
+	}
+
+	s.NextAttemptAt = now.Add(backoff(s.Attempts))
+}
+
+// RecordPermanentFailure marks the share dead without further retries,
+// for errors that retrying cannot fix such as a rejected status.
+func (s *Share) RecordPermanentFailure(message string) {
+	s.LockedUntil = nil
+	s.Status = ShareStatusDead
+	s.LastError = &message
+}
+
+// Skip gives up on the share without sending it.
+func (s *Share) Skip(reason string) {
+	s.LockedUntil = nil
+	s.Status = ShareStatusSkipped
+	s.LastError = &reason
+}
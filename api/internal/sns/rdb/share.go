@@ -0,0 +1,90 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	postrdb "github.com/ss49919201/myblog/api/internal/post/rdb"
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+	"github.com/ss49919201/myblog/api/internal/sns/repository"
+)
+
+const selectShareColumns = `BIN_TO_UUID(id), BIN_TO_UUID(post_id), network, status, remote_id, attempts, last_error, next_attempt_at, created_at, posted_at, locked_until`
+
+type ShareRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewShareRepository(db *sql.DB) repository.ShareRepository {
+	return &ShareRepositoryImpl{db: db}
+}
+
+func (r *ShareRepositoryImpl) CreateIfAbsent(ctx context.Context, s *sns.Share) error {
+	// uk_post_network で同じネットワークへの二重投稿を防ぐ
+	query := `INSERT IGNORE INTO sns_shares (id, post_id, network, status, attempts, next_attempt_at, created_at) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), ?, ?, ?, ?, ?)`
+
+	_, err := postrdb.Conn(ctx, r.db).ExecContext(ctx, query,
+		s.ID.String(),
+		s.PostID.String(),
+		s.Network,
+		s.Status,
+		s.Attempts,
+		s.NextAttemptAt,
+		s.CreatedAt,
+	)
+	return err
+}
+
+func (r *ShareRepositoryImpl) ClaimDue(ctx context.Context, now time.Time, limit int) ([]*sns.Share, error) {
+	query := `SELECT ` + selectShareColumns + ` FROM sns_shares WHERE status = 'pending' AND next_attempt_at <= ? AND (locked_until IS NULL OR locked_until <= ?) ORDER BY next_attempt_at LIMIT ? FOR UPDATE SKIP LOCKED`
+
+	rows, err := postrdb.Conn(ctx, r.db).QueryContext(ctx, query, now, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var shares []*sns.Share
+	for rows.Next() {
+		s, err := scanShare(rows)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, s)
+	}
+
+	return shares, rows.Err()
+}
+
+func (r *ShareRepositoryImpl) Update(ctx context.Context, s *sns.Share) error {
+	query := `UPDATE sns_shares SET status = ?, remote_id = ?, attempts = ?, last_error = ?, next_attempt_at = ?, posted_at = ?, locked_until = ? WHERE id = UUID_TO_BIN(?)`
+
+	_, err := postrdb.Conn(ctx, r.db).ExecContext(ctx, query, s.Status, s.RemoteID, s.Attempts, s.LastError, s.NextAttemptAt, s.PostedAt, s.LockedUntil, s.ID.String())
+	return err
+}
+
+func scanShare(rows *sql.Rows) (*sns.Share, error) {
+	var (
+		idStr, postIDStr, status string
+		s                        sns.Share
+	)
+	if err := rows.Scan(&idStr, &postIDStr, &s.Network, &status, &s.RemoteID, &s.Attempts, &s.LastError, &s.NextAttemptAt, &s.CreatedAt, &s.PostedAt, &s.LockedUntil); err != nil {
+		return nil, err
+	}
+
+	shareID, err := sns.ParseShareID(idStr)
+	if err != nil {
+		return nil, err
+	}
+	postID, err := post.ParsePostID(postIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	s.ID = shareID
+	s.PostID = postID
+	s.Status = sns.ShareStatus(status)
+	return &s, nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+)
+
+type ShareRepository interface {
+	// CreateIfAbsent stores s unless the post already has a share on the
+	// same network, so a post is never announced twice.
+	CreateIfAbsent(ctx context.Context, s *sns.Share) error
+	// ClaimDue locks up to limit pending shares due at now, skipping rows
+	// locked by another worker and shares whose lease has not expired. It
+	// must be called inside a transaction.
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]*sns.Share, error)
+	Update(ctx context.Context, s *sns.Share) error
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	postrepository "github.com/ss49919201/myblog/api/internal/post/repository"
+	"github.com/ss49919201/myblog/api/internal/sns/entity/sns"
+	"github.com/ss49919201/myblog/api/internal/sns/repository"
+)
+
+// EnqueueSharesUsecase schedules the announcement of a post on every
+// configured network when a post with SNSAutoPost is published.
+// It runs as an outbox relay handler, so the shares are committed in the
+// same transaction that marks the outbox message as delivered.
+type EnqueueSharesUsecase struct {
+	posts    postrepository.PostRepository
+	shares   repository.ShareRepository
+	networks []string
+}
+
+func NewEnqueueSharesUsecase(posts postrepository.PostRepository, shares repository.ShareRepository, networks []string) *EnqueueSharesUsecase {
+	return &EnqueueSharesUsecase{posts: posts, shares: shares, networks: networks}
+}
+
+func (u *EnqueueSharesUsecase) Execute(ctx context.Context, e post.PostEvent) error {
+	if e.Type != post.PostEventTypePublishPost || len(u.networks) == 0 {
+		return nil
+	}
+
+	p, err := u.posts.FindByID(ctx, e.AggregateID)
+	if err != nil {
+		if err.Error() == "post not found" {
+			return nil
+		}
+		return err
+	}
+	if !p.SNSAutoPost {
+		return nil
+	}
+
+	now := time.Now()
+	for _, network := range u.networks {
+		if err := u.shares.CreateIfAbsent(ctx, sns.NewShare(p.ID, network, now)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
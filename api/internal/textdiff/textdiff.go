@@ -0,0 +1,270 @@
+// Package textdiff computes line and word diffs of UTF-8 text.
+//
+// Text is split into tokens on rune boundaries, so multi-byte characters are
+// never cut in half. Japanese is written without spaces, so in word mode
+// every kanji and kana is a token of its own while runs of Latin letters and
+// digits form one word.
+package textdiff
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Op is the kind of an Edit.
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpInsert Op = "insert"
+	OpDelete Op = "delete"
+)
+
+// Edit is a run of text that is kept, inserted or deleted. Adjacent edits
+// never share the same Op.
+type Edit struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+// Mode selects the granularity of a diff.
+type Mode string
+
+const (
+	ModeLine Mode = "line"
+	ModeWord Mode = "word"
+)
+
+func (m Mode) Valid() bool {
+	return m == ModeLine || m == ModeWord
+}
+
+// MaxEdits bounds the number of inserted and deleted tokens the diff
+// searches for. Beyond it, the differing middle of the texts is reported as
+// one deletion followed by one insertion.
+const MaxEdits = 1000
+
+// Diff returns the edits turning a into b. Concatenating the equal and
+// delete edits yields a; the equal and insert edits yield b.
+func Diff(a, b string, mode Mode) []Edit {
+	split := Lines
+	if mode == ModeWord {
+		split = Words
+	}
+
+	return diffTokens(split(a), split(b))
+}
+
+// Lines splits s into lines, each keeping its trailing newline.
+func Lines(s string) []string {
+	var lines []string
+	for s != "" {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			lines = append(lines, s)
+			break
+		}
+		lines = append(lines, s[:i+1])
+		s = s[i+1:]
+	}
+	return lines
+}
+
+// Words splits s into words, runs of white space and single other
+// characters. Kanji, kana and other characters of scripts written without
+// spaces are single tokens.
+func Words(s string) []string {
+	var words []string
+	for s != "" {
+		r, size := utf8.DecodeRuneInString(s)
+		class := classOf(r)
+
+		end := size
+		if class == classWord || class == classSpace {
+			for end < len(s) {
+				next, nextSize := utf8.DecodeRuneInString(s[end:])
+				if classOf(next) != class {
+					break
+				}
+				end += nextSize
+			}
+		}
+
+		words = append(words, s[:end])
+		s = s[end:]
+	}
+	return words
+}
+
+type runeClass int
+
+const (
+	classOther runeClass = iota
+	classWord
+	classSpace
+)
+
+func classOf(r rune) runeClass {
+	switch {
+	case unicode.IsSpace(r):
+		return classSpace
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul, unicode.Thai):
+		return classOther
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_':
+		return classWord
+	default:
+		return classOther
+	}
+}
+
+func diffTokens(a, b []string) []Edit {
+	// 共通の先頭と末尾は探索の対象から外す
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []Edit
+	edits = appendEdit(edits, OpEqual, a[:prefix]...)
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	edits = appendEdit(edits, OpEqual, a[len(a)-suffix:]...)
+
+	return merge(edits)
+}
+
+// myers finds a shortest edit script with the algorithm from E. Myers,
+// "An O(ND) Difference Algorithm and Its Variations" (1986).
+func myers(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return appendEdit(appendEdit(nil, OpDelete, a...), OpInsert, b...)
+	}
+
+	maxD := min(n+m, MaxEdits)
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+				return backtrack(a, b, trace)
+			}
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+
+	// 差分が大きすぎる場合は全体の置き換えとして扱う
+	return appendEdit(appendEdit(nil, OpDelete, a...), OpInsert, b...)
+}
+
+// backtrack walks the furthest reaching paths recorded in trace, where
+// trace[d][k+d] is the x reached on diagonal k with d edits, from the end of
+// both inputs back to their start.
+func backtrack(a, b []string, trace [][]int) []Edit {
+	at := func(d, k int) int {
+		return trace[d][k+d]
+	}
+
+	var reversed []Edit
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && at(d-1, k-1) < at(d-1, k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(d-1, prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, Edit{Op: OpEqual, Text: a[x]})
+		}
+		if x == prevX {
+			y--
+			reversed = append(reversed, Edit{Op: OpInsert, Text: b[y]})
+		} else {
+			x--
+			reversed = append(reversed, Edit{Op: OpDelete, Text: a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		reversed = append(reversed, Edit{Op: OpEqual, Text: a[x]})
+	}
+
+	edits := make([]Edit, 0, len(reversed))
+	for i := len(reversed) - 1; i >= 0; i-- {
+		edits = append(edits, reversed[i])
+	}
+	return edits
+}
+
+func appendEdit(edits []Edit, op Op, tokens ...string) []Edit {
+	for _, t := range tokens {
+		edits = append(edits, Edit{Op: op, Text: t})
+	}
+	return edits
+}
+
+// merge joins adjacent edits with the same Op and lists the deletions of a
+// change before its insertions.
+func merge(edits []Edit) []Edit {
+	merged := []Edit{}
+	var deleted, inserted strings.Builder
+
+	flush := func() {
+		if deleted.Len() > 0 {
+			merged = append(merged, Edit{Op: OpDelete, Text: deleted.String()})
+			deleted.Reset()
+		}
+		if inserted.Len() > 0 {
+			merged = append(merged, Edit{Op: OpInsert, Text: inserted.String()})
+			inserted.Reset()
+		}
+	}
+
+	for _, e := range edits {
+		switch e.Op {
+		case OpDelete:
+			deleted.WriteString(e.Text)
+		case OpInsert:
+			inserted.WriteString(e.Text)
+		default:
+			flush()
+			if n := len(merged); n > 0 && merged[n-1].Op == OpEqual {
+				merged[n-1].Text += e.Text
+				continue
+			}
+			merged = append(merged, e)
+		}
+	}
+	flush()
+
+	return merged
+}
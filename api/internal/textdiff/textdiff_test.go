@@ -0,0 +1,130 @@
+package textdiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{in: "hello, world", want: []string{"hello", ",", " ", "world"}},
+		{in: "Go 1.24", want: []string{"Go", " ", "1", ".", "24"}},
+		{in: "Goのテスト", want: []string{"Go", "の", "テ", "ス", "ト"}},
+		{in: "東京\n大阪", want: []string{"東", "京", "\n", "大", "阪"}},
+		{in: "café", want: []string{"café"}},
+		{in: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := Words(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Words(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLines(t *testing.T) {
+	got := Lines("a\nb\n\nc")
+	want := []string{"a\n", "b\n", "\n", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		mode Mode
+		want []Edit
+	}{
+		{
+			name: "identical",
+			a:    "same\n",
+			b:    "same\n",
+			mode: ModeLine,
+			want: []Edit{{OpEqual, "same\n"}},
+		},
+		{
+			name: "both empty",
+			mode: ModeLine,
+			want: []Edit{},
+		},
+		{
+			name: "changed line",
+			a:    "one\ntwo\nthree\n",
+			b:    "one\n2\nthree\n",
+			mode: ModeLine,
+			want: []Edit{{OpEqual, "one\n"}, {OpDelete, "two\n"}, {OpInsert, "2\n"}, {OpEqual, "three\n"}},
+		},
+		{
+			name: "inserted and deleted lines",
+			a:    "a\nb\nc\n",
+			b:    "b\nc\nd\n",
+			mode: ModeLine,
+			want: []Edit{{OpDelete, "a\n"}, {OpEqual, "b\nc\n"}, {OpInsert, "d\n"}},
+		},
+		{
+			name: "changed word",
+			a:    "the quick fox",
+			b:    "the slow fox",
+			mode: ModeWord,
+			want: []Edit{{OpEqual, "the "}, {OpDelete, "quick"}, {OpInsert, "slow"}, {OpEqual, " fox"}},
+		},
+		{
+			name: "japanese characters",
+			a:    "今日は晴れです。",
+			b:    "今日は雨です。",
+			mode: ModeWord,
+			want: []Edit{{OpEqual, "今日は"}, {OpDelete, "晴れ"}, {OpInsert, "雨"}, {OpEqual, "です。"}},
+		},
+		{
+			name: "same leading byte in different characters",
+			a:    "あい",
+			b:    "あう",
+			mode: ModeWord,
+			want: []Edit{{OpEqual, "あ"}, {OpDelete, "い"}, {OpInsert, "う"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.a, tt.b, tt.mode); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff_Reconstructs(t *testing.T) {
+	a := "吾輩は猫である。名前はまだ無い。\nどこで生れたかとんと見当がつかぬ。\nGo is fun.\n"
+	b := "吾輩は犬である。名前はポチ。\nGo is really fun!\nどこで生れたかとんと見当がつかぬ。\n"
+
+	for _, mode := range []Mode{ModeLine, ModeWord} {
+		var before, after strings.Builder
+		for _, e := range Diff(a, b, mode) {
+			if e.Op != OpInsert {
+				before.WriteString(e.Text)
+			}
+			if e.Op != OpDelete {
+				after.WriteString(e.Text)
+			}
+		}
+		if before.String() != a || after.String() != b {
+			t.Errorf("%s: edits rebuild %q and %q", mode, before.String(), after.String())
+		}
+	}
+}
+
+func TestDiff_FallsBackBeyondMaxEdits(t *testing.T) {
+	a := strings.Repeat("a\n", MaxEdits)
+	b := strings.Repeat("b\n", MaxEdits)
+
+	want := []Edit{{OpDelete, a}, {OpInsert, b}}
+	if got := Diff(a, b, ModeLine); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() returned %d edits, want a single replacement", len(got))
+	}
+}
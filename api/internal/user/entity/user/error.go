@@ -0,0 +1,66 @@
+package user
+
+import "errors"
+
+type ErrUserNotFound struct {
+}
+
+func (e *ErrUserNotFound) Error() string {
+	return "user not found"
+}
+
+func AsErrUserNotFound(err error) (*ErrUserNotFound, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrUserNotFound
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
+
+// ErrInvalidCredentials is returned when an email/password pair does not match.
+// It intentionally does not tell which of the two was wrong.
+type ErrInvalidCredentials struct {
+}
+
+func (e *ErrInvalidCredentials) Error() string {
+	return "invalid credentials"
+}
+
+func AsErrInvalidCredentials(err error) (*ErrInvalidCredentials, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrInvalidCredentials
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
+
+// ErrUnauthenticated is returned when a request carries no valid identity.
+type ErrUnauthenticated struct {
+}
+
+func (e *ErrUnauthenticated) Error() string {
+	return "authentication required"
+}
+
+func AsErrUnauthenticated(err error) (*ErrUnauthenticated, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrUnauthenticated
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,155 @@
+package user
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/id"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserID id.UUID
+
+func (u UserID) String() string {
+	return id.UUID(u).String()
+}
+
+func (u UserID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + u.String() + `"`), nil
+}
+
+func ParseUserID(userID string) (UserID, error) {
+	parsedID, err := id.ParseUUID(userID)
+	if err != nil {
+		return UserID{}, err
+	}
+
+	return UserID(parsedID), nil
+}
+
+func NewUserID() UserID {
+	return UserID(id.GenerateUUID())
+}
+
+type Role string
+
+const (
+	RoleGeneral Role = "general"
+	RoleEditor  Role = "editor"
+	RoleAdmin   Role = "admin"
+)
+
+func (r Role) String() string {
+	return string(r)
+}
+
+func (r Role) Valid() bool {
+	switch r {
+	case RoleGeneral, RoleEditor, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+const (
+	minPasswordLength = 8
+	// bcrypt は72バイトを超える入力を扱えない
+	maxPasswordLength = 72
+)
+
+type User struct {
+	ID           UserID    `json:"id"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"-"`
+	Role         Role      `json:"role"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// VerifyPassword reports whether password matches the stored hash.
+func (u *User) VerifyPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+// dummyPasswordHash is a bcrypt hash at bcrypt.DefaultCost that no password
+// of a real user is compared with.
+const dummyPasswordHash = "$2a$10$3jegXWIbeUvdbXUy8axcGOb07KMRG67LjhHOFavgBccKdNavzKtNS"
+
+// VerifyNoPassword spends the same time as VerifyPassword when no user
+// matches, so that response times do not reveal which emails are registered.
+// It always reports false.
+func VerifyNoPassword(password string) bool {
+	_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+	return false
+}
+
+// NormalizeEmail returns email in the form it is stored in.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func ValidateEmail(email string) error {
+	if _, err := mail.ParseAddress(email); err != nil || strings.Contains(email, " ") {
+		return errors.New("email is invalid")
+	}
+
+	return nil
+}
+
+func ValidatePassword(password string) error {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return errors.New("password must be between 8 and 72 bytes")
+	}
+
+	return nil
+}
+
+// Construct creates a new User, hashing the plain text password.
+func Construct(email, password string, role Role) (*User, error) {
+	email = NormalizeEmail(email)
+	if err := ValidateEmail(email); err != nil {
+		return nil, err
+	}
+
+	if err := ValidatePassword(password); err != nil {
+		return nil, err
+	}
+
+	if !role.Valid() {
+		return nil, errors.New("role is invalid")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return &User{
+		ID:           NewUserID(),
+		Email:        email,
+		PasswordHash: string(hash),
+		Role:         role,
+		CreatedAt:    time.Now(),
+	}, nil
+}
+
+func Reconstruct(
+	id UserID,
+	email string,
+	passwordHash string,
+	role Role,
+	createdAt time.Time,
+) (*User, error) {
+	if !role.Valid() {
+		return nil, errors.New("role is invalid")
+	}
+
+	return &User{
+		ID:           id,
+		Email:        email,
+		PasswordHash: passwordHash,
+		Role:         role,
+		CreatedAt:    createdAt,
+	}, nil
+}
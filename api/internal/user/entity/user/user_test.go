@@ -0,0 +1,64 @@
+package user
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestConstruct_HashesPassword(t *testing.T) {
+	u, err := Construct(" Editor@Example.com ", "password123", RoleEditor)
+	if err != nil {
+		t.Fatalf("Construct() error = %v", err)
+	}
+
+	if u.Email != "editor@example.com" {
+		t.Errorf("Construct() Email = %q, want %q", u.Email, "editor@example.com")
+	}
+	if u.PasswordHash == "password123" {
+		t.Error("Construct() should not store the plain text password")
+	}
+	if !u.VerifyPassword("password123") {
+		t.Error("VerifyPassword() = false for the correct password")
+	}
+	if u.VerifyPassword("password124") {
+		t.Error("VerifyPassword() = true for a wrong password")
+	}
+}
+
+func TestConstruct_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		role     Role
+	}{
+		{name: "invalid email", email: "not an email", password: "password123", role: RoleGeneral},
+		{name: "short password", email: "a@example.com", password: "short", role: RoleGeneral},
+		{name: "unknown role", email: "a@example.com", password: "password123", role: Role("owner")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Construct(tt.email, tt.password, tt.role); err == nil {
+				t.Error("Construct() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestVerifyNoPassword_CostsLikeVerifyPassword(t *testing.T) {
+	// 実在するユーザーと同じ時間をかけるため、コストを揃える
+	if cost, err := bcrypt.Cost([]byte(dummyPasswordHash)); err != nil || cost != bcrypt.DefaultCost {
+		t.Errorf("bcrypt.Cost(dummyPasswordHash) = %d, %v, want %d", cost, err, bcrypt.DefaultCost)
+	}
+	if VerifyNoPassword("no user has this password") {
+		t.Error("VerifyNoPassword() = true, want false")
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	if got := NormalizeEmail(" Foo@X.com "); got != "foo@x.com" {
+		t.Errorf("NormalizeEmail() = %q, want %q", got, "foo@x.com")
+	}
+}
@@ -0,0 +1,65 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/user/entity/user"
+	"github.com/ss49919201/myblog/api/internal/user/repository"
+)
+
+type UserRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) repository.UserRepository {
+	return &UserRepositoryImpl{db: db}
+}
+
+func (r *UserRepositoryImpl) Create(ctx context.Context, u *user.User) error {
+	query := `INSERT INTO users (id, email, password_hash, role, created_at) VALUES (UUID_TO_BIN(?), ?, ?, ?, ?)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		u.ID.String(),
+		u.Email,
+		u.PasswordHash,
+		u.Role,
+		u.CreatedAt,
+	)
+	return err
+}
+
+func (r *UserRepositoryImpl) FindByID(ctx context.Context, id user.UserID) (*user.User, error) {
+	query := `SELECT BIN_TO_UUID(id), email, password_hash, role, created_at FROM users WHERE id = UUID_TO_BIN(?)`
+
+	return scanUser(r.db.QueryRowContext(ctx, query, id.String()))
+}
+
+func (r *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*user.User, error) {
+	query := `SELECT BIN_TO_UUID(id), email, password_hash, role, created_at FROM users WHERE email = ?`
+
+	return scanUser(r.db.QueryRowContext(ctx, query, strings.ToLower(strings.TrimSpace(email))))
+}
+
+func scanUser(row *sql.Row) (*user.User, error) {
+	var idStr, email, passwordHash, role string
+	var createdAt time.Time
+
+	err := row.Scan(&idStr, &email, &passwordHash, &role, &createdAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &user.ErrUserNotFound{}
+		}
+		return nil, err
+	}
+
+	userID, err := user.ParseUserID(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Reconstruct(userID, email, passwordHash, user.Role(role), createdAt)
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/user/entity/user"
+)
+
+type UserRepository interface {
+	Create(ctx context.Context, u *user.User) error
+	FindByID(ctx context.Context, id user.UserID) (*user.User, error)
+	FindByEmail(ctx context.Context, email string) (*user.User, error)
+}
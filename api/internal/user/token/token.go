@@ -0,0 +1,151 @@
+// Package token issues and verifies the signed bearer tokens used for API
+// authentication. Tokens are compact JWTs signed with HMAC-SHA256 (HS256).
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/user/entity/user"
+)
+
+const DefaultTTL = 24 * time.Hour
+
+// minSecretLength keeps HS256 keys at least as long as the hash output.
+const minSecretLength = 32
+
+var (
+	ErrMalformed        = errors.New("token is malformed")
+	ErrInvalidSignature = errors.New("token signature is invalid")
+	ErrExpired          = errors.New("token is expired")
+)
+
+type Claims struct {
+	Subject   string    `json:"sub"`
+	Role      user.Role `json:"role"`
+	IssuedAt  int64     `json:"iat"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+type Token struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type Manager struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewManager(secret string, ttl time.Duration) (*Manager, error) {
+	if len(secret) < minSecretLength {
+		return nil, errors.New("token secret must be at least 32 bytes")
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Manager{
+		secret: []byte(secret),
+		ttl:    ttl,
+		now:    time.Now,
+	}, nil
+}
+
+// Issue creates a signed token for the given user.
+func (m *Manager) Issue(u *user.User) (*Token, error) {
+	now := m.now()
+	expiresAt := now.Add(m.ttl)
+
+	claims := Claims{
+		Subject:   u.ID.String(),
+		Role:      u.Role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return nil, err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	signingInput := encode(headerJSON) + "." + encode(claimsJSON)
+
+	return &Token{
+		Value:     signingInput + "." + encode(m.sign(signingInput)),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+	}, nil
+}
+
+// Verify checks the signature and expiry of raw and returns its claims.
+func (m *Manager) Verify(raw string) (*Claims, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	headerJSON, err := decode(parts[0])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	var h header
+	if err := json.Unmarshal(headerJSON, &h); err != nil {
+		return nil, ErrMalformed
+	}
+	// alg を固定することで "none" などへのダウングレードを防ぐ
+	if h.Alg != "HS256" {
+		return nil, ErrInvalidSignature
+	}
+
+	signature, err := decode(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if !hmac.Equal(signature, m.sign(parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidSignature
+	}
+
+	claimsJSON, err := decode(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	var claims Claims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, ErrMalformed
+	}
+
+	if !m.now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, ErrExpired
+	}
+
+	return &claims, nil
+}
+
+func (m *Manager) sign(signingInput string) []byte {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
@@ -0,0 +1,124 @@
+package token
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/user/entity/user"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestManager(t *testing.T, now time.Time) *Manager {
+	t.Helper()
+
+	m, err := NewManager(testSecret, time.Hour)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	m.now = func() time.Time { return now }
+	return m
+}
+
+func TestNewManager_ShortSecret_ReturnsError(t *testing.T) {
+	if _, err := NewManager("short", time.Hour); err == nil {
+		t.Error("NewManager() with short secret should return error")
+	}
+}
+
+func TestManager_IssueAndVerify(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	m := newTestManager(t, now)
+	u := &user.User{ID: user.NewUserID(), Role: user.RoleEditor}
+
+	issued, err := m.Issue(u)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if !issued.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("Issue() ExpiresAt = %v, want %v", issued.ExpiresAt, now.Add(time.Hour))
+	}
+
+	claims, err := m.Verify(issued.Value)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if claims.Subject != u.ID.String() {
+		t.Errorf("Verify() Subject = %v, want %v", claims.Subject, u.ID.String())
+	}
+	if claims.Role != user.RoleEditor {
+		t.Errorf("Verify() Role = %v, want %v", claims.Role, user.RoleEditor)
+	}
+}
+
+func TestManager_Verify(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	m := newTestManager(t, now)
+	issued, err := m.Issue(&user.User{ID: user.NewUserID(), Role: user.RoleGeneral})
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	parts := strings.Split(issued.Value, ".")
+
+	other, err := NewManager("fedcba9876543210fedcba9876543210", time.Hour)
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	other.now = m.now
+	forged, err := other.Issue(&user.User{ID: user.NewUserID(), Role: user.RoleAdmin})
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		raw     string
+		now     time.Time
+		wantErr error
+	}{
+		{
+			name:    "malformed token",
+			raw:     "not-a-token",
+			now:     now,
+			wantErr: ErrMalformed,
+		},
+		{
+			name:    "signed with another secret",
+			raw:     forged.Value,
+			now:     now,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "tampered claims",
+			raw:     parts[0] + "." + strings.Split(forged.Value, ".")[1] + "." + parts[2],
+			now:     now,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "alg none",
+			raw:     encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + ".",
+			now:     now,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "expired",
+			raw:     issued.Value,
+			now:     now.Add(time.Hour),
+			wantErr: ErrExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifyAt := tt.now
+			m.now = func() time.Time { return verifyAt }
+
+			_, err := m.Verify(tt.raw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/user/entity/user"
+	"github.com/ss49919201/myblog/api/internal/user/repository"
+	"github.com/ss49919201/myblog/api/internal/user/token"
+)
+
+type LoginInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type LoginOutput struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type LoginUsecase struct {
+	repo   repository.UserRepository
+	tokens *token.Manager
+}
+
+func NewLoginUsecase(repo repository.UserRepository, tokens *token.Manager) *LoginUsecase {
+	return &LoginUsecase{repo: repo, tokens: tokens}
+}
+
+func (u *LoginUsecase) Execute(ctx context.Context, input LoginInput) (*LoginOutput, error) {
+	found, err := u.repo.FindByEmail(ctx, user.NormalizeEmail(input.Email))
+	if err != nil {
+		// ユーザーの存在有無を外部に漏らさない。応答時間からも分からないよう、
+		// 存在しない場合もパスワードの照合と同じだけ時間をかける
+		if _, ok := user.AsErrUserNotFound(err); ok {
+			user.VerifyNoPassword(input.Password)
+			return nil, &user.ErrInvalidCredentials{}
+		}
+		return nil, err
+	}
+
+	if !found.VerifyPassword(input.Password) {
+		return nil, &user.ErrInvalidCredentials{}
+	}
+
+	issued, err := u.tokens.Issue(found)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginOutput{Token: issued.Value, ExpiresAt: issued.ExpiresAt}, nil
+}
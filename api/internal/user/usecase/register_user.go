@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ss49919201/myblog/api/internal/user/entity/user"
+	"github.com/ss49919201/myblog/api/internal/user/repository"
+)
+
+type RegisterUserInput struct {
+	Email    string    `json:"email"`
+	Password string    `json:"password"`
+	Role     user.Role `json:"role"`
+}
+
+type RegisterUserOutput struct {
+	User *user.User `json:"user"`
+}
+
+type RegisterUserUsecase struct {
+	repo repository.UserRepository
+}
+
+func NewRegisterUserUsecase(repo repository.UserRepository) *RegisterUserUsecase {
+	return &RegisterUserUsecase{repo: repo}
+}
+
+func (u *RegisterUserUsecase) Execute(ctx context.Context, input RegisterUserInput) (*RegisterUserOutput, error) {
+	newUser, err := user.Construct(input.Email, input.Password, input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Create(ctx, newUser); err != nil {
+		return nil, err
+	}
+
+	return &RegisterUserOutput{User: newUser}, nil
+}
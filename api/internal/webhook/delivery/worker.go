@@ -0,0 +1,200 @@
+// Package delivery sends pending webhook deliveries to their endpoints.
+//
+// Every request is a POST of the event's JSON envelope signed with the
+// endpoint secret:
+//
+//	X-Webhook-Id:        delivery ID, stable across retries
+//	X-Webhook-Event:     event type, e.g. post.published
+//	X-Webhook-Timestamp: Unix seconds at which the request was signed
+//	X-Webhook-Signature: sha256=<hex HMAC-SHA256(secret, timestamp + "." + body)>
+//
+// A 2xx response marks the delivery as succeeded; anything else is retried
+// with backoff until MaxAttempts is reached.
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock"
+	"github.com/ss49919201/myblog/api/internal/post/outbox"
+	postrepository "github.com/ss49919201/myblog/api/internal/post/repository"
+	"github.com/ss49919201/myblog/api/internal/webhook/repository"
+)
+
+const (
+	HeaderID        = "X-Webhook-Id"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+type Config struct {
+	// Interval is how long the worker sleeps when nothing is due.
+	Interval  time.Duration
+	BatchSize int
+	// MaxAttempts is the number of failed attempts after which a delivery is
+	// marked dead.
+	MaxAttempts int
+	// Backoff returns the delay before the next attempt after attempts failures.
+	Backoff func(attempts int) time.Duration
+	// Lease is how long claimed deliveries stay hidden from other workers.
+	// It must cover sending a whole batch, since deliveries are sent one by
+	// one after they are claimed.
+	Lease time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Interval:    5 * time.Second,
+		BatchSize:   10,
+		MaxAttempts: 8,
+		Backoff:     outbox.ExponentialBackoff(10*time.Second, time.Hour),
+		Lease:       5 * time.Minute,
+	}
+}
+
+// Sign returns the X-Webhook-Signature value for body signed at timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Worker sends due deliveries. Several replicas can run a Worker at once;
+// deliveries are claimed with SELECT ... FOR UPDATE SKIP LOCKED and a lease,
+// so that no transaction is held open while the requests are sent.
+type Worker struct {
+	repo   repository.DeliveryRepository
+	tx     postrepository.Transactor
+	client *http.Client
+	clock  clock.Clock
+	config Config
+}
+
+func NewWorker(repo repository.DeliveryRepository, tx postrepository.Transactor, client *http.Client, clk clock.Clock, config Config) *Worker {
+	return &Worker{repo: repo, tx: tx, client: client, clock: clk, config: config}
+}
+
+// Run sends deliveries until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	slog.Info("webhook worker started")
+
+	for {
+		processed, err := w.Tick(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Error("failed to send webhooks", slog.String("err", err.Error()))
+		}
+
+		if err == nil && processed == w.config.BatchSize {
+			if ctx.Err() != nil {
+				slog.Info("webhook worker stopped")
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("webhook worker stopped")
+			return
+		case <-w.clock.After(w.config.Interval):
+		}
+	}
+}
+
+// Tick sends one batch of due deliveries and returns how many were claimed.
+func (w *Worker) Tick(ctx context.Context) (int, error) {
+	var due []repository.DueDelivery
+
+	// 短いトランザクションでリースを取り、送信中は行ロックも接続も持たない
+	err := w.tx.RunInTx(ctx, func(ctx context.Context) error {
+		claimed, err := w.repo.ClaimDue(ctx, w.clock.Now(), w.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		now := w.clock.Now()
+		for _, d := range claimed {
+			d.Delivery.Claim(now, w.config.Lease)
+			if err := w.repo.Update(ctx, d.Delivery); err != nil {
+				return err
+			}
+		}
+
+		due = claimed
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// 結果は 1 件ずつ記録し、記録に失敗した配信だけがリース切れ後に再送される
+	var errs []error
+	for _, d := range due {
+		statusCode, err := w.send(ctx, d)
+		now := w.clock.Now()
+		if err != nil {
+			slog.Warn("webhook delivery failed",
+				slog.String("delivery_id", d.Delivery.ID.String()),
+				slog.String("endpoint_id", d.Endpoint.ID.String()),
+				slog.Int("attempt", d.Delivery.Attempts),
+				slog.String("err", err.Error()),
+			)
+			d.Delivery.RecordFailure(statusCode, err.Error(), now, w.config.MaxAttempts, w.config.Backoff)
+		} else {
+			d.Delivery.RecordSuccess(*statusCode, now)
+		}
+
+		if err := w.tx.RunInTx(ctx, func(ctx context.Context) error {
+			return w.repo.Update(ctx, d.Delivery)
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("record delivery %s: %w", d.Delivery.ID, err))
+		}
+	}
+
+	return len(due), errors.Join(errs...)
+}
+
+// send posts the delivery and returns the response status, which is nil when
+// no response was received.
+func (w *Worker) send(ctx context.Context, d repository.DueDelivery) (*int, error) {
+	timestamp := w.clock.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Endpoint.URL, bytes.NewReader(d.Delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, d.Delivery.ID.String())
+	req.Header.Set(HeaderEvent, d.Delivery.EventType)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(d.Endpoint.Secret, timestamp, d.Delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// 接続を再利用できるようにレスポンスボディを読み捨てる
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	statusCode := resp.StatusCode
+	if statusCode < 200 || statusCode >= 300 {
+		return &statusCode, fmt.Errorf("endpoint responded with status %d", statusCode)
+	}
+
+	return &statusCode, nil
+}
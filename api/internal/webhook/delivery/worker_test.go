@@ -0,0 +1,248 @@
+package delivery
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/clock/clocktest"
+	"github.com/ss49919201/myblog/api/internal/post/entity/event"
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/webhook/entity/webhook"
+	"github.com/ss49919201/myblog/api/internal/webhook/repository"
+)
+
+const testSecret = "0123456789abcdef-secret"
+
+// memoryRepo is an in-memory DeliveryRepository and Transactor.
+type memoryRepo struct {
+	endpoint   *webhook.Endpoint
+	deliveries []*webhook.Delivery
+}
+
+func (r *memoryRepo) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (r *memoryRepo) CreateIfAbsent(ctx context.Context, d *webhook.Delivery) error {
+	r.deliveries = append(r.deliveries, d)
+	return nil
+}
+
+func (r *memoryRepo) ClaimDue(ctx context.Context, now time.Time, limit int) ([]repository.DueDelivery, error) {
+	var due []repository.DueDelivery
+	for _, d := range r.deliveries {
+		leased := d.LockedUntil != nil && d.LockedUntil.After(now)
+		if d.Status == webhook.DeliveryStatusPending && r.endpoint.Active && !d.NextAttemptAt.After(now) && !leased && len(due) < limit {
+			due = append(due, repository.DueDelivery{Delivery: d, Endpoint: r.endpoint})
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryRepo) Update(ctx context.Context, d *webhook.Delivery) error {
+	return nil
+}
+
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, statusCodes ...int) (*httptest.Server, func() []receivedRequest) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		received []receivedRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, receivedRequest{header: r.Header.Clone(), body: body})
+		code := statusCodes[min(len(received), len(statusCodes))-1]
+		mu.Unlock()
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, func() []receivedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]receivedRequest(nil), received...)
+	}
+}
+
+func newTestRepo(t *testing.T, url string, now time.Time) *memoryRepo {
+	t.Helper()
+
+	endpoint, err := webhook.ConstructEndpoint(url, testSecret, []string{post.PostEventTypePublishPost.String()})
+	if err != nil {
+		t.Fatalf("ConstructEndpoint: %v", err)
+	}
+	d, err := webhook.NewDelivery(endpoint, post.PostEvent{
+		ID:          event.GenerateID(),
+		AggregateID: post.NewPostID(),
+		Type:        post.PostEventTypePublishPost,
+		OccurredAt:  now,
+		Payload:     &post.PostStatusChangedPayload{From: post.StatusDraft, To: post.StatusPublished},
+	}, now)
+	if err != nil {
+		t.Fatalf("NewDelivery: %v", err)
+	}
+
+	return &memoryRepo{endpoint: endpoint, deliveries: []*webhook.Delivery{d}}
+}
+
+func TestWorker_SignsRequests(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv, received := newReceiver(t, http.StatusNoContent)
+	repo := newTestRepo(t, srv.URL, now)
+	w := NewWorker(repo, repo, srv.Client(), clocktest.NewFake(now), DefaultConfig())
+
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+
+	reqs := received()
+	if len(reqs) != 1 {
+		t.Fatalf("received %d requests, want 1", len(reqs))
+	}
+	req := reqs[0]
+	d := repo.deliveries[0]
+
+	if got := req.header.Get(HeaderID); got != d.ID.String() {
+		t.Errorf("%s = %q, want %q", HeaderID, got, d.ID.String())
+	}
+	if got := req.header.Get(HeaderEvent); got != "post.published" {
+		t.Errorf("%s = %q, want post.published", HeaderEvent, got)
+	}
+	if got := req.header.Get(HeaderTimestamp); got != strconv.FormatInt(now.Unix(), 10) {
+		t.Errorf("%s = %q, want %d", HeaderTimestamp, got, now.Unix())
+	}
+	if got, want := req.header.Get(HeaderSignature), Sign(testSecret, now.Unix(), req.body); got != want {
+		t.Errorf("%s = %q, want %q", HeaderSignature, got, want)
+	}
+	if string(req.body) != string(d.Payload) {
+		t.Errorf("body = %s, want %s", req.body, d.Payload)
+	}
+
+	if d.Status != webhook.DeliveryStatusSucceeded || d.Attempts != 1 || d.DeliveredAt == nil {
+		t.Errorf("delivery = %+v, want succeeded after 1 attempt", d)
+	}
+}
+
+func TestWorker_RetriesUntilDead(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv, received := newReceiver(t, http.StatusInternalServerError)
+	repo := newTestRepo(t, srv.URL, now)
+	clk := clocktest.NewFake(now)
+	config := DefaultConfig()
+	config.MaxAttempts = 3
+	config.Backoff = func(int) time.Duration { return time.Minute }
+	w := NewWorker(repo, repo, srv.Client(), clk, config)
+	d := repo.deliveries[0]
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		if _, err := w.Tick(context.Background()); err != nil {
+			t.Fatalf("Tick: %v", err)
+		}
+		if d.Attempts != attempt {
+			t.Fatalf("attempts = %d, want %d", d.Attempts, attempt)
+		}
+
+		// バックオフ中は再送しない
+		if processed, _ := w.Tick(context.Background()); processed != 0 {
+			t.Fatalf("processed %d deliveries during backoff, want 0", processed)
+		}
+		clk.Advance(time.Minute)
+	}
+
+	if d.Status != webhook.DeliveryStatusDead {
+		t.Errorf("status = %s, want dead", d.Status)
+	}
+	if d.LastStatusCode == nil || *d.LastStatusCode != http.StatusInternalServerError {
+		t.Errorf("lastStatusCode = %v, want 500", d.LastStatusCode)
+	}
+	if n := len(received()); n != 3 {
+		t.Errorf("received %d requests, want 3", n)
+	}
+}
+
+func TestWorker_RecoversAfterFailure(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv, _ := newReceiver(t, http.StatusBadGateway, http.StatusOK)
+	repo := newTestRepo(t, srv.URL, now)
+	clk := clocktest.NewFake(now)
+	w := NewWorker(repo, repo, srv.Client(), clk, DefaultConfig())
+	d := repo.deliveries[0]
+
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	if d.Status != webhook.DeliveryStatusPending || d.LastError == nil {
+		t.Fatalf("delivery = %+v, want pending with an error", d)
+	}
+
+	clk.Advance(time.Hour)
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	if d.Status != webhook.DeliveryStatusSucceeded || d.Attempts != 2 || d.LastError != nil {
+		t.Errorf("delivery = %+v, want succeeded after 2 attempts", d)
+	}
+}
+
+func TestWorker_SkipsLeasedDeliveries(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv, received := newReceiver(t, http.StatusOK)
+	repo := newTestRepo(t, srv.URL, now)
+	clk := clocktest.NewFake(now)
+	config := DefaultConfig()
+	w := NewWorker(repo, repo, srv.Client(), clk, config)
+	d := repo.deliveries[0]
+
+	// 別のワーカーが送信中のまま止まった配信
+	d.Claim(now, config.Lease)
+
+	if processed, err := w.Tick(context.Background()); err != nil || processed != 0 {
+		t.Fatalf("Tick() = %d, %v, want 0, nil while leased", processed, err)
+	}
+
+	clk.Advance(config.Lease)
+	if _, err := w.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick: %v", err)
+	}
+	if n := len(received()); n != 1 {
+		t.Errorf("received %d requests, want 1", n)
+	}
+	// 中断した試行も回数に数える
+	if d.Status != webhook.DeliveryStatusSucceeded || d.Attempts != 2 || d.LockedUntil != nil {
+		t.Errorf("delivery = %+v, want succeeded after 2 attempts without a lease", d)
+	}
+}
+
+func TestWorker_SkipsDeactivatedEndpoints(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv, received := newReceiver(t, http.StatusOK)
+	repo := newTestRepo(t, srv.URL, now)
+	w := NewWorker(repo, repo, srv.Client(), clocktest.NewFake(now), DefaultConfig())
+
+	// 登録後、送信前に無効化されたエンドポイント
+	repo.endpoint.Deactivate()
+
+	if processed, err := w.Tick(context.Background()); err != nil || processed != 0 {
+		t.Fatalf("Tick() = %d, %v, want 0, nil for a deactivated endpoint", processed, err)
+	}
+	if n := len(received()); n != 0 {
+		t.Errorf("received %d requests, want 0", n)
+	}
+	if d := repo.deliveries[0]; d.Status != webhook.DeliveryStatusPending || d.Attempts != 0 {
+		t.Errorf("delivery = %+v, want untouched", d)
+	}
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/id"
+)
+
+type DeliveryID id.UUID
+
+func (d DeliveryID) String() string {
+	return id.UUID(d).String()
+}
+
+func (d DeliveryID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+func ParseDeliveryID(s string) (DeliveryID, error) {
+	parsed, err := id.ParseUUID(s)
+	if err != nil {
+		return DeliveryID{}, err
+	}
+
+	return DeliveryID(parsed), nil
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	// DeliveryStatusDead marks a delivery that failed too many times.
+	DeliveryStatusDead DeliveryStatus = "dead"
+)
+
+// Delivery is one event sent (or to be sent) to one endpoint.
+type Delivery struct {
+	ID         DeliveryID     `json:"id"`
+	EndpointID EndpointID     `json:"endpointId"`
+	EventID    string         `json:"eventId"`
+	EventType  string         `json:"eventType"`
+	PostID     string         `json:"postId"`
+	Payload    []byte         `json:"-"`
+	Status     DeliveryStatus `json:"status"`
+	Attempts   int            `json:"attempts"`
+	// LastStatusCode is the HTTP status of the last attempt, or nil if the
+	// request did not get a response.
+	LastStatusCode *int       `json:"lastStatusCode"`
+	LastError      *string    `json:"lastError"`
+	NextAttemptAt  time.Time  `json:"nextAttemptAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+	// LockedUntil is the end of the lease held by the worker sending the
+	// delivery. No other worker claims it before then.
+	LockedUntil *time.Time `json:"-"`
+}
+
+// NewDelivery prepares a pending delivery of e to endpoint. The payload is the
+// event's JSON envelope and is sent verbatim on every attempt.
+func NewDelivery(endpoint *Endpoint, e post.PostEvent, now time.Time) (*Delivery, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Delivery{
+		ID:            DeliveryID(id.GenerateUUID()),
+		EndpointID:    endpoint.ID,
+		EventID:       e.ID.String(),
+		EventType:     e.Type.String(),
+		PostID:        e.AggregateID.String(),
+		Payload:       payload,
+		Status:        DeliveryStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// Claim takes a lease on the delivery for the attempt about to be made. The
+// attempt is counted here rather than when it is recorded, so that an
+// attempt cut short by a crash still counts once the lease expires.
+func (d *Delivery) Claim(now time.Time, lease time.Duration) {
+	d.Attempts++
+	lockedUntil := now.Add(lease)
+	d.LockedUntil = &lockedUntil
+}
+
+// RecordSuccess marks the delivery as accepted by the receiver.
+func (d *Delivery) RecordSuccess(statusCode int, now time.Time) {
+	d.LockedUntil = nil
+	d.Status = DeliveryStatusSucceeded
+	d.LastStatusCode = &statusCode
+	d.LastError = nil
+	d.DeliveredAt = &now
+}
+
+// RecordFailure records a failed attempt. The delivery is retried after
+// backoff(attempts) until maxAttempts is reached, then it is marked dead.
+func (d *Delivery) RecordFailure(statusCode *int, message string, now time.Time, maxAttempts int, backoff func(attempts int) time.Duration) {
+	d.LockedUntil = nil
+	d.LastStatusCode = statusCode
+	d.LastError = &message
+
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryStatusDead
+		return
+	}
+
+	d.NextAttemptAt = now.Add(backoff(d.Attempts))
+}
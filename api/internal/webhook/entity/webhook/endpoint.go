@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	"github.com/ss49919201/myblog/api/internal/post/id"
+)
+
+const minSecretLength = 16
+
+type EndpointID id.UUID
+
+func (e EndpointID) String() string {
+	return id.UUID(e).String()
+}
+
+func (e EndpointID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + e.String() + `"`), nil
+}
+
+func ParseEndpointID(s string) (EndpointID, error) {
+	parsed, err := id.ParseUUID(s)
+	if err != nil {
+		return EndpointID{}, err
+	}
+
+	return EndpointID(parsed), nil
+}
+
+// Endpoint is a URL registered by an admin to receive post events.
+type Endpoint struct {
+	ID  EndpointID `json:"id"`
+	URL string     `json:"url"`
+	// Secret signs every delivery with HMAC-SHA256. It is never returned by the API.
+	Secret     string    `json:"-"`
+	EventTypes []string  `json:"eventTypes"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ConstructEndpoint validates and creates a new active endpoint.
+// eventTypes are stable event names such as "post.published".
+func ConstructEndpoint(rawURL, secret string, eventTypes []string) (*Endpoint, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, post.NewValidationError("url", post.ValidationCodeInvalidValue, "url must be an absolute http or https URL")
+	}
+
+	if len(secret) < minSecretLength {
+		return nil, post.NewValidationError("secret", post.ValidationCodeLength, "secret must be at least 16 characters")
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, post.NewValidationError("eventTypes", post.ValidationCodeRequired, "at least one event type is required")
+	}
+	for _, eventType := range eventTypes {
+		if _, err := post.ParsePostEventType(eventType); err != nil {
+			return nil, post.NewValidationError("eventTypes", post.ValidationCodeInvalidValue, err.Error())
+		}
+	}
+
+	types := slices.Clone(eventTypes)
+	slices.Sort(types)
+
+	return &Endpoint{
+		ID:         EndpointID(id.GenerateUUID()),
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: slices.Compact(types),
+		Active:     true,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func ReconstructEndpoint(id EndpointID, rawURL, secret string, eventTypes []string, active bool, createdAt time.Time) *Endpoint {
+	return &Endpoint{
+		ID:         id,
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     active,
+		CreatedAt:  createdAt,
+	}
+}
+
+// Deactivate stops deliveries to the endpoint. Past deliveries stay
+// queryable in the delivery log.
+func (e *Endpoint) Deactivate() {
+	e.Active = false
+}
+
+// Subscribes reports whether the endpoint wants events of type t.
+func (e *Endpoint) Subscribes(t post.PostEventType) bool {
+	return e.Active && slices.Contains(e.EventTypes, t.String())
+}
@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+)
+
+func TestConstructEndpoint(t *testing.T) {
+	const secret = "0123456789abcdef"
+
+	tests := []struct {
+		name       string
+		url        string
+		secret     string
+		eventTypes []string
+		wantField  string
+	}{
+		{name: "valid", url: "https://example.com/hook", secret: secret, eventTypes: []string{"post.published"}},
+		{name: "relative url", url: "/hook", secret: secret, eventTypes: []string{"post.published"}, wantField: "url"},
+		{name: "unsupported scheme", url: "ftp://example.com/hook", secret: secret, eventTypes: []string{"post.published"}, wantField: "url"},
+		{name: "short secret", url: "https://example.com/hook", secret: "short", eventTypes: []string{"post.published"}, wantField: "secret"},
+		{name: "no event types", url: "https://example.com/hook", secret: secret, wantField: "eventTypes"},
+		{name: "unknown event type", url: "https://example.com/hook", secret: secret, eventTypes: []string{"post.liked"}, wantField: "eventTypes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConstructEndpoint(tt.url, tt.secret, tt.eventTypes)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			validationErr, ok := post.AsErrValidation(err)
+			if !ok {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestEndpoint_Subscribes(t *testing.T) {
+	e, err := ConstructEndpoint("https://example.com/hook", "0123456789abcdef", []string{"post.published", "post.deleted", "post.published"})
+	if err != nil {
+		t.Fatalf("ConstructEndpoint: %v", err)
+	}
+
+	if want := []string{"post.deleted", "post.published"}; !slices.Equal(e.EventTypes, want) {
+		t.Errorf("EventTypes = %v, want %v", e.EventTypes, want)
+	}
+	if !e.Subscribes(post.PostEventTypePublishPost) {
+		t.Error("expected endpoint to subscribe to post.published")
+	}
+	if e.Subscribes(post.PostEventTypeCreatePost) {
+		t.Error("expected endpoint not to subscribe to post.created")
+	}
+
+	e.Deactivate()
+	if e.Subscribes(post.PostEventTypePublishPost) {
+		t.Error("expected deactivated endpoint not to subscribe")
+	}
+}
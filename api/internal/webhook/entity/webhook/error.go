@@ -0,0 +1,23 @@
+package webhook
+
+import "errors"
+
+type ErrEndpointNotFound struct {
+}
+
+func (e *ErrEndpointNotFound) Error() string {
+	return "webhook endpoint not found"
+}
+
+func AsErrEndpointNotFound(err error) (*ErrEndpointNotFound, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var result *ErrEndpointNotFound
+	if errors.As(err, &result) {
+		return result, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,168 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/webhook/entity/webhook"
+)
+
+const selectEndpointColumns = `BIN_TO_UUID(id), url, secret, event_types, active, created_at`
+
+func selectEndpointColumnsOf(alias string) string {
+	return `BIN_TO_UUID(` + alias + `.id), ` + alias + `.url, ` + alias + `.secret, ` + alias + `.event_types, ` + alias + `.active, ` + alias + `.created_at`
+}
+
+const selectDeliveryColumns = `BIN_TO_UUID(d.id), BIN_TO_UUID(d.endpoint_id), BIN_TO_UUID(d.event_id), d.event_type, BIN_TO_UUID(d.post_id), d.payload, d.status, d.attempts, d.last_status_code, d.last_error, d.next_attempt_at, d.created_at, d.delivered_at, d.locked_until`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// FindWebhookEndpoints returns every registered endpoint, newest first.
+func FindWebhookEndpoints(ctx context.Context, db *sql.DB) ([]*webhook.Endpoint, error) {
+	query := `SELECT ` + selectEndpointColumns + ` FROM webhook_endpoints ORDER BY created_at DESC`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var endpoints []*webhook.Endpoint
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, e)
+	}
+
+	return endpoints, rows.Err()
+}
+
+// FindWebhookDeliveries returns the latest deliveries to an endpoint, newest first.
+func FindWebhookDeliveries(ctx context.Context, db *sql.DB, endpointID webhook.EndpointID, limit int) ([]*webhook.Delivery, error) {
+	query := `SELECT ` + selectDeliveryColumns + ` FROM webhook_deliveries d WHERE d.endpoint_id = UUID_TO_BIN(?) ORDER BY d.created_at DESC LIMIT ?`
+
+	rows, err := db.QueryContext(ctx, query, endpointID.String(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*webhook.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func scanEndpoint(row rowScanner) (*webhook.Endpoint, error) {
+	var idStr, url, secret string
+	var eventTypesJSON []byte
+	var active bool
+	var createdAt time.Time
+
+	if err := row.Scan(&idStr, &url, &secret, &eventTypesJSON, &active, &createdAt); err != nil {
+		return nil, err
+	}
+
+	return endpointFromColumns(idStr, url, secret, eventTypesJSON, active, createdAt)
+}
+
+func endpointFromColumns(idStr, url, secret string, eventTypesJSON []byte, active bool, createdAt time.Time) (*webhook.Endpoint, error) {
+	endpointID, err := webhook.ParseEndpointID(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventTypes []string
+	if err := json.Unmarshal(eventTypesJSON, &eventTypes); err != nil {
+		return nil, err
+	}
+
+	return webhook.ReconstructEndpoint(endpointID, url, secret, eventTypes, active, createdAt), nil
+}
+
+type deliveryColumns struct {
+	id, endpointID, eventID, eventType, postID, status string
+	payload                                            []byte
+	attempts                                           int
+	lastStatusCode                                     *int
+	lastError                                          *string
+	nextAttemptAt, createdAt                           time.Time
+	deliveredAt, lockedUntil                           *time.Time
+}
+
+func (c *deliveryColumns) dest() []any {
+	return []any{&c.id, &c.endpointID, &c.eventID, &c.eventType, &c.postID, &c.payload, &c.status, &c.attempts, &c.lastStatusCode, &c.lastError, &c.nextAttemptAt, &c.createdAt, &c.deliveredAt, &c.lockedUntil}
+}
+
+func (c *deliveryColumns) delivery() (*webhook.Delivery, error) {
+	deliveryID, err := webhook.ParseDeliveryID(c.id)
+	if err != nil {
+		return nil, err
+	}
+	endpointID, err := webhook.ParseEndpointID(c.endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook.Delivery{
+		ID:             deliveryID,
+		EndpointID:     endpointID,
+		EventID:        c.eventID,
+		EventType:      c.eventType,
+		PostID:         c.postID,
+		Payload:        c.payload,
+		Status:         webhook.DeliveryStatus(c.status),
+		Attempts:       c.attempts,
+		LastStatusCode: c.lastStatusCode,
+		LastError:      c.lastError,
+		NextAttemptAt:  c.nextAttemptAt,
+		CreatedAt:      c.createdAt,
+		DeliveredAt:    c.deliveredAt,
+		LockedUntil:    c.lockedUntil,
+	}, nil
+}
+
+func scanDelivery(row rowScanner) (*webhook.Delivery, error) {
+	var c deliveryColumns
+	if err := row.Scan(c.dest()...); err != nil {
+		return nil, err
+	}
+
+	return c.delivery()
+}
+
+func scanDueDelivery(row rowScanner) (*webhook.Delivery, *webhook.Endpoint, error) {
+	var c deliveryColumns
+	var idStr, url, secret string
+	var eventTypesJSON []byte
+	var active bool
+	var createdAt time.Time
+
+	dest := append(c.dest(), &idStr, &url, &secret, &eventTypesJSON, &active, &createdAt)
+	if err := row.Scan(dest...); err != nil {
+		return nil, nil, err
+	}
+
+	d, err := c.delivery()
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err := endpointFromColumns(idStr, url, secret, eventTypesJSON, active, createdAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d, e, nil
+}
@@ -0,0 +1,135 @@
+package rdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	postrdb "github.com/ss49919201/myblog/api/internal/post/rdb"
+	"github.com/ss49919201/myblog/api/internal/webhook/entity/webhook"
+	"github.com/ss49919201/myblog/api/internal/webhook/repository"
+)
+
+type EndpointRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewEndpointRepository(db *sql.DB) repository.EndpointRepository {
+	return &EndpointRepositoryImpl{db: db}
+}
+
+func (r *EndpointRepositoryImpl) Create(ctx context.Context, e *webhook.Endpoint) error {
+	query := `INSERT INTO webhook_endpoints (id, url, secret, event_types, active, created_at) VALUES (UUID_TO_BIN(?), ?, ?, ?, ?, ?)`
+
+	eventTypes, err := json.Marshal(e.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	_, err = postrdb.Conn(ctx, r.db).ExecContext(ctx, query, e.ID.String(), e.URL, e.Secret, eventTypes, e.Active, e.CreatedAt)
+	return err
+}
+
+func (r *EndpointRepositoryImpl) FindByID(ctx context.Context, id webhook.EndpointID) (*webhook.Endpoint, error) {
+	query := `SELECT ` + selectEndpointColumns + ` FROM webhook_endpoints WHERE id = UUID_TO_BIN(?)`
+
+	e, err := scanEndpoint(postrdb.Conn(ctx, r.db).QueryRowContext(ctx, query, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &webhook.ErrEndpointNotFound{}
+		}
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (r *EndpointRepositoryImpl) Update(ctx context.Context, e *webhook.Endpoint) error {
+	query := `UPDATE webhook_endpoints SET url = ?, secret = ?, event_types = ?, active = ? WHERE id = UUID_TO_BIN(?)`
+
+	eventTypes, err := json.Marshal(e.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	_, err = postrdb.Conn(ctx, r.db).ExecContext(ctx, query, e.URL, e.Secret, eventTypes, e.Active, e.ID.String())
+	return err
+}
+
+func (r *EndpointRepositoryImpl) FindActiveByEventType(ctx context.Context, eventType string) ([]*webhook.Endpoint, error) {
+	query := `SELECT ` + selectEndpointColumns + ` FROM webhook_endpoints WHERE active = TRUE AND JSON_CONTAINS(event_types, JSON_QUOTE(?))`
+
+	rows, err := postrdb.Conn(ctx, r.db).QueryContext(ctx, query, eventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var endpoints []*webhook.Endpoint
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, e)
+	}
+
+	return endpoints, rows.Err()
+}
+
+type DeliveryRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewDeliveryRepository(db *sql.DB) repository.DeliveryRepository {
+	return &DeliveryRepositoryImpl{db: db}
+}
+
+func (r *DeliveryRepositoryImpl) CreateIfAbsent(ctx context.Context, d *webhook.Delivery) error {
+	// uk_endpoint_event で同じイベントの二重登録を防ぐ
+	query := `INSERT IGNORE INTO webhook_deliveries (id, endpoint_id, event_id, event_type, post_id, payload, status, attempts, next_attempt_at, created_at) VALUES (UUID_TO_BIN(?), UUID_TO_BIN(?), UUID_TO_BIN(?), ?, UUID_TO_BIN(?), ?, ?, ?, ?, ?)`
+
+	_, err := postrdb.Conn(ctx, r.db).ExecContext(ctx, query,
+		d.ID.String(),
+		d.EndpointID.String(),
+		d.EventID,
+		d.EventType,
+		d.PostID,
+		d.Payload,
+		d.Status,
+		d.Attempts,
+		d.NextAttemptAt,
+		d.CreatedAt,
+	)
+	return err
+}
+
+func (r *DeliveryRepositoryImpl) ClaimDue(ctx context.Context, now time.Time, limit int) ([]repository.DueDelivery, error) {
+	query := `SELECT ` + selectDeliveryColumns + `, ` + selectEndpointColumnsOf("e") + ` FROM webhook_deliveries d JOIN webhook_endpoints e ON e.id = d.endpoint_id WHERE d.status = 'pending' AND e.active = TRUE AND d.next_attempt_at <= ? AND (d.locked_until IS NULL OR d.locked_until <= ?) ORDER BY d.next_attempt_at LIMIT ? FOR UPDATE OF d SKIP LOCKED`
+
+	rows, err := postrdb.Conn(ctx, r.db).QueryContext(ctx, query, now, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []repository.DueDelivery
+	for rows.Next() {
+		d, e, err := scanDueDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		due = append(due, repository.DueDelivery{Delivery: d, Endpoint: e})
+	}
+
+	return due, rows.Err()
+}
+
+func (r *DeliveryRepositoryImpl) Update(ctx context.Context, d *webhook.Delivery) error {
+	query := `UPDATE webhook_deliveries SET status = ?, attempts = ?, last_status_code = ?, last_error = ?, next_attempt_at = ?, delivered_at = ?, locked_until = ? WHERE id = UUID_TO_BIN(?)`
+
+	_, err := postrdb.Conn(ctx, r.db).ExecContext(ctx, query, d.Status, d.Attempts, d.LastStatusCode, d.LastError, d.NextAttemptAt, d.DeliveredAt, d.LockedUntil, d.ID.String())
+	return err
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/webhook/entity/webhook"
+)
+
+type EndpointRepository interface {
+	Create(ctx context.Context, e *webhook.Endpoint) error
+	FindByID(ctx context.Context, id webhook.EndpointID) (*webhook.Endpoint, error)
+	Update(ctx context.Context, e *webhook.Endpoint) error
+	FindActiveByEventType(ctx context.Context, eventType string) ([]*webhook.Endpoint, error)
+}
+
+// DueDelivery is a delivery claimed for sending together with its endpoint.
+type DueDelivery struct {
+	Delivery *webhook.Delivery
+	Endpoint *webhook.Endpoint
+}
+
+type DeliveryRepository interface {
+	// CreateIfAbsent stores d unless a delivery of the same event to the same
+	// endpoint already exists, so redelivered events are not sent twice.
+	CreateIfAbsent(ctx context.Context, d *webhook.Delivery) error
+	// ClaimDue locks up to limit pending deliveries due at now, skipping rows
+	// locked by another worker, deliveries whose lease has not expired and
+	// deliveries to deactivated endpoints. It must be called inside a
+	// transaction.
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]DueDelivery, error)
+	Update(ctx context.Context, d *webhook.Delivery) error
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	postusecase "github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/webhook/entity/webhook"
+	"github.com/ss49919201/myblog/api/internal/webhook/repository"
+)
+
+type DeactivateEndpointInput struct {
+	ID string `json:"id"`
+}
+
+type DeactivateEndpointUsecase struct {
+	repo   repository.EndpointRepository
+	policy postusecase.Policy
+}
+
+func NewDeactivateEndpointUsecase(repo repository.EndpointRepository, policy postusecase.Policy) *DeactivateEndpointUsecase {
+	return &DeactivateEndpointUsecase{repo: repo, policy: policy}
+}
+
+func (u *DeactivateEndpointUsecase) Execute(ctx context.Context, input DeactivateEndpointInput, userCtx postusecase.UserContext) error {
+	if err := u.policy.Authorize(userCtx, postusecase.ActionManageWebhooks, nil); err != nil {
+		return err
+	}
+
+	endpointID, err := webhook.ParseEndpointID(input.ID)
+	if err != nil {
+		return &webhook.ErrEndpointNotFound{}
+	}
+
+	endpoint, err := u.repo.FindByID(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+
+	endpoint.Deactivate()
+
+	return u.repo.Update(ctx, endpoint)
+}
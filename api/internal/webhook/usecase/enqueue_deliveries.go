@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ss49919201/myblog/api/internal/post/entity/post"
+	postrepository "github.com/ss49919201/myblog/api/internal/post/repository"
+	"github.com/ss49919201/myblog/api/internal/webhook/entity/webhook"
+	"github.com/ss49919201/myblog/api/internal/webhook/repository"
+)
+
+// EnqueueDeliveriesUsecase turns a post event into one pending delivery per
+// subscribed endpoint. Only posts with ExternalNotification set are sent.
+// It runs as an outbox relay handler, so the deliveries are committed in the
+// same transaction that marks the outbox message as delivered.
+type EnqueueDeliveriesUsecase struct {
+	posts      postrepository.PostRepository
+	endpoints  repository.EndpointRepository
+	deliveries repository.DeliveryRepository
+}
+
+func NewEnqueueDeliveriesUsecase(posts postrepository.PostRepository, endpoints repository.EndpointRepository, deliveries repository.DeliveryRepository) *EnqueueDeliveriesUsecase {
+	return &EnqueueDeliveriesUsecase{posts: posts, endpoints: endpoints, deliveries: deliveries}
+}
+
+func (u *EnqueueDeliveriesUsecase) Execute(ctx context.Context, e post.PostEvent) error {
+	notify, err := u.externalNotification(ctx, e)
+	if err != nil || !notify {
+		return err
+	}
+
+	endpoints, err := u.endpoints.FindActiveByEventType(ctx, e.Type.String())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, endpoint := range endpoints {
+		delivery, err := webhook.NewDelivery(endpoint, e, now)
+		if err != nil {
+			return err
+		}
+		if err := u.deliveries.CreateIfAbsent(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// externalNotification reports whether the post behind e opted in to
+// external notification.
+func (u *EnqueueDeliveriesUsecase) externalNotification(ctx context.Context, e post.PostEvent) (bool, error) {
+	// 削除済みの投稿は取得できないため、イベントに残された状態を使う
+	if payload, ok := e.Payload.(*post.PostDeletedPayload); ok {
+		return payload.Post.ExternalNotification, nil
+	}
+
+	p, err := u.posts.FindByID(ctx, e.AggregateID)
+	if err != nil {
+		if err.Error() == "post not found" {
+			// イベント配信前に削除された投稿は、削除イベント側で通知する
+			return false, nil
+		}
+		return false, err
+	}
+
+	return p.ExternalNotification, nil
+}
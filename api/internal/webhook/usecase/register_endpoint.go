@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	postusecase "github.com/ss49919201/myblog/api/internal/post/usecase"
+	"github.com/ss49919201/myblog/api/internal/webhook/entity/webhook"
+	"github.com/ss49919201/myblog/api/internal/webhook/repository"
+)
+
+type RegisterEndpointInput struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+}
+
+type RegisterEndpointOutput struct {
+	Endpoint *webhook.Endpoint `json:"endpoint"`
+}
+
+type RegisterEndpointUsecase struct {
+	repo   repository.EndpointRepository
+	policy postusecase.Policy
+}
+
+func NewRegisterEndpointUsecase(repo repository.EndpointRepository, policy postusecase.Policy) *RegisterEndpointUsecase {
+	return &RegisterEndpointUsecase{repo: repo, policy: policy}
+}
+
+func (u *RegisterEndpointUsecase) Execute(ctx context.Context, input RegisterEndpointInput, userCtx postusecase.UserContext) (*RegisterEndpointOutput, error) {
+	if err := u.policy.Authorize(userCtx, postusecase.ActionManageWebhooks, nil); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := webhook.ConstructEndpoint(input.URL, input.Secret, input.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.repo.Create(ctx, endpoint); err != nil {
+		return nil, err
+	}
+
+	return &RegisterEndpointOutput{Endpoint: endpoint}, nil
+}
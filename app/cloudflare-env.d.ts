@@ -6,6 +6,7 @@ declare namespace Cloudflare {
     NEXTJS_ENV: string;
     ASSETS: Fetcher;
     KV_POST: KVNamespace;
+    API_BASE_URL: string;
   }
 }
 interface CloudflareEnv extends Cloudflare.Env {}
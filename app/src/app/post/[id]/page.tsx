@@ -1,5 +1,5 @@
 import { getPost } from "@/query/post";
-import { notFound } from "next/navigation";
+import { notFound, permanentRedirect } from "next/navigation";
 import Link from "next/link";
 import ReactMarkdown from "react-markdown";
 import { createLogger } from "@/logger";
@@ -15,12 +15,20 @@ export default async function PostPage({ params }: PostPageProps) {
   
   logger.info(`Rendering post page for ID: ${id}`);
   
-  const post = await getPost(id);
+  const key = decodeURIComponent(id);
+  const post = await getPost(key);
 
   if (!post) {
     logger.warn(`Post not found, returning 404: ${id}`);
     notFound();
   }
+
+  // 旧 slug で開かれた場合、API のリダイレクト先の投稿が返るので現在の URL へ移す
+  const currentKey = post.slug ?? post.id;
+  if (currentKey !== key) {
+    logger.info(`Redirecting old slug ${key} to ${currentKey}`);
+    permanentRedirect(`/post/${encodeURIComponent(currentKey)}`);
+  }
   
   logger.info(`Successfully rendered post: ${post.title}`);
 
@@ -91,7 +91,7 @@ export default function HomePage({ initialPosts, allTags }: HomePageProps) {
                 }}
               >
                 <Link
-                  href={`/post/${post.id}`}
+                  href={`/post/${encodeURIComponent(post.slug ?? post.id)}`}
                   style={{ textDecoration: "none", color: "inherit" }}
                 >
                   <h2
@@ -143,7 +143,7 @@ export default function HomePage({ initialPosts, allTags }: HomePageProps) {
                 </div>
                 <div style={{ marginTop: "1rem" }}>
                   <Link
-                    href={`/post/${post.id}`}
+                    href={`/post/${encodeURIComponent(post.slug ?? post.id)}`}
                     style={{
                       color: "#0070f3",
                       textDecoration: "none",
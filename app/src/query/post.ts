@@ -1,27 +1,39 @@
 import { getCloudflareContext } from "@opennextjs/cloudflare";
 import { createLogger } from "@/logger";
 
+// Published post as returned by the public read API (/api/public/posts).
 export type Post = {
   id: string;
   title: string;
   body: string;
   tags: string[];
+  slug: string | null;
+  publishedAt: string;
 };
 
+type PostList = {
+  items: Post[];
+  nextCursor: string | null;
+};
+
+const PAGE_LIMIT = 100;
+
+// getPost looks a published post up by its slug, or by its ID when it has no slug.
 export async function getPost(id: string): Promise<Post | null> {
   const logger = createLogger({ component: 'post.getPost' });
   
   try {
     logger.info(`Fetching post with ID: ${id}`);
-    const kv = await kvPost();
-    const got = await kv.get(id, "json");
+    const response = await fetchPublicAPI(`/public/posts/${encodeURIComponent(id)}`);
     
-    if (got) {
+    if (response.ok) {
       logger.info(`Post found: ${id}`);
-      return got as Post;
-    } else {
+      return (await response.json()) as Post;
+    } else if (response.status === 404) {
       logger.warn(`Post not found: ${id}`);
       return null;
+    } else {
+      throw new Error(`HTTP ${response.status}`);
     }
   } catch (error) {
     logger.error(`Failed to fetch post: ${id}`, { postId: id }, error as Error);
@@ -29,26 +41,35 @@ export async function getPost(id: string): Promise<Post | null> {
   }
 }
 
-export async function searchPosts(): Promise<Post[]> {
+export async function searchPosts(tag?: string): Promise<Post[]> {
   const logger = createLogger({ component: 'post.searchPosts' });
   
   try {
     logger.info('Searching for posts');
-    const kv = await kvPost();
-    const { keys } = await kv.list();
-    
-    logger.info(`Found ${keys.length} post keys`);
+    const listed: Post[] = [];
+    let cursor: string | null = null;
+
+    do {
+      const params = new URLSearchParams({ limit: String(PAGE_LIMIT) });
+      if (tag) {
+        params.set('tag', tag);
+      }
+      if (cursor) {
+        params.set('cursor', cursor);
+      }
 
-    const listed = (
-      await Promise.all(
-        keys.map(async (key) => {
-          return await getPost(key.name);
-        })
-      )
-    ).filter((v) => !!v);
+      const response = await fetchPublicAPI(`/public/posts?${params}`);
+      if (!response.ok) {
+        throw new Error(`HTTP ${response.status}`);
+      }
+
+      const page = (await response.json()) as PostList;
+      listed.push(...page.items);
+      cursor = page.nextCursor;
+    } while (cursor);
 
     logger.info(`Successfully loaded ${listed.length} posts`);
-    return listed as unknown as Post[];
+    return listed;
   } catch (error) {
     logger.error('Failed to search posts', {}, error as Error);
     return [];
@@ -60,10 +81,7 @@ export async function searchPostsByTag(tag: string): Promise<Post[]> {
   
   try {
     logger.info(`Searching for posts with tag: ${tag}`);
-    const allPosts = await searchPosts();
-    const filteredPosts = allPosts.filter(post => 
-      post.tags && post.tags.includes(tag)
-    );
+    const filteredPosts = await searchPosts(tag);
     
     logger.info(`Found ${filteredPosts.length} posts with tag: ${tag}`);
     return filteredPosts;
@@ -90,7 +108,7 @@ export async function getAllTags(): Promise<string[]> {
   }
 }
 
-async function kvPost(): Promise<CloudflareEnv["KV_POST"]> {
+async function fetchPublicAPI(path: string): Promise<Response> {
   const context = await getCloudflareContext({ async: true });
-  return context.env.KV_POST;
+  return fetch(`${context.env.API_BASE_URL}${path}`, { cache: 'no-store' });
 }
@@ -1,21 +1,154 @@
+CREATE TABLE users (
+    id BINARY(16) PRIMARY KEY DEFAULT (UUID_TO_BIN(UUID())),
+    email VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    role ENUM('general', 'editor', 'admin') NOT NULL DEFAULT 'general',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_email (email)
+);
+
 CREATE TABLE posts (
     id BINARY(16) PRIMARY KEY DEFAULT (UUID_TO_BIN(UUID())),
     title VARCHAR(100) NOT NULL,
-    body TEXT(5000) NOT NULL,
-    status ENUM('draft', 'scheduled', 'published') NOT NULL DEFAULT 'draft',
+    body VARCHAR(5000) NOT NULL,
+    status ENUM('draft', 'scheduled', 'published', 'archived') NOT NULL DEFAULT 'draft',
     scheduled_at TIMESTAMP NULL,
     category VARCHAR(50) NULL,
     tags JSON NULL,
     featured_image_url VARCHAR(500) NULL,
-    meta_description TEXT(300) NULL,
+    meta_description VARCHAR(300) NULL,
     slug VARCHAR(200) NULL,
     sns_auto_post BOOLEAN DEFAULT FALSE,
     external_notification BOOLEAN DEFAULT FALSE,
     emergency_flag BOOLEAN DEFAULT FALSE,
     created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     published_at TIMESTAMP NULL,
+    author_id BINARY(16) NULL,
+    last_editor_id BINARY(16) NULL,
+    version INT NOT NULL DEFAULT 1,
+    deleted_at TIMESTAMP NULL,
+    deleted_by BINARY(16) NULL,
+    review_status ENUM('none', 'pending', 'approved', 'rejected') NOT NULL DEFAULT 'none',
+    body_format ENUM('markdown', 'html', 'plain') NOT NULL DEFAULT 'markdown',
+    rendered_body MEDIUMTEXT NULL,
     INDEX idx_status (status),
     INDEX idx_category (category),
     INDEX idx_scheduled_at (scheduled_at),
-    UNIQUE KEY uk_slug (slug)
+    INDEX idx_status_scheduled_at (status, scheduled_at),
+    INDEX idx_author_id (author_id),
+    INDEX idx_created_at_id (created_at, id),
+    INDEX idx_published_at_id (published_at, id),
+    INDEX idx_deleted_at (deleted_at),
+    INDEX idx_review_status (review_status),
+    UNIQUE KEY uk_slug (slug),
+    FOREIGN KEY fk_posts_author (author_id) REFERENCES users (id) ON DELETE SET NULL,
+    FOREIGN KEY fk_posts_last_editor (last_editor_id) REFERENCES users (id) ON DELETE SET NULL,
+    FOREIGN KEY fk_posts_deleted_by (deleted_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE TABLE post_slug_history (
+    slug VARCHAR(200) PRIMARY KEY,
+    post_id BINARY(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tombstoned_at TIMESTAMP NULL,
+    INDEX idx_post_id (post_id)
+);
+
+CREATE TABLE post_revisions (
+    post_id BINARY(16) NOT NULL,
+    number INT NOT NULL,
+    title VARCHAR(100) NOT NULL,
+    body VARCHAR(5000) NOT NULL,
+    body_format ENUM('markdown', 'html', 'plain') NOT NULL DEFAULT 'markdown',
+    category VARCHAR(50) NULL,
+    tags JSON NULL,
+    featured_image_url VARCHAR(500) NULL,
+    meta_description VARCHAR(300) NULL,
+    slug VARCHAR(200) NULL,
+    editor_id BINARY(16) NULL,
+    restored_from INT NULL,
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (post_id, number),
+    FOREIGN KEY fk_post_revisions_post (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    FOREIGN KEY fk_post_revisions_editor (editor_id) REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE TABLE post_reviews (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    post_id BINARY(16) NOT NULL,
+    action ENUM('submit', 'approve', 'reject') NOT NULL,
+    actor_id BINARY(16) NULL,
+    comment TEXT NULL,
+    reasons JSON NOT NULL,
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    INDEX idx_post_id_created_at (post_id, created_at),
+    FOREIGN KEY fk_post_reviews_post (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    FOREIGN KEY fk_post_reviews_actor (actor_id) REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE TABLE outbox (
+    id BINARY(16) PRIMARY KEY,
+    aggregate_id BINARY(16) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSON NOT NULL,
+    status ENUM('pending', 'delivered', 'dead') NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    last_error TEXT NULL,
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    delivered_at TIMESTAMP(6) NULL,
+    INDEX idx_status_next_attempt_at (status, next_attempt_at),
+    INDEX idx_aggregate_id (aggregate_id)
+);
+
+CREATE TABLE editorial_rules (
+    scope VARCHAR(64) PRIMARY KEY,
+    rules JSON NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE webhook_endpoints (
+    id BINARY(16) PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types JSON NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE webhook_deliveries (
+    id BINARY(16) PRIMARY KEY,
+    endpoint_id BINARY(16) NOT NULL,
+    event_id BINARY(16) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    post_id BINARY(16) NOT NULL,
+    payload JSON NOT NULL,
+    status ENUM('pending', 'succeeded', 'dead') NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_status_code INT NULL,
+    last_error TEXT NULL,
+    next_attempt_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    delivered_at TIMESTAMP(6) NULL,
+    locked_until TIMESTAMP(6) NULL,
+    UNIQUE KEY uk_endpoint_event (endpoint_id, event_id),
+    INDEX idx_status_next_attempt_at (status, next_attempt_at),
+    INDEX idx_endpoint_created_at (endpoint_id, created_at),
+    FOREIGN KEY fk_webhook_deliveries_endpoint (endpoint_id) REFERENCES webhook_endpoints (id) ON DELETE CASCADE
+);
+
+CREATE TABLE sns_shares (
+    id BINARY(16) PRIMARY KEY,
+    post_id BINARY(16) NOT NULL,
+    network VARCHAR(64) NOT NULL,
+    status ENUM('pending', 'posted', 'skipped', 'dead') NOT NULL DEFAULT 'pending',
+    remote_id VARCHAR(255) NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    next_attempt_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    created_at TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    posted_at TIMESTAMP(6) NULL,
+    locked_until TIMESTAMP(6) NULL,
+    UNIQUE KEY uk_post_network (post_id, network),
+    INDEX idx_status_next_attempt_at (status, next_attempt_at)
 );
@@ -1,6 +1,6 @@
 import Link from 'next/link';
 import { serverApi } from '@/lib/api';
-import { Post } from '@/types/api';
+import { Post, postPath } from '@/types/api';
 import { Metadata } from 'next';
 import MarkdownRenderer from '@/components/MarkdownRenderer';
 
@@ -31,9 +31,7 @@ function PostCard({ post }: { post: Post }) {
     ? `${post.body.substring(0, 100)}...` 
     : post.body;
   
-  const publishDate = post.publishdAt 
-    ? `公開日: ${new Date(post.publishdAt).toLocaleDateString('ja-JP')}`
-    : '下書き';
+  const publishDate = `公開日: ${new Date(post.publishedAt).toLocaleDateString('ja-JP')}`;
 
   return (
     <article className="retro-mobile-card retro-card mb-4 sm:mb-6 hover:shadow-2xl transition-all duration-300">
@@ -48,7 +46,7 @@ function PostCard({ post }: { post: Post }) {
       
       <h2 className="retro-title text-lg xs:text-xl sm:text-2xl mb-3 sm:mb-4 hover:text-retro-orange transition-colors leading-tight">
         <Link 
-          href={`/posts/${post.id}`}
+          href={postPath(post)}
           className="block hover:translate-x-1 sm:hover:translate-x-2 transition-transform duration-200"
         >
           📄 {post.title}
@@ -63,7 +61,7 @@ function PostCard({ post }: { post: Post }) {
       
       <div className="flex justify-end">
         <Link 
-          href={`/posts/${post.id}`}
+          href={postPath(post)}
           className="retro-button text-xs sm:text-sm"
         >
           <span className="hidden xs:inline">READ MORE &gt;&gt;</span>
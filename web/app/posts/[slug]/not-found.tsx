@@ -12,7 +12,7 @@ export default function NotFound() {
           &gt; 指定された投稿は存在しません
         </div>
         <div className="retro-text text-xs sm:text-sm mb-6 sm:mb-8 opacity-70">
-          {/* 投稿IDを確認してください */}
+          {/* URLを確認してください */}
           {"// POST ID NOT EXISTS"}
         </div>
         <Link 
@@ -6,18 +6,18 @@ import { Metadata } from 'next';
 import MarkdownRenderer from '@/components/MarkdownRenderer';
 
 interface Props {
-  params: Promise<{ id: string }>;
+  params: Promise<{ slug: string }>;
 }
 
 export async function generateMetadata({ params }: Props): Promise<Metadata> {
-  const { id } = await params;
+  const { slug } = await params;
   try {
-    const post = await serverApi.getPost(id);
+    const post = await serverApi.getPost(decodeURIComponent(slug));
     return {
       title: post.title,
-      description: post.body.length > 150 
-        ? `${post.body.substring(0, 150)}...` 
-        : post.body,
+      description: post.metaDescription ?? (post.body.length > 150
+        ? `${post.body.substring(0, 150)}...`
+        : post.body),
     };
   } catch {
     return {
@@ -42,9 +42,7 @@ function BackLink() {
 }
 
 function PostHeader({ post }: { post: Post }) {
-  const publishDate = post.publishdAt 
-    ? `公開日: ${new Date(post.publishdAt).toLocaleDateString('ja-JP')}`
-    : '下書き';
+  const publishDate = `公開日: ${new Date(post.publishedAt).toLocaleDateString('ja-JP')}`;
 
   return (
     <header className="retro-mobile-card retro-card mb-6 sm:mb-8 bg-gradient-to-br from-retro-cream to-retro-yellow">
@@ -127,11 +125,11 @@ function PostActions() {
 }
 
 export default async function PostDetail({ params }: Props) {
-  const { id } = await params;
+  const { slug } = await params;
   let post: Post;
   
   try {
-    post = await serverApi.getPost(id);
+    post = await serverApi.getPost(decodeURIComponent(slug));
   } catch (error) {
     if (error instanceof Error && error.message === 'NOT_FOUND') {
       notFound();
@@ -55,26 +55,26 @@ async function serverApiRequest<T>(endpoint: string, options: RequestInit = {}):
 
 // Client-side API (for use in 'use client' components)
 export const api = {
-  // Get all posts
+  // Get published posts
   getPosts: (): Promise<PostList> => {
-    return apiRequest<PostList>('/posts');
+    return apiRequest<PostList>('/public/posts');
   },
 
-  // Get a single post by ID
-  getPost: (id: string): Promise<Post> => {
-    return apiRequest<Post>(`/posts/${id}`);
+  // Get a published post by slug, or by ID when it has no slug
+  getPost: (slug: string): Promise<Post> => {
+    return apiRequest<Post>(`/public/posts/${encodeURIComponent(slug)}`);
   },
 };
 
 // Server-side API (for use in server components)
 export const serverApi = {
-  // Get all posts
+  // Get published posts
   getPosts: (): Promise<PostList> => {
-    return serverApiRequest<PostList>('/posts');
+    return serverApiRequest<PostList>('/public/posts');
   },
 
-  // Get a single post by ID
-  getPost: (id: string): Promise<Post> => {
-    return serverApiRequest<Post>(`/posts/${id}`);
+  // Get a published post by slug, or by ID when it has no slug
+  getPost: (slug: string): Promise<Post> => {
+    return serverApiRequest<Post>(`/public/posts/${encodeURIComponent(slug)}`);
   },
 };
\ No newline at end of file
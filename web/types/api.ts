@@ -1,15 +1,28 @@
+// Shape of the public read API (/api/public/posts), which only returns
+// published posts.
 export interface Post {
   id: string;
   title: string;
   body: string;
-  publishdAt: string | null;
+  category: string;
+  tags: string[];
+  featuredImageURL: string | null;
+  metaDescription: string | null;
+  slug: string | null;
+  publishedAt: string;
 }
 
 export interface PostList {
   items: Post[];
+  nextCursor: string | null;
 }
 
 export interface ApiError {
   code: number;
   message: string;
-}
\ No newline at end of file
+}
+
+// Path segment of a post page: the slug, or the id when the post has none.
+export function postPath(post: Post): string {
+  return `/posts/${encodeURIComponent(post.slug ?? post.id)}`;
+}